@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/egor-denisov/wallet-infotecs/config"
 	"github.com/egor-denisov/wallet-infotecs/internal/app"
 )
 
+// Starts the server, the schema is migrated on start. Migrations can also be managed without the server:
+//
+//	go run cmd/app/main.go migrate up | down N | status
 func main() {
 	// Configuration
 	cfg, err := config.NewConfig()
@@ -14,6 +19,14 @@ func main() {
 		log.Fatalf("Config error: %s", err)
 	}
 
+	// Migrate
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(context.Background(), cfg, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Migrate error: %s", err)
+		}
+		return
+	}
+
 	// Run
 	app.Run(cfg)
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/app"
+)
+
+// Executes a pain.001 payment file and prints the payment status report:
+//
+//	go run cmd/import/main.go payouts.xml
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalf("Usage: %s <pain.001 file>", os.Args[0])
+	}
+
+	// Configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	file, err := os.Open(os.Args[1])
+	if err != nil {
+		log.Fatalf("File error: %s", err)
+	}
+	defer file.Close()
+
+	// Import
+	report, err := app.ImportPayments(context.Background(), cfg, file)
+	if err != nil {
+		log.Fatalf("Import error: %s", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatalf("Output error: %s", err)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/app"
+)
+
+// Rebuilds the projections of the event-sourced wallet storage from scratch:
+//
+//	go run cmd/projections/main.go
+func main() {
+	// Configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	// Rebuild
+	rebuilt, err := app.RebuildProjections(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Rebuild error: %s", err)
+	}
+
+	log.Printf("Rebuilt projections of %d wallets", rebuilt)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/app"
+)
+
+// Reconciles the balances of the wallets with the recorded transactions, exits with an error on a drift:
+//
+//	go run cmd/reconcile/main.go
+func main() {
+	// Configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	// Reconciliation
+	reconciliation, err := app.Reconcile(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Reconciliation error: %s", err)
+	}
+
+	for _, mismatch := range reconciliation.Mismatches {
+		log.Printf("Wallet %s has balance %f, expected %f (initial %f, incoming %f, outgoing %f)",
+			mismatch.WalletID, mismatch.Balance, mismatch.Expected, mismatch.InitialBalance, mismatch.Incoming, mismatch.Outgoing)
+	}
+	log.Printf("Total balance %f, total initial balance %f", reconciliation.TotalBalance, reconciliation.TotalInitialBalance)
+
+	if reconciliation.Mismatched > 0 || !reconciliation.Conserved {
+		log.Fatalf("Reconciled %d wallets: %d mismatched, money conserved: %t", reconciliation.Wallets, reconciliation.Mismatched, reconciliation.Conserved)
+	}
+	log.Printf("Reconciled %d wallets without drift", reconciliation.Wallets)
+}
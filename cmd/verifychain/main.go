@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/app"
+)
+
+// Verifies the hash chain of the transactions and the signed chain heads, exits with an error on the first broken link:
+//
+//	go run cmd/verifychain/main.go
+func main() {
+	// Configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	// Verification
+	verification, err := app.VerifyChain(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Verification error: %s", err)
+	}
+
+	if verification.Break != nil {
+		log.Fatalf("Chain is broken at transaction %d: %s", verification.Break.TransactionID, verification.Break.Reason)
+	}
+	if verification.Unchained > 0 {
+		log.Printf("%d latest transactions are not chained yet", verification.Unchained)
+	}
+	log.Printf("Verified %d transactions and %d signed chain heads", verification.Transactions, verification.Heads)
+}
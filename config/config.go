@@ -1,19 +1,40 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
 )
 
 type (
 	// Config -.
 	Config struct {
-		App        `yaml:"app"`
-		HTTP       `yaml:"http"`
-		Log        `yaml:"logger"`
-		PG         `yaml:"postgres"`
+		App            `yaml:"app"`
+		HTTP           `yaml:"http"`
+		GRPC           `yaml:"grpc"`
+		Log            `yaml:"logger"`
+		PG             `yaml:"postgres"`
+		Admin          `yaml:"admin"`
+		Promo          `yaml:"promo"`
+		Voucher        `yaml:"voucher"`
+		Gateway        `yaml:"gateway"`
+		Interest       `yaml:"interest"`
+		Balance        `yaml:"balance"`
+		Statement      `yaml:"statement"`
+		Events         `yaml:"events"`
+		Webhook        `yaml:"webhook"`
+		Outbox         `yaml:"outbox"`
+		Storage        `yaml:"storage"`
+		Reconciliation `yaml:"reconciliation"`
+		Chain          `yaml:"chain"`
+		Receipt        `yaml:"receipt"`
+		Audit          `yaml:"audit"`
 	}
 
 	// App -.
@@ -28,18 +49,122 @@ type (
 		Port string `env-required:"true" yaml:"port" env:"HTTP_PORT"`
 	}
 
+	// GRPC -.
+	GRPC struct {
+		Port string `env-required:"true" yaml:"port" env:"GRPC_PORT"`
+	}
+
 	// Log -.
 	Log struct {
 		Level string `env-required:"true" yaml:"log_level"   env:"LOG_LEVEL"`
 	}
 
-	// PG -.
+	// PG - required by the postgres and eventsourced storages, optional with others.
 	PG struct {
-		User     string `env-required:"true"  yaml:"pg_user"  env:"POSTGRES_USER"`
-		DB       string `env-required:"true" yaml:"pg_db" env:"POSTGRES_DB"`
-		Host     string `env-required:"true" yaml:"pg_host" env:"POSTGRES_HOST"`
-		Port     int    `env-required:"true" yaml:"pg_port" env:"POSTGRES_PORT"`
-		Password string `env-required:"true" yaml:"pg_password" env:"POSTGRES_PASSWORD"`
+		User     string `yaml:"pg_user"  env:"POSTGRES_USER"`
+		DB       string `yaml:"pg_db" env:"POSTGRES_DB"`
+		Host     string `yaml:"pg_host" env:"POSTGRES_HOST"`
+		Port     int    `yaml:"pg_port" env:"POSTGRES_PORT"`
+		Password string `yaml:"pg_password" env:"POSTGRES_PASSWORD"`
+	}
+
+	// Admin -.
+	Admin struct {
+		Token string `yaml:"token" env:"ADMIN_TOKEN"`
+	}
+
+	// Promo -.
+	Promo struct {
+		TTL           time.Duration `env-required:"true" yaml:"ttl"            env:"PROMO_TTL"`
+		SpendPriority []string      `env-required:"true" yaml:"spend_priority" env:"PROMO_SPEND_PRIORITY" env-separator:","`
+		SweepInterval time.Duration `env-required:"true" yaml:"sweep_interval" env:"PROMO_SWEEP_INTERVAL"`
+	}
+
+	// Voucher -.
+	Voucher struct {
+		MaxFailedAttempts int           `env-required:"true" yaml:"max_failed_attempts" env:"VOUCHER_MAX_FAILED_ATTEMPTS"`
+		AttemptsWindow    time.Duration `env-required:"true" yaml:"attempts_window"     env:"VOUCHER_ATTEMPTS_WINDOW"`
+		RefundInterval    time.Duration `env-required:"true" yaml:"refund_interval"     env:"VOUCHER_REFUND_INTERVAL"`
+	}
+
+	// Gateway -.
+	Gateway struct {
+		Provider         string        `env-required:"true" yaml:"provider"           env:"GATEWAY_PROVIDER"`
+		Secret           string        `env-required:"true" yaml:"secret"             env:"GATEWAY_SECRET"`
+		CallbackURL      string        `env-required:"true" yaml:"callback_url"       env:"GATEWAY_CALLBACK_URL"`
+		FakeDelay        time.Duration `yaml:"fake_delay"         env:"GATEWAY_FAKE_DELAY"`
+		FakeDeclineAbove float64       `yaml:"fake_decline_above" env:"GATEWAY_FAKE_DECLINE_ABOVE"`
+	}
+
+	// Interest -.
+	Interest struct {
+		SavingsRate     float64       `env-required:"true" yaml:"savings_rate"     env:"INTEREST_SAVINGS_RATE"`
+		OverdraftRate   float64       `env-required:"true" yaml:"overdraft_rate"   env:"INTEREST_OVERDRAFT_RATE"`
+		CatchUpDays     int           `env-required:"true" yaml:"catch_up_days"    env:"INTEREST_CATCH_UP_DAYS"`
+		AccrualInterval time.Duration `env-required:"true" yaml:"accrual_interval" env:"INTEREST_ACCRUAL_INTERVAL"`
+	}
+
+	// Balance -.
+	Balance struct {
+		CatchUpDays      int           `env-required:"true" yaml:"catch_up_days"     env:"BALANCE_CATCH_UP_DAYS"`
+		SnapshotInterval time.Duration `env-required:"true" yaml:"snapshot_interval" env:"BALANCE_SNAPSHOT_INTERVAL"`
+	}
+
+	// Statement -.
+	Statement struct {
+		Currency string `env-required:"true" yaml:"currency" env:"STATEMENT_CURRENCY"`
+	}
+
+	// Events -.
+	Events struct {
+		Secret string `yaml:"secret" env:"EVENTS_SECRET"`
+	}
+
+	// Webhook -.
+	Webhook struct {
+		Timeout          time.Duration `env-required:"true" yaml:"timeout"           env:"WEBHOOK_TIMEOUT"`
+		MaxAttempts      int           `env-required:"true" yaml:"max_attempts"      env:"WEBHOOK_MAX_ATTEMPTS"`
+		Backoff          time.Duration `env-required:"true" yaml:"backoff"           env:"WEBHOOK_BACKOFF"`
+		MaxBackoff       time.Duration `env-required:"true" yaml:"max_backoff"       env:"WEBHOOK_MAX_BACKOFF"`
+		DispatchInterval time.Duration `env-required:"true" yaml:"dispatch_interval" env:"WEBHOOK_DISPATCH_INTERVAL"`
+	}
+
+	// Outbox -.
+	Outbox struct {
+		Publisher     string        `env-required:"true" yaml:"publisher"      env:"OUTBOX_PUBLISHER"`
+		File          string        `yaml:"file"           env:"OUTBOX_FILE"`
+		RelayInterval time.Duration `env-required:"true" yaml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL"`
+	}
+
+	// Storage -.
+	Storage struct {
+		Wallets       string `env-required:"true" yaml:"wallets"        env:"STORAGE_WALLETS"`
+		SnapshotEvery int    `yaml:"snapshot_every" env:"STORAGE_SNAPSHOT_EVERY"`
+		SQLitePath    string `yaml:"sqlite_path"    env:"STORAGE_SQLITE_PATH"`
+	}
+
+	// Reconciliation -.
+	Reconciliation struct {
+		Interval  time.Duration `env-required:"true" yaml:"interval"   env:"RECONCILIATION_INTERVAL"`
+		BatchSize int           `env-required:"true" yaml:"batch_size" env:"RECONCILIATION_BATCH_SIZE"`
+	}
+
+	// Chain -.
+	Chain struct {
+		SigningKey      string        `yaml:"signing_key"      env:"CHAIN_SIGNING_KEY"`
+		PublishInterval time.Duration `env-required:"true" yaml:"publish_interval" env:"CHAIN_PUBLISH_INTERVAL"`
+		BatchSize       int           `env-required:"true" yaml:"batch_size"       env:"CHAIN_BATCH_SIZE"`
+	}
+
+	// Receipt -.
+	Receipt struct {
+		SigningKey string `yaml:"signing_key" env:"RECEIPT_SIGNING_KEY"`
+	}
+
+	// Audit -.
+	Audit struct {
+		Retention     time.Duration `yaml:"retention"      env:"AUDIT_RETENTION"`
+		PurgeInterval time.Duration `env-required:"true" yaml:"purge_interval" env:"AUDIT_PURGE_INTERVAL"`
 	}
 )
 
@@ -48,14 +173,72 @@ func NewConfig() (*Config, error) {
 	cfg := &Config{}
 
 	err := godotenv.Load()
-    if err != nil {
-        fmt.Println("Error loading .env file")
-    }
+	if err != nil {
+		fmt.Println("Error loading .env file")
+	}
 
 	err = cleanenv.ReadConfig("./config/config.yml", cfg)
 	if err != nil {
 		return nil, fmt.Errorf("config error: %w", err)
 	}
 
+	err = validateSpendPriority(cfg.Promo.SpendPriority)
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	_, err = cfg.Chain.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	_, err = cfg.Receipt.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	return cfg, nil
 }
+
+// validateSpendPriority - checking that each balance bucket is listed exactly once.
+func validateSpendPriority(priority []string) error {
+	seen := make(map[string]bool, len(priority))
+	for _, bucket := range priority {
+		if bucket != entity.BucketMain && bucket != entity.BucketPromo {
+			return fmt.Errorf("unknown balance bucket %q in spend priority", bucket)
+		}
+		if seen[bucket] {
+			return fmt.Errorf("balance bucket %q is listed twice in spend priority", bucket)
+		}
+		seen[bucket] = true
+	}
+	if len(seen) != 2 {
+		return fmt.Errorf("spend priority must list both %q and %q buckets", entity.BucketMain, entity.BucketPromo)
+	}
+
+	return nil
+}
+
+// PrivateKey - ed25519 key signing the chain heads, nil if it isn't configured.
+func (c Chain) PrivateKey() (ed25519.PrivateKey, error) {
+	return parseSigningKey("chain", c.SigningKey)
+}
+
+// PrivateKey - ed25519 key signing the transfer receipts, nil if it isn't configured.
+func (r Receipt) PrivateKey() (ed25519.PrivateKey, error) {
+	return parseSigningKey("receipt", r.SigningKey)
+}
+
+// parseSigningKey - ed25519 key from its base64 encoded 32 byte seed.
+func parseSigningKey(name string, key string) (ed25519.PrivateKey, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%s signing key must be a base64 encoded %d byte seed", name, ed25519.SeedSize)
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
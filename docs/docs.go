@@ -1,3 +1,4 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -14,32 +15,325 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/wallet": {
-            "post": {
-                "description": "Создает новый кошелек с уникальным ID. Идентификатор генерируется сервером.\n\nСозданный кошелек должен иметь сумму 100.0 у.е. на балансе",
+        "/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает последние записи журнала аудита, новые первыми. В журнал записываются все запросы, изменяющие состояние: создание кошелька, перевод и действия администратора. Для изменений кошельков, включая промо-начисления, выпуск ваучеров, копилки и платежи, сохраняется состояние кошелька до и после операции.\n\nЗаписи не изменяются и удаляются только по истечении срока хранения",
                 "tags": [
-                    "Wallet"
+                    "Admin"
+                ],
+                "summary": "Получение журнала аудита",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "wallet_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "client",
+                            "grpc",
+                            "unauthorized",
+                            "system"
+                        ],
+                        "type": "string",
+                        "description": "Кто выполнил операцию",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Операция",
+                        "name": "operation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID запроса",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Начало периода (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Конец периода, не включая его (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей, по умолчанию 100, не более 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Создание кошелька",
                 "responses": {
                     "200": {
-                        "description": "Кошелек создан",
+                        "description": "Записи журнала получены",
                         "schema": {
-                            "$ref": "#/definitions/entity.Wallet"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AuditEntry"
+                            }
                         }
                     },
                     "400": {
                         "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
                     }
                 }
             }
         },
-        "/wallet/{walletId}": {
+        "/admin/balance/snapshot": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Сохраняет балансы кошельков на конец указанного завершившегося дня (UTC). Повторный запуск за тот же день не изменяет сохраненные снимки.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Снимок балансов на конец дня",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "День в формате YYYY-MM-DD",
+                        "name": "day",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество новых снимков",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе или день еще не завершился"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    }
+                }
+            }
+        },
+        "/admin/chain/heads": {
             "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает периодически публикуемые вершины хэш-цепочки операций в порядке публикации. Каждая операция связана с предыдущей хэшем SHA-256 от своего содержимого и хэша предыдущей операции, поэтому вершина фиксирует всю историю до нее.\n\nВершина подписана ключом ed25519; подпись строки \"<transaction_id>:<hash>:<signed_at>\" проверяется открытым ключом. Аудиторы сохраняют вершины вне сервиса, чтобы обнаружить изменение истории после подписи",
                 "tags": [
-                    "Wallet"
+                    "Admin"
                 ],
-                "summary": "Получение текущего состояния кошелька",
+                "summary": "Получение подписанных вершин цепочки операций",
+                "responses": {
+                    "200": {
+                        "description": "Вершины цепочки получены",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.ChainHead"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/admin/interest/accrue": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Начисляет проценты по сберегательным кошелькам за указанный завершившийся день (UTC). Повторный запуск за тот же день не начисляет проценты повторно.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Начисление процентов за день",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "День в формате YYYY-MM-DD",
+                        "name": "day",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество новых начислений",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе или день еще не завершился"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    }
+                }
+            }
+        },
+        "/admin/payments/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Принимает файл ISO 20022 pain.001 и проводит каждый платеж как обычный перевод со счета плательщика (DbtrAcct/Id/Othr/Id) на счет получателя (CdtrAcct/Id/Othr/Id).\n\nФайл отклоняется целиком, если количество платежей или контрольная сумма не совпадают. Отказ по отдельному платежу не останавливает остальные, платежи с уже отправленным EndToEndId отклоняются как дубликаты",
+                "consumes": [
+                    "application/xml"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Импорт пакета платежей pain.001",
+                "parameters": [
+                    {
+                        "description": "Файл pain.001",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчет о статусе платежей",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BulkStatusReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Файл некорректен или контрольные суммы не совпадают"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    }
+                }
+            }
+        },
+        "/admin/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает результат последней сверки балансов кошельков с начальными балансами и операциями, а также проверку сохранения общей суммы средств.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Получение результата последней сверки",
+                "responses": {
+                    "200": {
+                        "description": "Результат сверки получен",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Reconciliation"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Сверка еще не проводилась"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/admin/vouchers": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Генерирует коды ваучеров фиксированного номинала. Полная стоимость партии списывается с исходного кошелька.\n\nКоды возвращаются только в ответе на этот запрос, в базе хранятся их хэши",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Выпуск партии ваучеров",
+                "parameters": [
+                    {
+                        "description": "Запрос выпуска ваучеров",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.VoucherBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ваучеры выпущены",
+                        "schema": {
+                            "$ref": "#/definitions/entity.VoucherBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе или недостаточно средств"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Исходный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/admin/wallet/{walletId}/credit-limit": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Устанавливает кредитный лимит кошелька, баланс может опускаться до -credit_limit. Лимит не может быть меньше текущей задолженности.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Изменение кредитного лимита",
                 "parameters": [
                     {
                         "type": "string",
@@ -47,28 +341,63 @@ const docTemplate = `{
                         "name": "walletId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Запрос изменения лимита",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CreditLimitRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag кошелька, лимит изменяется только если кошелек не изменился",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Лимит изменен",
                         "schema": {
                             "$ref": "#/definitions/entity.Wallet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия кошелька"
+                            }
                         }
                     },
+                    "400": {
+                        "description": "Ошибка в запросе или лимит меньше задолженности"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
                     "404": {
                         "description": "Указанный кошелек не найден"
+                    },
+                    "412": {
+                        "description": "Кошелек изменился"
                     }
                 }
             }
         },
-        "/wallet/{walletId}/history": {
+        "/admin/wallet/{walletId}/events-token": {
             "get": {
-                "description": "Возвращает историю транзакций по указанному кошельку.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает токен, с которым клиент может подписаться на события кошелька. Токен не истекает и зависит только от ID кошелька и секрета EVENTS_SECRET",
                 "tags": [
-                    "Wallet"
+                    "Admin"
                 ],
-                "summary": "Получение историй входящих и исходящих транзакций",
+                "summary": "Выпуск токена подписки на события кошелька",
                 "parameters": [
                     {
                         "type": "string",
@@ -80,26 +409,29 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "История транзакций получена",
+                        "description": "Токен выпущен",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.Transaction"
-                            }
+                            "$ref": "#/definitions/entity.EventSubscription"
                         }
                     },
-                    "404": {
-                        "description": "Указанный кошелек не найден"
+                    "401": {
+                        "description": "Требуется токен администратора"
                     }
                 }
             }
         },
-        "/wallet/{walletId}/send": {
+        "/admin/wallet/{walletId}/promo": {
             "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Начисляет кошельку бонусные средства, которые сгорают по истечении срока действия.\n\nПромо-баланс расходуется в порядке, заданном в конфигурации",
                 "tags": [
-                    "Wallet"
+                    "Admin"
                 ],
-                "summary": "Перевод средств с одного кошелька на другой",
+                "summary": "Начисление промо-баланса",
                 "parameters": [
                     {
                         "type": "string",
@@ -109,58 +441,1834 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Запрос перевода средств",
+                        "description": "Запрос начисления",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.TransactionRequest"
+                            "$ref": "#/definitions/entity.PromoGrantRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Перевод успешно проведен"
+                        "description": "Промо-баланс начислен",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PromoGrant"
+                        }
                     },
                     "400": {
-                        "description": "Ошибка в пользовательском запросе или ошибка перевода"
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
                     },
                     "404": {
-                        "description": "Исходящий кошелек не найден"
+                        "description": "Указанный кошелек не найден"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Transaction": {
-            "description": "Денежный перевод",
-            "type": "object",
-            "required": [
-                "amount",
-                "from",
-                "time",
-                "to"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "number",
-                    "format": "float",
-                    "minimum": 0,
-                    "example": 30
-                },
-                "from": {
-                    "type": "string",
-                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
-                },
-                "time": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2024-02-04T17:25:35.448Z"
-                },
-                "to": {
-                    "type": "string",
-                    "example": "eb376add88bf8e70f80787266a0801d5"
+        },
+        "/admin/wallet/{walletId}/status": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Замораживает, размораживает или закрывает кошелек вместе с его копилками. Замороженные и закрытые кошельки не участвуют в переводах.\n\nПри закрытии средства копилок возвращаются в кошелек, итоговый баланс должен быть нулевым. Закрытый кошелек нельзя открыть снова",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Изменение статуса кошелька",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос изменения статуса",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WalletStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag кошелька, статус изменяется только если кошелек не изменился",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статус изменен",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Wallet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия кошелька"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе, кошелек закрыт или его баланс не нулевой"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    },
+                    "412": {
+                        "description": "Кошелек изменился"
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает подписки внешних систем на события кошелька без их секретов",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Получение подписок кошелька",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "wallet_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписки получены",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Не указан кошелек"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Подписывает внешнюю систему на события кошелька. События отправляются POST-запросом на указанный адрес; неудачные попытки повторяются с экспоненциальной задержкой.\n\nЗапрос подписывается заголовком X-Webhook-Signature: HMAC-SHA256 строки \"<X-Webhook-Timestamp>.<тело запроса>\" с секретом подписки в hex. Секрет возвращается только в ответе на этот запрос",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Создание подписки на события кошелька",
+                "parameters": [
+                    {
+                        "description": "Запрос создания подписки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка создана",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{webhookId}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Удаляет подписку вместе с историей доставок, неотправленные события не доставляются",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Удаление подписки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID подписки",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка удалена"
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Подписка не найдена"
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает доставки событий по подписке, начиная с последних. Доставки в статусе failed исчерпали попытки и могут быть отправлены повторно",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Получение доставок по подписке",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID подписки",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус доставки (pending, delivered, failed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Доставки получены",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Подписка не найдена"
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{webhookId}/deliveries/{deliveryId}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Возвращает доставку в статусе failed в очередь с новым счетчиком попыток",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Повторная отправка доставки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID подписки",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID доставки",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Доставка поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Требуется токен администратора"
+                    },
+                    "404": {
+                        "description": "Доставка не найдена"
+                    },
+                    "409": {
+                        "description": "Доставка не находится в статусе failed"
+                    }
+                }
+            }
+        },
+        "/payments/callback": {
+            "post": {
+                "description": "Принимает подписанное уведомление о результате платежа. Повторные уведомления с тем же статусом игнорируются.",
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Уведомление платежного шлюза",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Подпись уведомления",
+                        "name": "X-Gateway-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Уведомление",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PaymentNotification"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Уведомление обработано",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в уведомлении или кошелек заморожен или закрыт"
+                    },
+                    "401": {
+                        "description": "Неверная подпись"
+                    },
+                    "404": {
+                        "description": "Платеж не найден"
+                    },
+                    "409": {
+                        "description": "Платеж уже завершен с другим статусом"
+                    }
+                }
+            }
+        },
+        "/payments/{paymentId}": {
+            "get": {
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Получение состояния платежа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID платежа",
+                        "name": "paymentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "404": {
+                        "description": "Платеж не найден"
+                    }
+                }
+            }
+        },
+        "/receipts/key": {
+            "get": {
+                "description": "Возвращает открытый ключ ed25519, которым проверяется подпись строки \"receipt:<transaction_id>:<from>:<to>:<amount>:<time>\" квитанции. Сумма записывается в кратчайшей десятичной форме, время - в RFC 3339 в UTC",
+                "tags": [
+                    "Receipt"
+                ],
+                "summary": "Получение открытого ключа квитанций",
+                "responses": {
+                    "200": {
+                        "description": "Ключ получен",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ReceiptKey"
+                        }
+                    },
+                    "404": {
+                        "description": "Сервер не выдает квитанции"
+                    }
+                }
+            }
+        },
+        "/receipts/verify": {
+            "post": {
+                "description": "Проверяет, что квитанция подписана текущим ключом сервера и не изменена. Квитанцию можно проверить и без сервера открытым ключом из /receipts/key",
+                "tags": [
+                    "Receipt"
+                ],
+                "summary": "Проверка квитанции о переводе",
+                "parameters": [
+                    {
+                        "description": "Квитанция о переводе",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Receipt"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Квитанция проверена",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ReceiptVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "404": {
+                        "description": "Сервер не выдает квитанции"
+                    }
+                }
+            }
+        },
+        "/transaction/{transactionId}/receipt": {
+            "get": {
+                "description": "Возвращает подписанную квитанцию о переводе для одного из его участников. Перевод другого кошелька не будет найден",
+                "tags": [
+                    "Receipt"
+                ],
+                "summary": "Получение квитанции о переводе",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID перевода",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID исходящего или входящего кошелька перевода",
+                        "name": "wallet_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Квитанция получена",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Receipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "404": {
+                        "description": "Перевод не найден или сервер не выдает квитанции"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/wallet": {
+            "post": {
+                "description": "Создает новый кошелек с уникальным ID. Идентификатор генерируется сервером.\n\nСозданный кошелек должен иметь сумму 100.0 у.е. на балансе\n\nТело запроса необязательно, при его отсутствии создается обычный кошелек. Сберегательный кошелек получает текущую процентную ставку",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Создание кошелька",
+                "parameters": [
+                    {
+                        "description": "Запрос создания кошелька",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/entity.CreateWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Кошелек создан",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}": {
+            "get": {
+                "description": "Возвращает баланс кошелька с разбивкой на основную и промо-часть, а также предстоящие сгорания промо-баланса.\n\nДля кошельков с кредитным лимитом возвращается доступный остаток лимита\n\nДля кошельков с копилками возвращается список копилок и общий баланс\n\nВерсия кошелька возвращается в заголовке ETag, она меняется при каждом изменении баланса, кредитного лимита или статуса",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Получение текущего состояния кошелька",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag кошелька, полученный ранее",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Wallet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия кошелька"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Кошелек не изменился"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/balance": {
+            "get": {
+                "description": "Возвращает баланс кошелька на указанный момент. Баланс вычисляется от ближайшего снимка на конец дня с учетом последующих операций.",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Получение баланса на момент времени",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Момент времени в формате RFC 3339",
+                        "name": "at",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Баланс получен",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BalanceAt"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в формате момента времени"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/deposit": {
+            "post": {
+                "description": "Создает платеж в статусе pending. Кошелек пополняется после подтверждения платежа шлюзом.",
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Пополнение кошелька через платежный шлюз",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос пополнения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Платеж создан",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе, кошелек заморожен или закрыт, ошибка платежного шлюза"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/events": {
+            "get": {
+                "description": "Передает события кошелька в формате Server-Sent Events сразу после проведения операций: входящие и исходящие переводы и прочие изменения баланса.\n\nТокен подписки передается в заголовке Authorization: Bearer или в параметре token. Поле id события SSE содержит его номер sequence. После переподключения события продолжаются с события, следующего за Last-Event-ID",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Подписка на события кошелька (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен подписки",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер (sequence) последнего полученного события",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер (sequence) последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WalletEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Неверный токен подписки"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/events/ws": {
+            "get": {
+                "description": "Передает события кошелька в виде JSON-сообщений WebSocket сразу после проведения операций.\n\nТокен подписки передается в заголовке Authorization: Bearer или в параметре token. После переподключения события продолжаются с события, следующего за номером last_event_id (поле sequence события)",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Подписка на события кошелька (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен подписки",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер (sequence) последнего полученного события",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Соединение WebSocket установлено",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WalletEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "401": {
+                        "description": "Неверный токен подписки"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/history": {
+            "get": {
+                "description": "Возвращает историю транзакций по указанному кошельку.\n\nПри указании external_reference возвращаются только переводы с данным внешним идентификатором\n\nПри include_pockets=true в историю включаются операции копилок кошелька",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Получение историй входящих и исходящих транзакций",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Внешний идентификатор перевода",
+                        "name": "external_reference",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить операции копилок",
+                        "name": "include_pockets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История транзакций получена",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Transaction"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/interest": {
+            "get": {
+                "description": "Возвращает ежедневные начисления процентов по сберегательному кошельку. Начисления зачисляются на баланс раз в месяц.",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Получение начислений процентов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Начисления получены",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.InterestAccrual"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/pockets": {
+            "post": {
+                "description": "Создает пустую копилку под указанным кошельком. Баланс копилки учитывается в общем балансе кошелька.",
+                "tags": [
+                    "Pocket"
+                ],
+                "summary": "Создание копилки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос создания копилки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PocketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Копилка создана",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе или кошелек заморожен"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/pockets/{pocketId}": {
+            "delete": {
+                "description": "Закрывает копилку, остаток средств возвращается в кошелек.",
+                "tags": [
+                    "Pocket"
+                ],
+                "summary": "Закрытие копилки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID копилки",
+                        "name": "pocketId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Копилка закрыта"
+                    },
+                    "400": {
+                        "description": "Кошелек или копилка заморожены или уже закрыты"
+                    },
+                    "404": {
+                        "description": "Кошелек или копилка не найдены"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/pockets/{pocketId}/move": {
+            "post": {
+                "description": "Перемещает средства из кошелька в копилку (in) или обратно (out).",
+                "tags": [
+                    "Pocket"
+                ],
+                "summary": "Перемещение средств между кошельком и копилкой",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID копилки",
+                        "name": "pocketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос перемещения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PocketTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Средства перемещены",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе или недостаточно средств"
+                    },
+                    "404": {
+                        "description": "Кошелек или копилка не найдены"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/redeem": {
+            "post": {
+                "description": "Зачисляет номинал ваучера на кошелек. Количество неудачных попыток активации для кошелька ограничено.",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Активация ваучера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос активации ваучера",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RedeemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ваучер активирован",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный, просроченный или уже использованный код, кошелек заморожен или закрыт"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    },
+                    "429": {
+                        "description": "Слишком много неудачных попыток"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/send": {
+            "post": {
+                "description": "Возвращает подписанную квитанцию о переводе, если сервер выдает квитанции",
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Перевод средств с одного кошелька на другой",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос перевода средств",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TransactionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag исходящего кошелька, перевод проводится только если кошелек не изменился",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перевод успешно проведен",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Receipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в пользовательском запросе или ошибка перевода"
+                    },
+                    "404": {
+                        "description": "Исходящий кошелек не найден"
+                    },
+                    "412": {
+                        "description": "Кошелек изменился"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/statement": {
+            "get": {
+                "description": "Возвращает входящий остаток, операции с остатком после каждой из них и исходящий остаток за период.\n\nПериод задается датами в UTC включительно, по умолчанию - текущий месяц.\n\nФорматы: json, csv, pdf, camt053 (ISO 20022 camt.053.001.02) и ofx (OFX 2.2) для импорта в учетные системы",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/pdf",
+                    "application/xml",
+                    "application/x-ofx"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Получение выписки по кошельку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Первый день периода в формате YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода в формате YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат выписки (json, csv, pdf, camt053, ofx)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Выписка получена",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Statement"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        },
+        "/wallet/{walletId}/withdraw": {
+            "post": {
+                "description": "Создает платеж в статусе pending и резервирует средства. Если шлюз отклонит платеж, средства вернутся на кошелек.",
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Вывод средств через платежный шлюз",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID кошелька",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запрос вывода средств",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Платеж создан",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе, недостаточно средств или ошибка платежного шлюза"
+                    },
+                    "404": {
+                        "description": "Указанный кошелек не найден"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AuditEntry": {
+            "description": "Запись журнала аудита",
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "after": {
+                    "$ref": "#/definitions/entity.Wallet"
+                },
+                "before": {
+                    "$ref": "#/definitions/entity.Wallet"
+                },
+                "client_ip": {
+                    "type": "string",
+                    "example": "192.168.0.10"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "operation": {
+                    "type": "string",
+                    "example": "set_credit_limit"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "time": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.BalanceAt": {
+            "description": "Баланс кошелька на момент времени",
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2023-12-31T23:59:59Z"
+                },
+                "balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 250
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.BalanceBucket": {
+            "description": "Часть баланса кошелька",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 20
+                },
+                "name": {
+                    "type": "string",
+                    "example": "promo"
+                }
+            }
+        },
+        "entity.BalanceMismatch": {
+            "description": "Расхождение баланса кошелька с операциями",
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 130
+                },
+                "difference": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 10
+                },
+                "expected": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 120
+                },
+                "incoming": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 50
+                },
+                "initial_balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 100
+                },
+                "outgoing": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 30
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.BulkInstructionStatus": {
+            "description": "Статус платежа из файла",
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string",
+                    "example": "insufficient funds"
+                },
+                "end_to_end_id": {
+                    "type": "string",
+                    "example": "INV-2024-0042"
+                },
+                "instruction_id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "payment_info_id": {
+                    "type": "string",
+                    "example": "BATCH-1"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "AM04"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "RJCT"
+                }
+            }
+        },
+        "entity.BulkStatusReport": {
+            "description": "Отчет о статусе платежей по формату pain.002",
+            "type": "object",
+            "properties": {
+                "group_status": {
+                    "type": "string",
+                    "example": "PART"
+                },
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BulkInstructionStatus"
+                    }
+                },
+                "original_message_id": {
+                    "type": "string",
+                    "example": "PAYOUTS-2024-02-29"
+                }
+            }
+        },
+        "entity.ChainHead": {
+            "description": "Подписанная вершина цепочки операций",
+            "type": "object",
+            "properties": {
+                "hash": {
+                    "type": "string",
+                    "example": "5f2b7e3c0a9d4e18b6c1f0a2d3e4b5c6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "public_key": {
+                    "type": "string",
+                    "example": "dGVzdC1wdWJsaWMta2V5LXRlc3QtcHVibGljLWtleSE="
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "c2lnbmF0dXJl"
+                },
+                "signed_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-05T03:00:00Z"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1024
+                }
+            }
+        },
+        "entity.CreateWalletRequest": {
+            "description": "Запрос создания кошелька",
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "example": "savings"
+                }
+            }
+        },
+        "entity.CreditLimitRequest": {
+            "description": "Запрос изменения кредитного лимита",
+            "type": "object",
+            "properties": {
+                "credit_limit": {
+                    "type": "number",
+                    "format": "float",
+                    "minimum": 0,
+                    "example": 500
+                }
+            }
+        },
+        "entity.EventSubscription": {
+            "description": "Токен подписки на события кошелька",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.InterestAccrual": {
+            "description": "Начисление процентов за день. Отрицательная сумма - проценты за использование кредитного лимита",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 0.136986
+                },
+                "annual_rate": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 0.05
+                },
+                "balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 1000
+                },
+                "capitalized_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-03-01T00:05:00Z"
+                },
+                "day": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T00:00:00Z"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.Payment": {
+            "description": "Пополнение или вывод средств через платежный шлюз",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 50
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "gateway_reference": {
+                    "type": "string",
+                    "example": "fake-3f2a9c1e"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "deposit"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.PaymentNotification": {
+            "type": "object",
+            "properties": {
+                "gateway_reference": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PaymentRequest": {
+            "description": "Запрос пополнения или вывода средств",
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "minimum": 0,
+                    "example": 50
+                }
+            }
+        },
+        "entity.PocketRequest": {
+            "description": "Запрос создания копилки",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Отпуск"
+                }
+            }
+        },
+        "entity.PocketTransferRequest": {
+            "description": "Запрос перемещения средств между кошельком и копилкой",
+            "type": "object",
+            "required": [
+                "amount",
+                "direction"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "in"
+                }
+            }
+        },
+        "entity.PromoExpiration": {
+            "description": "Предстоящее сгорание промо-баланса",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 20
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-03-05T17:25:35.448Z"
+                }
+            }
+        },
+        "entity.PromoGrant": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 50
+                },
+                "expired_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-03-05T17:30:00.000Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-03-05T17:25:35.448Z"
+                },
+                "granted_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "remaining": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 20
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.PromoGrantRequest": {
+            "description": "Запрос начисления промо-баланса",
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "minimum": 0,
+                    "example": 50
+                }
+            }
+        },
+        "entity.Receipt": {
+            "description": "Подписанная квитанция о переводе",
+            "type": "object",
+            "required": [
+                "amount",
+                "from",
+                "public_key",
+                "signature",
+                "time",
+                "to",
+                "transaction_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 30
+                },
+                "from": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                },
+                "public_key": {
+                    "type": "string",
+                    "example": "dGVzdC1wdWJsaWMta2V5LXRlc3QtcHVibGljLWtleSE="
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "c2lnbmF0dXJl"
+                },
+                "time": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "eb376add88bf8e70f80787266a0801d5"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1024
+                }
+            }
+        },
+        "entity.ReceiptKey": {
+            "description": "Открытый ключ для проверки квитанций",
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "example": "ed25519"
+                },
+                "public_key": {
+                    "type": "string",
+                    "example": "dGVzdC1wdWJsaWMta2V5LXRlc3QtcHVibGljLWtleSE="
+                }
+            }
+        },
+        "entity.ReceiptVerification": {
+            "description": "Результат проверки квитанции",
+            "type": "object",
+            "properties": {
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "entity.Reconciliation": {
+            "description": "Результат сверки балансов кошельков с операциями",
+            "type": "object",
+            "properties": {
+                "conserved": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "finished_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-05T03:02:15Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "mismatched": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "mismatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BalanceMismatch"
+                    }
+                },
+                "started_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-05T03:00:00Z"
+                },
+                "total_balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 100000000
+                },
+                "total_initial_balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 100000000
+                },
+                "wallets": {
+                    "type": "integer",
+                    "example": 1000000
+                }
+            }
+        },
+        "entity.RedeemRequest": {
+            "description": "Запрос активации ваучера",
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "K7QF-9M2X-PLT4-ZC8N"
+                }
+            }
+        },
+        "entity.Statement": {
+            "description": "Выписка по кошельку за период",
+            "type": "object",
+            "properties": {
+                "closing_balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 70
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "XXX"
+                },
+                "from": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "generated_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-03-01T00:05:00Z"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.StatementLine"
+                    }
+                },
+                "opening_balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 100
+                },
+                "to": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.StatementLine": {
+            "description": "Операция в выписке",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": -30
+                },
+                "balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 70
+                },
+                "counterparty": {
+                    "type": "string",
+                    "example": "eb376add88bf8e70f80787266a0801d5"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Оплата по счету №42"
+                },
+                "external_reference": {
+                    "type": "string",
+                    "example": "INV-2024-0042"
+                },
+                "time": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "transfer"
+                }
+            }
+        },
+        "entity.Transaction": {
+            "description": "Денежный перевод",
+            "type": "object",
+            "required": [
+                "amount",
+                "from",
+                "time",
+                "to"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Оплата по счету №42"
+                },
+                "external_reference": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "INV-2024-0042"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                },
+                "time": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "eb376add88bf8e70f80787266a0801d5"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "transfer"
                 }
             }
         },
@@ -178,12 +2286,98 @@ const docTemplate = `{
                     "minimum": 0,
                     "example": 100
                 },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Оплата по счету №42"
+                },
+                "external_reference": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "INV-2024-0042"
+                },
                 "to": {
                     "type": "string",
                     "example": "eb376add88bf8e70f80787266a0801d5"
                 }
             }
         },
+        "entity.VoucherBatch": {
+            "description": "Выпущенная партия ваучеров",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 10
+                },
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "K7QF-9M2X-PLT4-ZC8N"
+                    ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "source_wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.VoucherBatchRequest": {
+            "description": "Запрос выпуска ваучеров",
+            "type": "object",
+            "required": [
+                "amount",
+                "count",
+                "expires_at",
+                "max_redemptions",
+                "source_wallet_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "count": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1,
+                    "example": 100
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "source_wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
         "entity.Wallet": {
             "description": "Состояние кошелька",
             "type": "object",
@@ -192,18 +2386,262 @@ const docTemplate = `{
                 "id"
             ],
             "properties": {
+                "annual_rate": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 0.05
+                },
+                "available_credit": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 350
+                },
                 "balance": {
                     "type": "number",
                     "format": "float",
-                    "minimum": 0,
                     "example": 100
                 },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BalanceBucket"
+                    }
+                },
+                "credit_limit": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 500
+                },
+                "expirations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PromoExpiration"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                },
+                "interest_debt": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 12.5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Отпуск"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "eb376add88bf8e70f80787266a0801d5"
+                },
+                "pockets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Wallet"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "total_balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 250
+                },
+                "type": {
+                    "type": "string",
+                    "example": "savings"
+                }
+            }
+        },
+        "entity.WalletEvent": {
+            "description": "Событие кошелька",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 30
+                },
+                "balance": {
+                    "type": "number",
+                    "format": "float",
+                    "example": 130
+                },
+                "counterparty": {
+                    "type": "string",
+                    "example": "eb376add88bf8e70f80787266a0801d5"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "sequence": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "time": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "transaction_type": {
+                    "type": "string",
+                    "example": "transfer"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "incoming_transfer"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.WalletStatusRequest": {
+            "description": "Запрос изменения статуса кошелька",
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "frozen"
+                }
+            }
+        },
+        "entity.Webhook": {
+            "description": "Подписка внешней системы на события кошелька",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "incoming_transfer"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example/hooks/wallet"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "5b53700ed469fa6a09ea72bb78f36fd9"
+                }
+            }
+        },
+        "entity.WebhookDelivery": {
+            "description": "Доставка события по подписке",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:36.448Z"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "incoming_transfer"
+                },
                 "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status 503"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-02-04T17:25:35.448Z"
+                },
+                "payload": {
+                    "type": "string",
+                    "example": "{\"id\":42}"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "entity.WebhookRequest": {
+            "description": "Запрос создания подписки на события кошелька",
+            "type": "object",
+            "required": [
+                "url",
+                "wallet_id"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "incoming_transfer"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example/hooks/wallet"
+                },
+                "wallet_id": {
                     "type": "string",
                     "example": "5b53700ed469fa6a09ea72bb78f36fd9"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -1,44 +1,157 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"os"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/egor-denisov/wallet-infotecs/config"
+	grpcserver "github.com/egor-denisov/wallet-infotecs/internal/controller/grpc"
 	v1 "github.com/egor-denisov/wallet-infotecs/internal/controller/http/v1"
+	"github.com/egor-denisov/wallet-infotecs/internal/publisher"
 	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
 	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
 	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
 	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+	"github.com/egor-denisov/wallet-infotecs/pkg/scheduler"
 )
 
 // Run creates objects via constructors.
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 
-	// Connect postgres db
-	pg, err := postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
-	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
+	// Connect postgres db and migrate its schema, only if the wallet storage or the audit log keeps data there
+	var pg *postgres.Postgres
+	if needsPostgres(cfg) {
+		var err error
+		pg, err = postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
+		}
+		defer pg.DB.Close()
+
+		migrations, err := postgres.LoadMigrations(os.DirFS(migrationsDir))
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - postgres.LoadMigrations: %w", err))
+		}
+		applied, err := pg.MigrateUp(context.Background(), migrations)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - pg.MigrateUp: %w", err))
+		}
+		for _, migration := range applied {
+			l.Info("app - Run - applied migration %d_%s", migration.Version, migration.Name)
+		}
+	}
+
+	// Domain events publisher
+	var eventPublisher usecase.EventPublisher
+	switch cfg.Outbox.Publisher {
+	case "memory":
+		eventPublisher = publisher.NewMemory()
+	case "file":
+		file, err := publisher.NewFile(cfg.Outbox.File)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - publisher.NewFile: %w", err))
+		}
+		defer file.Close()
+		eventPublisher = file
+	default:
+		l.Fatal(fmt.Errorf("app - Run - unknown domain events publisher %q", cfg.Outbox.Publisher))
 	}
-	// Migrate database schema
-	err = pg.Migrate("./migrations/up.sql");
+
+	// Wallet storage
+	walletRepo, outboxRepo, err := newWalletRepo(context.Background(), cfg, pg)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - pg.Migrate: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - newWalletRepo: %w", err))
 	}
-	defer pg.DB.Close()
 
-	// Use case
-	walletUseCase := usecase.New(
-		repo.NewWalletRepo(pg),
+	// Background jobs
+	jobs := scheduler.New(l)
+	defer jobs.Stop()
+
+	// Use cases, changes of the wallets and admin actions are recorded in the audit log kept in postgres
+	var walletUseCase usecase.Wallet = usecase.New(
+		walletRepo,
+		outboxRepo,
 		cfg.App.DefaultBalance,
+		cfg.Interest.SavingsRate,
+	)
+	var (
+		auditRepo    usecase.AuditRepo
+		auditUseCase usecase.Audit
+	)
+	if pg != nil {
+		auditRepo = repo.NewAuditRepo(pg)
+		if auditedStorage(cfg) {
+			walletUseCase = usecase.NewAuditedWallet(walletUseCase, auditRepo)
+		}
+		audit := usecase.NewAudit(
+			auditRepo,
+			cfg.Audit.Retention,
+		)
+		auditUseCase = audit
+
+		jobs.Every("audit retention", cfg.Audit.PurgeInterval, func(ctx context.Context) error {
+			deleted, err := audit.PurgeExpired(ctx)
+			if deleted > 0 {
+				l.Info("app - audit retention - deleted %d audit log entries", deleted)
+			}
+			return err
+		})
+	}
+	statementUseCase := usecase.NewStatement(
+		walletRepo,
+		cfg.Statement.Currency,
+	)
+	bulkUseCase := usecase.NewBulk(
+		walletUseCase,
+		cfg.Statement.Currency,
 	)
+	outboxUseCase := usecase.NewOutbox(
+		outboxRepo,
+		eventPublisher,
+	)
+
+	jobs.Every("outbox relay", cfg.Outbox.RelayInterval, func(ctx context.Context) error {
+		published, err := outboxUseCase.RelayEvents(ctx)
+		if published > 0 {
+			l.Info("app - outbox relay - published %d domain events", published)
+		}
+		return err
+	})
+
+	// Features working with the wallets table, their routes and jobs are disabled with other storages
+	features := postgresFeatures{}
+	if postgresStorage(cfg) {
+		eventsCtx, stopEvents := context.WithCancel(context.Background())
+		defer stopEvents()
+
+		features = newPostgresFeatures(eventsCtx, cfg, l, pg, walletRepo, outboxRepo, walletUseCase, auditRepo, jobs)
+	} else {
+		l.Info("app - Run - promo grants, vouchers, payments, pockets, interest, balance snapshots, wallet events, webhooks, reconciliation, the transaction chain and receipts are disabled with the %s storage", cfg.Storage.Wallets)
+	}
+
+	// gRPC Server
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - net.Listen: %w", err))
+	}
+	grpcServer := grpcserver.NewServer(l, walletUseCase)
+	defer grpcServer.GracefulStop()
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			l.Error(fmt.Errorf("app - Run - grpcServer.Serve: %w", err))
+		}
+	}()
 
 	// HTTP Server
 	httpServer := gin.New()
-	v1.NewRouter(httpServer, l, walletUseCase)
-	
+	v1.NewRouter(httpServer, l, walletUseCase, features.promo, features.voucher, features.payment, features.interest, features.balance, features.pocket, statementUseCase, bulkUseCase, features.event, features.webhook, features.reconciliation, features.chain, features.receipt, auditUseCase, cfg.Admin.Token)
+
 	httpServer.Run(fmt.Sprintf(":%s", cfg.HTTP.Port))
-	
+
 }
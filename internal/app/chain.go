@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// VerifyChain walks the hash chain of the transactions and checks the signed chain heads with the configured key.
+// The database schema is expected to be migrated by the server.
+func VerifyChain(ctx context.Context, cfg *config.Config) (*entity.ChainVerification, error) {
+	chainKey, err := cfg.Chain.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("app - VerifyChain - cfg.Chain.PrivateKey: %w", err)
+	}
+
+	// Connect postgres db
+	pg, err := postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
+	if err != nil {
+		return nil, fmt.Errorf("app - VerifyChain - postgres.New: %w", err)
+	}
+	defer pg.DB.Close()
+
+	return usecase.NewChain(repo.NewChainRepo(pg), chainKey, cfg.Chain.BatchSize).VerifyChain(ctx)
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/gateway"
+	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/internal/webhook"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+	"github.com/egor-denisov/wallet-infotecs/pkg/scheduler"
+)
+
+// postgresFeatures - use cases working with the wallets table of the postgres storage.
+// With other storages they are nil and their routes are not registered.
+type postgresFeatures struct {
+	promo          usecase.Promo
+	voucher        usecase.Voucher
+	payment        usecase.Payment
+	interest       usecase.Interest
+	balance        usecase.Balance
+	pocket         usecase.Pocket
+	event          usecase.Event
+	webhook        usecase.Webhook
+	reconciliation usecase.Reconciliation
+	chain          usecase.Chain
+	receipt        usecase.Receipt
+}
+
+// newPostgresFeatures - creating the use cases and scheduling their jobs. Their domain events are stored in the outbox
+// of the wallets. Wallet events committed by all app instances are delivered to the subscribers until the context is done.
+func newPostgresFeatures(ctx context.Context, cfg *config.Config, l logger.Interface, pg *postgres.Postgres,
+	walletRepo usecase.WalletRepo, outboxRepo usecase.OutboxRepo, walletUseCase usecase.Wallet, auditRepo usecase.AuditRepo,
+	jobs *scheduler.Scheduler) postgresFeatures {
+	promoUseCase := usecase.NewAuditedPromo(
+		usecase.NewPromo(
+			repo.NewPromoRepo(pg),
+			outboxRepo,
+			cfg.Promo.TTL,
+		),
+		walletUseCase,
+		auditRepo,
+	)
+	voucherUseCase := usecase.NewAuditedVoucher(
+		usecase.NewVoucher(
+			repo.NewVoucherRepo(pg, cfg.Promo.SpendPriority),
+			outboxRepo,
+			cfg.Voucher.MaxFailedAttempts,
+			cfg.Voucher.AttemptsWindow,
+		),
+		walletUseCase,
+		auditRepo,
+	)
+
+	// Payment gateway
+	var paymentGateway usecase.PaymentGateway
+	switch cfg.Gateway.Provider {
+	case "fake":
+		paymentGateway = gateway.NewFake(cfg.Gateway.Secret, cfg.Gateway.CallbackURL, cfg.Gateway.FakeDelay, cfg.Gateway.FakeDeclineAbove, l)
+	default:
+		l.Fatal(fmt.Errorf("app - newPostgresFeatures - unknown payment gateway provider %q", cfg.Gateway.Provider))
+	}
+	paymentUseCase := usecase.NewAuditedPayment(
+		usecase.NewPayment(
+			repo.NewPaymentRepo(pg, cfg.Promo.SpendPriority),
+			outboxRepo,
+			paymentGateway,
+		),
+		walletUseCase,
+		auditRepo,
+	)
+	interestUseCase := usecase.NewInterest(
+		repo.NewInterestRepo(pg),
+		outboxRepo,
+		cfg.Interest.CatchUpDays,
+		cfg.Interest.OverdraftRate,
+	)
+	balanceUseCase := usecase.NewBalance(
+		repo.NewBalanceRepo(pg),
+		walletRepo,
+		cfg.Balance.CatchUpDays,
+	)
+	pocketUseCase := usecase.NewAuditedPocket(
+		usecase.NewPocket(
+			repo.NewPocketRepo(pg, cfg.Promo.SpendPriority),
+			outboxRepo,
+		),
+		walletUseCase,
+		auditRepo,
+	)
+	eventUseCase := usecase.NewEvent(
+		repo.NewEventRepo(pg),
+		cfg.Events.Secret,
+	)
+	webhookUseCase := usecase.NewAuditedWebhook(
+		usecase.NewWebhook(
+			repo.NewWebhookRepo(pg),
+			webhook.NewSender(),
+			cfg.Webhook.Timeout,
+			cfg.Webhook.MaxAttempts,
+			cfg.Webhook.Backoff,
+			cfg.Webhook.MaxBackoff,
+		),
+		auditRepo,
+	)
+	reconciliationUseCase := usecase.NewReconciliation(
+		repo.NewReconciliationRepo(pg),
+		cfg.Reconciliation.BatchSize,
+		cfg.App.DefaultBalance,
+	)
+	chainKey, err := cfg.Chain.PrivateKey()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - newPostgresFeatures - cfg.Chain.PrivateKey: %w", err))
+	}
+	chainUseCase := usecase.NewChain(
+		repo.NewChainRepo(pg),
+		chainKey,
+		cfg.Chain.BatchSize,
+	)
+	receiptKey, err := cfg.Receipt.PrivateKey()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - newPostgresFeatures - cfg.Receipt.PrivateKey: %w", err))
+	}
+	receiptUseCase := usecase.NewReceipt(
+		repo.NewReceiptRepo(pg),
+		receiptKey,
+	)
+
+	// Background jobs
+	jobs.Every("promo sweeper", cfg.Promo.SweepInterval, func(ctx context.Context) error {
+		expired, err := promoUseCase.ExpirePromoGrants(ctx)
+		if expired > 0 {
+			l.Info("app - promo sweeper - expired %d promo grants", expired)
+		}
+		return err
+	})
+	jobs.Every("voucher refunds", cfg.Voucher.RefundInterval, func(ctx context.Context) error {
+		refunded, err := voucherUseCase.RefundExpiredVouchers(ctx)
+		if refunded > 0 {
+			l.Info("app - voucher refunds - refunded %d expired vouchers", refunded)
+		}
+		return err
+	})
+	jobs.Every("interest accrual", cfg.Interest.AccrualInterval, interestUseCase.RunAccrualJob)
+	jobs.Every("balance snapshots", cfg.Balance.SnapshotInterval, balanceUseCase.RunSnapshotJob)
+	jobs.Every("webhook dispatcher", cfg.Webhook.DispatchInterval, func(ctx context.Context) error {
+		delivered, err := webhookUseCase.DispatchDeliveries(ctx)
+		if delivered > 0 {
+			l.Info("app - webhook dispatcher - delivered %d webhooks", delivered)
+		}
+		return err
+	})
+	jobs.Every("reconciliation", cfg.Reconciliation.Interval, func(ctx context.Context) error {
+		reconciliation, err := reconciliationUseCase.Reconcile(ctx)
+		if err != nil {
+			return err
+		}
+		logReconciliation(l, reconciliation)
+		return nil
+	})
+	jobs.Every("transaction chain", cfg.Chain.PublishInterval, func(ctx context.Context) error {
+		chained, err := chainUseCase.ChainTransactions(ctx)
+		if chained > 0 {
+			l.Info("app - transaction chain - chained %d transactions", chained)
+		}
+		if err != nil || chainKey == nil {
+			return err
+		}
+
+		head, err := chainUseCase.PublishHead(ctx)
+		if errors.Is(err, entity.ErrEmptyChain) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		l.Info("app - transaction chain - signed head %s of transaction %d", head.Hash, head.TransactionID)
+		return nil
+	})
+
+	// Wallet events committed by all app instances
+	go eventUseCase.Listen(ctx, func(err error) {
+		l.Error(fmt.Errorf("app - newPostgresFeatures - eventUseCase.Listen: %w", err))
+	})
+
+	return postgresFeatures{
+		promo:          promoUseCase,
+		voucher:        voucherUseCase,
+		payment:        paymentUseCase,
+		interest:       interestUseCase,
+		balance:        balanceUseCase,
+		pocket:         pocketUseCase,
+		event:          eventUseCase,
+		webhook:        webhookUseCase,
+		reconciliation: reconciliationUseCase,
+		chain:          chainUseCase,
+		receipt:        receiptUseCase,
+	}
+}
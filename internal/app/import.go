@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/pain"
+	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// ImportPayments executes a pain.001 payment file without starting the server.
+// The database schema is expected to be migrated by the server.
+func ImportPayments(ctx context.Context, cfg *config.Config, r io.Reader) (*entity.BulkStatusReport, error) {
+	payment, err := pain.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("app - ImportPayments - pain.Parse: %w", err)
+	}
+
+	// Connect postgres db, only if the wallet storage or the audit log keeps data there
+	var pg *postgres.Postgres
+	if needsPostgres(cfg) {
+		pg, err = postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
+		if err != nil {
+			return nil, fmt.Errorf("app - ImportPayments - postgres.New: %w", err)
+		}
+		defer pg.DB.Close()
+	}
+
+	walletRepo, outboxRepo, err := newWalletRepo(ctx, cfg, pg)
+	if err != nil {
+		return nil, fmt.Errorf("app - ImportPayments - newWalletRepo: %w", err)
+	}
+
+	// Use case, the payments are recorded in the audit log as made by the service
+	var walletUseCase usecase.Wallet = usecase.New(
+		walletRepo,
+		outboxRepo,
+		cfg.App.DefaultBalance,
+		cfg.Interest.SavingsRate,
+	)
+	if pg != nil && auditedStorage(cfg) {
+		walletUseCase = usecase.NewAuditedWallet(walletUseCase, repo.NewAuditRepo(pg))
+	}
+	bulkUseCase := usecase.NewBulk(
+		walletUseCase,
+		cfg.Statement.Currency,
+	)
+
+	return bulkUseCase.ImportPayments(ctx, payment)
+}
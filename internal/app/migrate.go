@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// migrationsDir - directory of the numbered migration files.
+const migrationsDir = "./migrations"
+
+var errMigrateUsage = errors.New("usage: migrate up | down N | status")
+
+// Migrate runs the migrate command without starting the server: up, down N or status.
+func Migrate(ctx context.Context, cfg *config.Config, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	migrations, err := postgres.LoadMigrations(os.DirFS(migrationsDir))
+	if err != nil {
+		return fmt.Errorf("app - Migrate - postgres.LoadMigrations: %w", err)
+	}
+
+	// Connect postgres db
+	pg, err := postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
+	if err != nil {
+		return fmt.Errorf("app - Migrate - postgres.New: %w", err)
+	}
+	defer pg.DB.Close()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		applied, err := pg.MigrateUp(ctx, migrations)
+		if err != nil {
+			return fmt.Errorf("app - Migrate - pg.MigrateUp: %w", err)
+		}
+		for _, migration := range applied {
+			fmt.Fprintf(w, "applied %06d_%s\n", migration.Version, migration.Name)
+		}
+	case args[0] == "down" && len(args) == 2:
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return errMigrateUsage
+		}
+		reverted, err := pg.MigrateDown(ctx, migrations, n)
+		if err != nil {
+			return fmt.Errorf("app - Migrate - pg.MigrateDown: %w", err)
+		}
+		for _, migration := range reverted {
+			fmt.Fprintf(w, "reverted %06d_%s\n", migration.Version, migration.Name)
+		}
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := pg.MigrationStatus(ctx, migrations)
+		if err != nil {
+			return fmt.Errorf("app - Migrate - pg.MigrationStatus: %w", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%06d_%-24s %s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
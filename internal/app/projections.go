@@ -0,0 +1,23 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/eventstore"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// RebuildProjections replays the events of all wallet streams into new balance and history projections.
+// The database schema is expected to be migrated by the server.
+func RebuildProjections(ctx context.Context, cfg *config.Config) (int, error) {
+	// Connect postgres db
+	pg, err := postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
+	if err != nil {
+		return 0, fmt.Errorf("app - RebuildProjections - postgres.New: %w", err)
+	}
+	defer pg.DB.Close()
+
+	return eventstore.NewWalletRepo(pg, cfg.Storage.SnapshotEvery).RebuildProjections(ctx)
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// Reconcile compares the balances of the wallets with their recorded movements and stores the report,
+// which is also available to the server. The database schema is expected to be migrated by the server.
+func Reconcile(ctx context.Context, cfg *config.Config) (*entity.Reconciliation, error) {
+	// Connect postgres db
+	pg, err := postgres.New(fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.PG.User, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DB))
+	if err != nil {
+		return nil, fmt.Errorf("app - Reconcile - postgres.New: %w", err)
+	}
+	defer pg.DB.Close()
+
+	return usecase.NewReconciliation(repo.NewReconciliationRepo(pg), cfg.Reconciliation.BatchSize, cfg.App.DefaultBalance).Reconcile(ctx)
+}
+
+// logReconciliation - reporting the drift found by the reconciliation.
+func logReconciliation(l logger.Interface, reconciliation *entity.Reconciliation) {
+	for _, mismatch := range reconciliation.Mismatches {
+		l.Warn("app - reconciliation - wallet %s has balance %f, expected %f (initial %f, incoming %f, outgoing %f)",
+			mismatch.WalletID, mismatch.Balance, mismatch.Expected, mismatch.InitialBalance, mismatch.Incoming, mismatch.Outgoing)
+	}
+	if reconciliation.Mismatched > len(reconciliation.Mismatches) {
+		l.Warn("app - reconciliation - %d more wallets mismatched", reconciliation.Mismatched-len(reconciliation.Mismatches))
+	}
+	if !reconciliation.Conserved {
+		l.Warn("app - reconciliation - money is not conserved: total balance %f, total initial balance %f",
+			reconciliation.TotalBalance, reconciliation.TotalInitialBalance)
+	}
+
+	l.Info("app - reconciliation - checked %d wallets, %d mismatched", reconciliation.Wallets, reconciliation.Mismatched)
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/config"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/eventstore"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/memory"
+	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/sqlite"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+const (
+	// Storages of the wallets
+	storagePostgres     = "postgres"
+	storageEventSourced = "eventsourced"
+	storageMemory       = "memory"
+	storageSQLite       = "sqlite"
+)
+
+// postgresStorage - whether the wallets are kept in the wallets table. Promo grants, vouchers, payments, pockets,
+// interest, balance snapshots, wallet events, webhooks, reconciliation, the transaction chain and receipts work
+// only with it.
+func postgresStorage(cfg *config.Config) bool {
+	return cfg.Storage.Wallets == storagePostgres
+}
+
+// auditedStorage - whether the wallets are changed in postgres transactions, so the changes are recorded in the audit log
+// in their transactions. Changes of the wallets kept elsewhere are recorded only as requests.
+func auditedStorage(cfg *config.Config) bool {
+	return cfg.Storage.Wallets == storagePostgres || cfg.Storage.Wallets == storageEventSourced
+}
+
+// needsPostgres - whether the app connects to postgres. The postgres and eventsourced storages keep the wallets there,
+// with other storages it is connected only if its host is configured, to keep the audit log.
+func needsPostgres(cfg *config.Config) bool {
+	switch cfg.Storage.Wallets {
+	case storagePostgres, storageEventSourced:
+		return true
+	default:
+		return cfg.PG.Host != ""
+	}
+}
+
+// newWalletRepo - the wallet storage selected in the config with the outbox of its domain events.
+// The postgres connection is nil with the memory and sqlite storages if postgres isn't configured.
+func newWalletRepo(ctx context.Context, cfg *config.Config, pg *postgres.Postgres) (usecase.WalletRepo, usecase.OutboxRepo, error) {
+	switch cfg.Storage.Wallets {
+	case storagePostgres:
+		return repo.NewWalletRepo(pg, cfg.Promo.SpendPriority), repo.NewOutboxRepo(pg), nil
+	case storageEventSourced:
+		return eventstore.NewWalletRepo(pg, cfg.Storage.SnapshotEvery), repo.NewOutboxRepo(pg), nil
+	case storageMemory:
+		// Domain events stay in memory too, so they are never stored without the change
+		wallets := memory.NewWalletRepo()
+		return wallets, memory.NewOutboxRepo(wallets), nil
+	case storageSQLite:
+		// The database file is open while the process runs, its migrations are embedded in the binary
+		db, err := sqlite.New(ctx, cfg.Storage.SQLitePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sqlite.New: %w", err)
+		}
+		return sqlite.NewWalletRepo(db), sqlite.NewOutboxRepo(db), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown wallet storage %q", cfg.Storage.Wallets)
+	}
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// Status codes of the use case errors, checked in order
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{context.Canceled, codes.Canceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{entity.ErrWalletNotFound, codes.NotFound},
+	{entity.ErrReceiverNotFound, codes.NotFound},
+	{entity.ErrPocketNotFound, codes.NotFound},
+	{entity.ErrWrongAmount, codes.InvalidArgument},
+	{entity.ErrSenderIsReceiver, codes.InvalidArgument},
+	{entity.ErrWrongWalletType, codes.InvalidArgument},
+	{entity.ErrDescriptionTooLong, codes.InvalidArgument},
+	{entity.ErrExternalReferenceTooLong, codes.InvalidArgument},
+	{entity.ErrPocketTransfer, codes.InvalidArgument},
+	{entity.ErrInsufficientFunds, codes.FailedPrecondition},
+	{entity.ErrWalletFrozen, codes.FailedPrecondition},
+	{entity.ErrWalletClosed, codes.FailedPrecondition},
+	{entity.ErrVersionConflict, codes.Aborted},
+	{entity.ErrVersionMismatch, codes.FailedPrecondition},
+}
+
+// statusError - mapping errors of the use case to gRPC statuses, unknown errors are hidden from clients
+func statusError(err error) error {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.err.Error())
+		}
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
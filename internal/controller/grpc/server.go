@@ -0,0 +1,44 @@
+// Package grpc implements the wallet API over gRPC alongside the HTTP controller.
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/controller/grpc/pb"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// requestIDKey - metadata key of the request id.
+const requestIDKey = "x-request-id"
+
+// NewServer -.
+func NewServer(l logger.Interface, w usecase.Wallet, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(auditActor)}, opts...)
+	server := grpc.NewServer(opts...)
+	pb.RegisterWalletServiceServer(server, &walletServer{w: w, l: l})
+
+	return server
+}
+
+// auditActor - changes made with the gRPC calls are recorded in the audit log on behalf of the gRPC client.
+func auditActor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	actor := entity.AuditActor{Actor: entity.AuditActorGRPC}
+	if p, ok := peer.FromContext(ctx); ok {
+		actor.ClientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(actor.ClientIP); err == nil {
+			actor.ClientIP = host
+		}
+	}
+	if ids := metadata.ValueFromIncomingContext(ctx, requestIDKey); len(ids) > 0 && len(ids[0]) <= entity.MaxRequestIDLength {
+		actor.RequestID = ids[0]
+	}
+
+	return handler(usecase.WithAudit(ctx, actor), req)
+}
@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/controller/grpc/pb"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type walletServer struct {
+	pb.UnimplementedWalletServiceServer
+
+	w usecase.Wallet
+	l logger.Interface
+}
+
+func (s *walletServer) CreateWallet(ctx context.Context, request *pb.CreateWalletRequest) (*pb.Wallet, error) {
+	wallet, err := s.w.CreateNewWalletWithDefaultBalance(ctx, request.GetType())
+	if err != nil {
+		s.l.Error(err, "grpc - CreateWallet")
+
+		return nil, statusError(err)
+	}
+
+	return toWallet(wallet), nil
+}
+
+func (s *walletServer) SendFunds(ctx context.Context, request *pb.SendFundsRequest) (*pb.SendFundsResponse, error) {
+	_, err := s.w.SendFunds(ctx, request.GetWalletId(), entity.TransactionRequest{
+		To:                request.GetTo(),
+		Amount:            request.GetAmount(),
+		Description:       request.GetDescription(),
+		ExternalReference: request.GetExternalReference(),
+	})
+	if err != nil {
+		s.l.Error(err, "grpc - SendFunds")
+
+		return nil, statusError(err)
+	}
+
+	return &pb.SendFundsResponse{}, nil
+}
+
+func (s *walletServer) GetWallet(ctx context.Context, request *pb.GetWalletRequest) (*pb.Wallet, error) {
+	wallet, err := s.w.GetWalletById(ctx, request.GetWalletId())
+	if err != nil {
+		s.l.Error(err, "grpc - GetWallet")
+
+		return nil, statusError(err)
+	}
+
+	return toWallet(wallet), nil
+}
+
+func (s *walletServer) GetWalletHistory(request *pb.GetWalletHistoryRequest, stream pb.WalletService_GetWalletHistoryServer) error {
+	filter := entity.HistoryFilter{
+		ExternalReference: request.GetExternalReference(),
+		IncludePockets:    request.GetIncludePockets(),
+	}
+
+	transactions, err := s.w.GetWalletHistoryById(stream.Context(), request.GetWalletId(), filter)
+	if err != nil {
+		s.l.Error(err, "grpc - GetWalletHistory")
+
+		return statusError(err)
+	}
+
+	for i := range transactions {
+		if err := stream.Send(toTransaction(&transactions[i])); err != nil {
+			s.l.Error(err, "grpc - GetWalletHistory")
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toWallet - converting a wallet with its pockets to the protobuf message
+func toWallet(wallet *entity.Wallet) *pb.Wallet {
+	message := &pb.Wallet{
+		Id:              wallet.ID,
+		Balance:         wallet.Balance,
+		Type:            wallet.Type,
+		AnnualRate:      wallet.AnnualRate,
+		CreditLimit:     wallet.CreditLimit,
+		AvailableCredit: wallet.AvailableCredit,
+		Status:          wallet.Status,
+		ParentId:        wallet.ParentID,
+		Name:            wallet.Name,
+		TotalBalance:    wallet.TotalBalance,
+	}
+	for _, bucket := range wallet.Buckets {
+		message.Buckets = append(message.Buckets, &pb.BalanceBucket{
+			Name:   bucket.Name,
+			Amount: bucket.Amount,
+		})
+	}
+	for _, expiration := range wallet.Expirations {
+		message.Expirations = append(message.Expirations, &pb.PromoExpiration{
+			Amount:    expiration.Amount,
+			ExpiresAt: timestamppb.New(expiration.ExpiresAt),
+		})
+	}
+	for i := range wallet.Pockets {
+		message.Pockets = append(message.Pockets, toWallet(&wallet.Pockets[i]))
+	}
+
+	return message
+}
+
+// toTransaction - converting a transaction to the protobuf message
+func toTransaction(transaction *entity.Transaction) *pb.Transaction {
+	return &pb.Transaction{
+		Time:              timestamppb.New(transaction.Time),
+		From:              transaction.From,
+		To:                transaction.To,
+		Amount:            transaction.Amount,
+		Description:       transaction.Description,
+		ExternalReference: transaction.ExternalReference,
+		Type:              transaction.Type,
+	}
+}
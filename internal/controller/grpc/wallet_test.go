@@ -0,0 +1,409 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/controller/grpc/pb"
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// newTestClient - starting the server on an in-process listener and connecting to it
+func newTestClient(t *testing.T, wallet *mock_usecase.MockWallet) pb.WalletServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(logger.New(""), wallet)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewWalletServiceClient(conn)
+}
+
+func TestCreateWallet(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWallet, walletType string)
+
+	tests := []struct {
+		name             string
+		walletType       string
+		mockBehavior     mockBehavior
+		expectedCode     codes.Code
+		expectedResponse *pb.Wallet
+	}{
+		{
+			name:       "Ok",
+			walletType: "savings",
+			mockBehavior: func(r *mock_usecase.MockWallet, walletType string) {
+				r.EXPECT().CreateNewWalletWithDefaultBalance(gomock.Any(), walletType).Return(&entity.Wallet{
+					ID:         "5b53700ed469fa6a09ea72bb78f36fd9",
+					Balance:    100.0,
+					Type:       walletType,
+					AnnualRate: 0.05,
+					Status:     entity.WalletStatusActive,
+				}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedResponse: &pb.Wallet{
+				Id:         "5b53700ed469fa6a09ea72bb78f36fd9",
+				Balance:    100.0,
+				Type:       "savings",
+				AnnualRate: 0.05,
+				Status:     entity.WalletStatusActive,
+			},
+		},
+		{
+			name:       "Wrong wallet type",
+			walletType: "checking",
+			mockBehavior: func(r *mock_usecase.MockWallet, walletType string) {
+				r.EXPECT().CreateNewWalletWithDefaultBalance(gomock.Any(), walletType).Return(nil, entity.ErrWrongWalletType)
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Something went wrong",
+			mockBehavior: func(r *mock_usecase.MockWallet, walletType string) {
+				r.EXPECT().CreateNewWalletWithDefaultBalance(gomock.Any(), walletType).Return(nil, errors.New("something went wrong"))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			wallet := mock_usecase.NewMockWallet(c)
+			test.mockBehavior(wallet, test.walletType)
+			client := newTestClient(t, wallet)
+
+			// Make Request
+			response, err := client.CreateWallet(context.Background(), &pb.CreateWalletRequest{Type: test.walletType})
+
+			// Assert
+			require.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedResponse != nil {
+				require.True(t, proto.Equal(test.expectedResponse, response), response.String())
+			}
+		})
+	}
+}
+
+func TestSendFunds(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWallet, request *pb.SendFundsRequest)
+
+	tests := []struct {
+		name         string
+		request      *pb.SendFundsRequest
+		mockBehavior mockBehavior
+		expectedCode codes.Code
+	}{
+		{
+			name: "Ok",
+			request: &pb.SendFundsRequest{
+				WalletId:          "5b53700ed469fa6a09ea72bb78f36fd9",
+				To:                "eb376add88bf8e70f80787266a0801d5",
+				Amount:            30.0,
+				Description:       "Оплата по счету №42",
+				ExternalReference: "INV-2024-0042",
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.SendFundsRequest) {
+				r.EXPECT().SendFunds(gomock.Any(), request.WalletId, entity.TransactionRequest{
+					To:                request.To,
+					Amount:            request.Amount,
+					Description:       request.Description,
+					ExternalReference: request.ExternalReference,
+				}).Return(&entity.Transaction{ID: 1024}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "Wallet not found",
+			request: &pb.SendFundsRequest{
+				WalletId: "5b53700ed469fa6a09ea72bb78f36fd9",
+				To:       "eb376add88bf8e70f80787266a0801d5",
+				Amount:   30.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.SendFundsRequest) {
+				r.EXPECT().SendFunds(gomock.Any(), request.WalletId, gomock.Any()).Return(
+					nil, fmt.Errorf("WalletUseCase - SendFunds - w.repo.SendFunds: %w", entity.ErrWalletNotFound),
+				)
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "Wrong amount",
+			request: &pb.SendFundsRequest{
+				WalletId: "5b53700ed469fa6a09ea72bb78f36fd9",
+				To:       "eb376add88bf8e70f80787266a0801d5",
+				Amount:   -30.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.SendFundsRequest) {
+				r.EXPECT().SendFunds(gomock.Any(), request.WalletId, gomock.Any()).Return(nil, entity.ErrWrongAmount)
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Insufficient funds",
+			request: &pb.SendFundsRequest{
+				WalletId: "5b53700ed469fa6a09ea72bb78f36fd9",
+				To:       "eb376add88bf8e70f80787266a0801d5",
+				Amount:   1000.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.SendFundsRequest) {
+				r.EXPECT().SendFunds(gomock.Any(), request.WalletId, gomock.Any()).Return(nil, entity.ErrInsufficientFunds)
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			wallet := mock_usecase.NewMockWallet(c)
+			test.mockBehavior(wallet, test.request)
+			client := newTestClient(t, wallet)
+
+			// Make Request
+			_, err := client.SendFunds(context.Background(), test.request)
+
+			// Assert
+			require.Equal(t, test.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestGetWallet(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWallet, id string)
+
+	expiresAt, _ := time.Parse(time.RFC3339, "2024-03-05T17:25:35Z")
+	total := 250.0
+
+	tests := []struct {
+		name             string
+		id               string
+		mockBehavior     mockBehavior
+		expectedCode     codes.Code
+		expectedResponse *pb.Wallet
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+				r.EXPECT().GetWalletById(gomock.Any(), id).Return(&entity.Wallet{
+					ID:           id,
+					Balance:      100.0,
+					Status:       entity.WalletStatusActive,
+					TotalBalance: &total,
+					Buckets: []entity.BalanceBucket{
+						{Name: entity.BucketMain, Amount: 80.0},
+						{Name: entity.BucketPromo, Amount: 20.0},
+					},
+					Expirations: []entity.PromoExpiration{
+						{Amount: 20.0, ExpiresAt: expiresAt},
+					},
+					Pockets: []entity.Wallet{
+						{ID: "eb376add88bf8e70f80787266a0801d5", Balance: 150.0, ParentID: id, Name: "Отпуск"},
+					},
+				}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedResponse: &pb.Wallet{
+				Id:           "5b53700ed469fa6a09ea72bb78f36fd9",
+				Balance:      100.0,
+				Status:       entity.WalletStatusActive,
+				TotalBalance: &total,
+				Buckets: []*pb.BalanceBucket{
+					{Name: entity.BucketMain, Amount: 80.0},
+					{Name: entity.BucketPromo, Amount: 20.0},
+				},
+				Expirations: []*pb.PromoExpiration{
+					{Amount: 20.0, ExpiresAt: timestamppb.New(expiresAt)},
+				},
+				Pockets: []*pb.Wallet{
+					{Id: "eb376add88bf8e70f80787266a0801d5", Balance: 150.0, ParentId: "5b53700ed469fa6a09ea72bb78f36fd9", Name: "Отпуск"},
+				},
+			},
+		},
+		{
+			name: "Not Found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+				r.EXPECT().GetWalletById(gomock.Any(), id).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			wallet := mock_usecase.NewMockWallet(c)
+			test.mockBehavior(wallet, test.id)
+			client := newTestClient(t, wallet)
+
+			// Make Request
+			response, err := client.GetWallet(context.Background(), &pb.GetWalletRequest{WalletId: test.id})
+
+			// Assert
+			require.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedResponse != nil {
+				require.True(t, proto.Equal(test.expectedResponse, response), response.String())
+			}
+		})
+	}
+}
+
+func TestGetWalletHistory(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWallet, request *pb.GetWalletHistoryRequest)
+
+	sentAt, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35Z")
+
+	tests := []struct {
+		name             string
+		request          *pb.GetWalletHistoryRequest
+		mockBehavior     mockBehavior
+		expectedCode     codes.Code
+		expectedMessages []*pb.Transaction
+	}{
+		{
+			name: "Ok",
+			request: &pb.GetWalletHistoryRequest{
+				WalletId:       "5b53700ed469fa6a09ea72bb78f36fd9",
+				IncludePockets: true,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.GetWalletHistoryRequest) {
+				r.EXPECT().GetWalletHistoryById(gomock.Any(), request.WalletId, entity.HistoryFilter{IncludePockets: true}).Return([]entity.Transaction{
+					{
+						Time:   sentAt,
+						From:   "5b53700ed469fa6a09ea72bb78f36fd9",
+						To:     "eb376add88bf8e70f80787266a0801d5",
+						Amount: 30.0,
+						Type:   entity.TransactionTypeTransfer,
+					},
+					{
+						Time:        sentAt.Add(time.Hour),
+						From:        "eb376add88bf8e70f80787266a0801d5",
+						To:          "5b53700ed469fa6a09ea72bb78f36fd9",
+						Amount:      10.0,
+						Description: "Возврат",
+						Type:        entity.TransactionTypeTransfer,
+					},
+				}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedMessages: []*pb.Transaction{
+				{
+					Time:   timestamppb.New(sentAt),
+					From:   "5b53700ed469fa6a09ea72bb78f36fd9",
+					To:     "eb376add88bf8e70f80787266a0801d5",
+					Amount: 30.0,
+					Type:   entity.TransactionTypeTransfer,
+				},
+				{
+					Time:        timestamppb.New(sentAt.Add(time.Hour)),
+					From:        "eb376add88bf8e70f80787266a0801d5",
+					To:          "5b53700ed469fa6a09ea72bb78f36fd9",
+					Amount:      10.0,
+					Description: "Возврат",
+					Type:        entity.TransactionTypeTransfer,
+				},
+			},
+		},
+		{
+			name: "Ok - no transactions",
+			request: &pb.GetWalletHistoryRequest{
+				WalletId:          "5b53700ed469fa6a09ea72bb78f36fd9",
+				ExternalReference: "INV-2024-0042",
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.GetWalletHistoryRequest) {
+				r.EXPECT().GetWalletHistoryById(gomock.Any(), request.WalletId, entity.HistoryFilter{ExternalReference: "INV-2024-0042"}).Return([]entity.Transaction{}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "Not Found",
+			request: &pb.GetWalletHistoryRequest{
+				WalletId: "5b53700ed469fa6a09ea72bb78f36fd9",
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, request *pb.GetWalletHistoryRequest) {
+				r.EXPECT().GetWalletHistoryById(gomock.Any(), request.WalletId, entity.HistoryFilter{}).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			wallet := mock_usecase.NewMockWallet(c)
+			test.mockBehavior(wallet, test.request)
+			client := newTestClient(t, wallet)
+
+			// Make Request
+			stream, err := client.GetWalletHistory(context.Background(), test.request)
+			require.NoError(t, err)
+
+			var messages []*pb.Transaction
+			for {
+				message, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					require.Equal(t, test.expectedCode, status.Code(err))
+
+					return
+				}
+				messages = append(messages, message)
+			}
+
+			// Assert
+			require.Equal(t, test.expectedCode, codes.OK)
+			require.Len(t, messages, len(test.expectedMessages))
+			for i := range messages {
+				require.True(t, proto.Equal(test.expectedMessages[i], messages[i]), messages[i].String())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type auditRoutes struct {
+	au usecase.Audit
+	l  logger.Interface
+}
+
+func newAuditRoutes(admin *gin.RouterGroup, au usecase.Audit, l logger.Interface) {
+	r := &auditRoutes{au, l}
+
+	a := admin.Group("/audit")
+	{
+		a.GET("", r.getAuditEntries)
+	}
+}
+
+// @Summary     Получение журнала аудита
+// @Description Возвращает последние записи журнала аудита, новые первыми. В журнал записываются все запросы, изменяющие состояние: создание кошелька, перевод и действия администратора. Для изменений кошельков, включая промо-начисления, выпуск ваучеров, копилки и платежи, сохраняется состояние кошелька до и после операции.
+// @Description
+// @Description Записи не изменяются и удаляются только по истечении срока хранения
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param wallet_id query string false "ID кошелька"
+// @Param actor query string false "Кто выполнил операцию" Enums(admin, client, grpc, unauthorized, system)
+// @Param operation query string false "Операция"
+// @Param request_id query string false "ID запроса"
+// @Param from query string false "Начало периода (RFC 3339)" format(date-time)
+// @Param to query string false "Конец периода, не включая его (RFC 3339)" format(date-time)
+// @Param limit query int false "Количество записей, по умолчанию 100, не более 1000"
+// @Success     200 {object} []entity.AuditEntry "Записи журнала получены"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     500 "Внутренняя ошибка сервера"
+// @Router      /admin/audit [get]
+func (r *auditRoutes) getAuditEntries(c *gin.Context) {
+	filter := entity.AuditFilter{
+		WalletID:  c.Query("wallet_id"),
+		Actor:     c.Query("actor"),
+		Operation: c.Query("operation"),
+		RequestID: c.Query("request_id"),
+	}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		filter.From, err = time.Parse(time.RFC3339, from)
+	}
+	if to := c.Query("to"); err == nil && to != "" {
+		filter.To, err = time.Parse(time.RFC3339, to)
+	}
+	if limit := c.Query("limit"); err == nil && limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAuditEntries")
+		c.AbortWithStatus(http.StatusBadRequest)
+
+		return
+	}
+
+	entries, err := r.au.GetEntries(c.Request.Context(), filter)
+	if errors.Is(err, entity.ErrWrongAuditLimit) {
+		r.l.Error(err, "http - v1 - getAuditEntries")
+		c.AbortWithStatus(http.StatusBadRequest)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAuditEntries")
+		c.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,221 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_getAuditEntries(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockAudit)
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?wallet_id=a&actor=admin&from=2024-02-04T00:00:00Z&limit=10",
+			mockBehavior: func(r *mock_usecase.MockAudit) {
+				at, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35Z")
+				from, _ := time.Parse(time.RFC3339, "2024-02-04T00:00:00Z")
+
+				r.EXPECT().GetEntries(context.Background(), entity.AuditFilter{WalletID: "a", Actor: "admin", From: from, Limit: 10}).Return([]entity.AuditEntry{
+					{
+						ID:        42,
+						Time:      at,
+						Actor:     entity.AuditActorAdmin,
+						ClientIP:  "192.168.0.10",
+						RequestID: "request-1",
+						Operation: entity.AuditOperationSetWalletStatus,
+						WalletID:  "a",
+						Details:   map[string]interface{}{"status": "frozen"},
+						Before:    &entity.Wallet{ID: "a", Balance: 100.0, Status: entity.WalletStatusActive},
+						After:     &entity.Wallet{ID: "a", Balance: 100.0, Status: entity.WalletStatusFrozen},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":42,"time":"2024-02-04T17:25:35Z","actor":"admin","client_ip":"192.168.0.10","request_id":"request-1","operation":"set_wallet_status","wallet_id":"a","details":{"status":"frozen"},"before":{"id":"a","balance":100,"status":"active"},"after":{"id":"a","balance":100,"status":"frozen"}}]`,
+		},
+		{
+			name:                 "Wrong time",
+			query:                "?to=yesterday",
+			mockBehavior:         func(r *mock_usecase.MockAudit) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Wrong limit",
+			query: "?limit=5000",
+			mockBehavior: func(r *mock_usecase.MockAudit) {
+				r.EXPECT().GetEntries(context.Background(), entity.AuditFilter{Limit: 5000}).Return(nil, entity.ErrWrongAuditLimit)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			mockBehavior: func(r *mock_usecase.MockAudit) {
+				r.EXPECT().GetEntries(context.Background(), entity.AuditFilter{}).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			audit := mock_usecase.NewMockAudit(c)
+			test.mockBehavior(audit)
+			handler := auditRoutes{
+				au: audit,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/audit", handler.getAuditEntries)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/audit"+test.query, nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_auditTrail(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockAudit)
+
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		requestID          string
+		handler            gin.HandlerFunc
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedRequestID  string
+	}{
+		{
+			name:      "Admin action",
+			method:    "POST",
+			path:      "/api/v1/admin/promo/a",
+			requestID: "request-1",
+			handler: func(c *gin.Context) {
+				c.Status(200)
+			},
+			mockBehavior: func(r *mock_usecase.MockAudit) {
+				r.EXPECT().Record(gomock.Any(), entity.AuditEntry{Operation: "POST /api/v1/admin/promo/:walletId", WalletID: "a"}).
+					DoAndReturn(func(ctx context.Context, _ entity.AuditEntry) error {
+						actor := usecase.AuditActorOf(ctx)
+						if actor.Actor != entity.AuditActorAdmin || actor.RequestID != "request-1" || actor.ClientIP != "192.0.2.1" {
+							t.Errorf("unexpected actor %+v", actor)
+						}
+						return nil
+					})
+			},
+			expectedStatusCode: 200,
+			expectedRequestID:  "request-1",
+		},
+		{
+			name:      "Rejected admin action",
+			method:    "POST",
+			path:      "/api/v1/admin/promo/a",
+			requestID: "request with spaces",
+			handler: func(c *gin.Context) {
+				c.AbortWithStatus(401)
+			},
+			mockBehavior: func(r *mock_usecase.MockAudit) {
+				r.EXPECT().Record(gomock.Any(), entity.AuditEntry{Operation: "POST /api/v1/admin/promo/:walletId", WalletID: "a", Error: "Unauthorized"}).
+					DoAndReturn(func(ctx context.Context, _ entity.AuditEntry) error {
+						if actor := usecase.AuditActorOf(ctx); actor.Actor != entity.AuditActorUnauthorized {
+							t.Errorf("unexpected actor %+v", actor)
+						}
+						return nil
+					})
+			},
+			expectedStatusCode: 401,
+		},
+		{
+			name:   "Recorded by the use case",
+			method: "POST",
+			path:   "/api/v1/wallet/a/send",
+			handler: func(c *gin.Context) {
+				repo := mock_usecase.NewMockAuditRepo(gomock.NewController(t))
+				repo.EXPECT().AddAuditEntry(gomock.Any(), gomock.Any()).Return(nil)
+				usecase.NewAudit(repo, 0).Record(c.Request.Context(), entity.AuditEntry{})
+				c.Status(200)
+			},
+			mockBehavior:       func(r *mock_usecase.MockAudit) {},
+			expectedStatusCode: 200,
+		},
+		{
+			name:      "Read only request",
+			method:    "GET",
+			path:      "/api/v1/admin/promo/a",
+			requestID: "request-1",
+			handler: func(c *gin.Context) {
+				c.Status(200)
+			},
+			mockBehavior:       func(r *mock_usecase.MockAudit) {},
+			expectedStatusCode: 200,
+			expectedRequestID:  "request-1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			audit := mock_usecase.NewMockAudit(c)
+			test.mockBehavior(audit)
+			// Init Endpoint
+			r := gin.New()
+			h := r.Group("/api/v1")
+			h.Use(auditTrail(audit, "/api/v1/admin", logger.New("")))
+			h.Handle(test.method, "/admin/promo/:walletId", test.handler)
+			h.Handle(test.method, "/wallet/:walletId/send", test.handler)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, nil)
+			req.Header.Set("X-Request-ID", test.requestID)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			if test.expectedRequestID != "" {
+				assert.Equal(t, w.Header().Get("X-Request-ID"), test.expectedRequestID)
+			} else {
+				assert.Equal(t, len(w.Header().Get("X-Request-ID")), 32)
+			}
+		})
+	}
+}
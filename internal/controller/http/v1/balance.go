@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type balanceRoutes struct {
+	b usecase.Balance
+	l logger.Interface
+}
+
+func newBalanceRoutes(handler *gin.RouterGroup, admin *gin.RouterGroup, b usecase.Balance, l logger.Interface) {
+	r := &balanceRoutes{b, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.GET("/:walletId/balance", r.getBalanceAt)
+	}
+
+	a := admin.Group("/balance")
+	{
+		a.POST("/snapshot", r.takeSnapshots)
+	}
+}
+
+// @Summary     Получение баланса на момент времени
+// @Description Возвращает баланс кошелька на указанный момент. Баланс вычисляется от ближайшего снимка на конец дня с учетом последующих операций.
+// @Tags  	    Wallet
+// @Param walletId path string true "ID кошелька"
+// @Param at query string true "Момент времени в формате RFC 3339"
+// @Success     200 {object} entity.BalanceAt "Баланс получен"
+// @Failure     400 "Ошибка в формате момента времени"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /wallet/{walletId}/balance [get]
+func (r *balanceRoutes) getBalanceAt(c *gin.Context) {
+	at, err := time.Parse(time.RFC3339, c.Query("at"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getBalanceAt")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	balance, err := r.b.GetBalanceAt(c.Request.Context(), c.Param("walletId"), at)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getBalanceAt")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// @Summary     Снимок балансов на конец дня
+// @Description Сохраняет балансы кошельков на конец указанного завершившегося дня (UTC). Повторный запуск за тот же день не изменяет сохраненные снимки.
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param day query string true "День в формате YYYY-MM-DD"
+// @Success     200 {object} integer "Количество новых снимков"
+// @Failure     400 "Ошибка в запросе или день еще не завершился"
+// @Failure     401 "Требуется токен администратора"
+// @Router      /admin/balance/snapshot [post]
+func (r *balanceRoutes) takeSnapshots(c *gin.Context) {
+	day, err := time.Parse(time.DateOnly, c.Query("day"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - takeSnapshots")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	taken, err := r.b.TakeSnapshots(c.Request.Context(), day)
+	if errors.Is(err, entity.ErrDayIsNotOver) {
+		r.l.Error(err, "http - v1 - takeSnapshots")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - takeSnapshots")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, taken)
+}
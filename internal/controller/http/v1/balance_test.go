@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_getBalanceAt(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockBalance, id string, at time.Time)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		at                   string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			at:   "2024-02-04T17:25:35Z",
+			mockBehavior: func(r *mock_usecase.MockBalance, id string, at time.Time) {
+				r.EXPECT().GetBalanceAt(context.Background(), id, at).Return(&entity.BalanceAt{
+					WalletID: id,
+					At:       at,
+					Balance:  250.0,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","at":"2024-02-04T17:25:35Z","balance":250}`,
+		},
+		{
+			name:                 "Wrong input - wrong moment format",
+			id:                   "5b53700ed469fa6a09ea72bb78f36fd9",
+			at:                   "2024-02-04",
+			mockBehavior:         func(r *mock_usecase.MockBalance, id string, at time.Time) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Not Found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			at:   "2024-02-04T17:25:35Z",
+			mockBehavior: func(r *mock_usecase.MockBalance, id string, at time.Time) {
+				r.EXPECT().GetBalanceAt(context.Background(), id, at).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			balance := mock_usecase.NewMockBalance(c)
+			at, _ := time.Parse(time.RFC3339, test.at)
+			test.mockBehavior(balance, test.id, at)
+			handler := balanceRoutes{
+				b: balance,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/:walletId/balance", handler.getBalanceAt)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/balance?at=%s", test.id, test.at), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_takeSnapshots(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockBalance, day time.Time)
+
+	tests := []struct {
+		name                 string
+		day                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			day:  "2024-02-04",
+			mockBehavior: func(r *mock_usecase.MockBalance, day time.Time) {
+				r.EXPECT().TakeSnapshots(context.Background(), day).Return(12, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `12`,
+		},
+		{
+			name:                 "Wrong input - wrong day format",
+			day:                  "04.02.2024",
+			mockBehavior:         func(r *mock_usecase.MockBalance, day time.Time) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Day is not over",
+			day:  "2024-02-04",
+			mockBehavior: func(r *mock_usecase.MockBalance, day time.Time) {
+				r.EXPECT().TakeSnapshots(context.Background(), day).Return(0, entity.ErrDayIsNotOver)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			day:  "2024-02-04",
+			mockBehavior: func(r *mock_usecase.MockBalance, day time.Time) {
+				r.EXPECT().TakeSnapshots(context.Background(), day).Return(0, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			balance := mock_usecase.NewMockBalance(c)
+			day, _ := time.Parse(time.DateOnly, test.day)
+			test.mockBehavior(balance, day)
+			handler := balanceRoutes{
+				b: balance,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/snapshot", handler.takeSnapshots)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/snapshot?day=%s", test.day), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
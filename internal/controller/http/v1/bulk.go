@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/pain"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// Maximum size of an uploaded payment file
+const maxPaymentFileSize = 10 << 20
+
+type bulkRoutes struct {
+	b usecase.Bulk
+	l logger.Interface
+}
+
+func newBulkRoutes(handler *gin.RouterGroup, b usecase.Bulk, l logger.Interface) {
+	r := &bulkRoutes{b, l}
+
+	h := handler.Group("/payments")
+	{
+		h.POST("/import", r.importPayments)
+	}
+}
+
+// @Summary     Импорт пакета платежей pain.001
+// @Description Принимает файл ISO 20022 pain.001 и проводит каждый платеж как обычный перевод со счета плательщика (DbtrAcct/Id/Othr/Id) на счет получателя (CdtrAcct/Id/Othr/Id).
+// @Description
+// @Description Файл отклоняется целиком, если количество платежей или контрольная сумма не совпадают. Отказ по отдельному платежу не останавливает остальные, платежи с уже отправленным EndToEndId отклоняются как дубликаты
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Accept      xml
+// @Param input body string true "Файл pain.001"
+// @Success     200 {object} entity.BulkStatusReport "Отчет о статусе платежей"
+// @Failure     400 "Файл некорректен или контрольные суммы не совпадают"
+// @Failure     401 "Требуется токен администратора"
+// @Router      /admin/payments/import [post]
+func (r *bulkRoutes) importPayments(c *gin.Context) {
+	payment, err := pain.Parse(http.MaxBytesReader(c.Writer, c.Request.Body, maxPaymentFileSize))
+	if err != nil {
+		r.l.Error(err, "http - v1 - importPayments")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	report, err := r.b.ImportPayments(c.Request.Context(), payment)
+	if err != nil {
+		r.l.Error(err, "http - v1 - importPayments")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
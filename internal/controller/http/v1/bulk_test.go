@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+const testPaymentFile = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+	<CstmrCdtTrfInitn>
+		<GrpHdr>
+			<MsgId>PAYOUTS-2024-02-29</MsgId>
+			<NbOfTxs>1</NbOfTxs>
+			<CtrlSum>100.25</CtrlSum>
+		</GrpHdr>
+		<PmtInf>
+			<PmtInfId>BATCH-1</PmtInfId>
+			<DbtrAcct><Id><Othr><Id>5b53700ed469fa6a09ea72bb78f36fd9</Id></Othr></Id></DbtrAcct>
+			<CdtTrfTxInf>
+				<PmtId><InstrId>1</InstrId><EndToEndId>INV-2024-0042</EndToEndId></PmtId>
+				<Amt><InstdAmt Ccy="XXX">100.25</InstdAmt></Amt>
+				<CdtrAcct><Id><Othr><Id>eb376add88bf8e70f80787266a0801d5</Id></Othr></Id></CdtrAcct>
+			</CdtTrfTxInf>
+		</PmtInf>
+	</CstmrCdtTrfInitn>
+</Document>`
+
+func Test_importPayments(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockBulk, payment *entity.BulkPayment)
+
+	payment := &entity.BulkPayment{
+		MessageID: "PAYOUTS-2024-02-29",
+		Instructions: []entity.BulkInstruction{
+			{
+				PaymentInfoID: "BATCH-1",
+				InstructionID: "1",
+				EndToEndID:    "INV-2024-0042",
+				From:          "5b53700ed469fa6a09ea72bb78f36fd9",
+				To:            "eb376add88bf8e70f80787266a0801d5",
+				Amount:        100.25,
+				Currency:      "XXX",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			body: testPaymentFile,
+			mockBehavior: func(r *mock_usecase.MockBulk, payment *entity.BulkPayment) {
+				r.EXPECT().ImportPayments(context.Background(), payment).Return(&entity.BulkStatusReport{
+					OriginalMessageID: payment.MessageID,
+					GroupStatus:       entity.BulkGroupRejected,
+					Instructions: []entity.BulkInstructionStatus{
+						{
+							PaymentInfoID: "BATCH-1",
+							InstructionID: "1",
+							EndToEndID:    "INV-2024-0042",
+							Status:        entity.BulkInstructionRejected,
+							Reason:        entity.BulkReasonInsufficientFunds,
+							Details:       entity.ErrInsufficientFunds.Error(),
+						},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"original_message_id":"PAYOUTS-2024-02-29","group_status":"RJCT","instructions":[{"payment_info_id":"BATCH-1","instruction_id":"1","end_to_end_id":"INV-2024-0042","status":"RJCT","reason":"AM04","details":"insufficient funds"}]}`,
+		},
+		{
+			name:                 "Wrong input - control sum mismatch",
+			body:                 strings.Replace(testPaymentFile, "<CtrlSum>100.25</CtrlSum>", "<CtrlSum>100.26</CtrlSum>", 1),
+			mockBehavior:         func(r *mock_usecase.MockBulk, payment *entity.BulkPayment) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Wrong input - not a payment file",
+			body:                 `{"to":"eb376add88bf8e70f80787266a0801d5","amount":100}`,
+			mockBehavior:         func(r *mock_usecase.MockBulk, payment *entity.BulkPayment) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			body: testPaymentFile,
+			mockBehavior: func(r *mock_usecase.MockBulk, payment *entity.BulkPayment) {
+				r.EXPECT().ImportPayments(context.Background(), payment).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			bulk := mock_usecase.NewMockBulk(c)
+			test.mockBehavior(bulk, payment)
+			handler := bulkRoutes{
+				b: bulk,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/import", handler.importPayments)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/import", strings.NewReader(test.body))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
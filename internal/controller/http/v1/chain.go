@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type chainRoutes struct {
+	ch usecase.Chain
+	l  logger.Interface
+}
+
+func newChainRoutes(admin *gin.RouterGroup, ch usecase.Chain, l logger.Interface) {
+	r := &chainRoutes{ch, l}
+
+	a := admin.Group("/chain")
+	{
+		a.GET("/heads", r.getHeads)
+	}
+}
+
+// @Summary     Получение подписанных вершин цепочки операций
+// @Description Возвращает периодически публикуемые вершины хэш-цепочки операций в порядке публикации. Каждая операция связана с предыдущей хэшем SHA-256 от своего содержимого и хэша предыдущей операции, поэтому вершина фиксирует всю историю до нее.
+// @Description
+// @Description Вершина подписана ключом ed25519; подпись строки "<transaction_id>:<hash>:<signed_at>" проверяется открытым ключом. Аудиторы сохраняют вершины вне сервиса, чтобы обнаружить изменение истории после подписи
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Success     200 {object} []entity.ChainHead "Вершины цепочки получены"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     500 "Внутренняя ошибка сервера"
+// @Router      /admin/chain/heads [get]
+func (r *chainRoutes) getHeads(c *gin.Context) {
+	heads, err := r.ch.GetHeads(c.Request.Context())
+	if err != nil {
+		r.l.Error(err, "http - v1 - getHeads")
+		c.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, heads)
+}
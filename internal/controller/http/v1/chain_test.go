@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_getHeads(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockChain)
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_usecase.MockChain) {
+				signedAt, _ := time.Parse(time.RFC3339, "2024-02-05T03:00:00Z")
+
+				r.EXPECT().GetHeads(context.Background()).Return([]entity.ChainHead{
+					{
+						ID:            42,
+						TransactionID: 1024,
+						Hash:          "5f2b7e3c",
+						SignedAt:      signedAt,
+						PublicKey:     "cHVibGljLWtleQ==",
+						Signature:     "c2lnbmF0dXJl",
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":42,"transaction_id":1024,"hash":"5f2b7e3c","signed_at":"2024-02-05T03:00:00Z","public_key":"cHVibGljLWtleQ==","signature":"c2lnbmF0dXJl"}]`,
+		},
+		{
+			name: "No heads",
+			mockBehavior: func(r *mock_usecase.MockChain) {
+				r.EXPECT().GetHeads(context.Background()).Return([]entity.ChainHead{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[]`,
+		},
+		{
+			name: "Something went wrong",
+			mockBehavior: func(r *mock_usecase.MockChain) {
+				r.EXPECT().GetHeads(context.Background()).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			chain := mock_usecase.NewMockChain(c)
+			test.mockBehavior(chain)
+			handler := chainRoutes{
+				ch: chain,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/chain/heads", handler.getHeads)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/chain/heads", nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+const (
+	// Interval of keep-alive messages of idle subscriptions
+	eventsHeartbeat = 15 * time.Second
+	// Timeout of writing a message to a WebSocket client
+	eventsWriteTimeout = 10 * time.Second
+)
+
+var eventsUpgrader = websocket.Upgrader{}
+
+type eventRoutes struct {
+	e usecase.Event
+	l logger.Interface
+}
+
+func newEventRoutes(handler *gin.RouterGroup, admin *gin.RouterGroup, e usecase.Event, l logger.Interface) {
+	r := &eventRoutes{e, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.GET("/:walletId/events", r.streamEvents)
+		h.GET("/:walletId/events/ws", r.websocketEvents)
+	}
+
+	a := admin.Group("/wallet")
+	{
+		a.GET("/:walletId/events-token", r.getSubscriptionToken)
+	}
+}
+
+// @Summary     Подписка на события кошелька (SSE)
+// @Description Передает события кошелька в формате Server-Sent Events сразу после проведения операций: входящие и исходящие переводы и прочие изменения баланса.
+// @Description
+// @Description Токен подписки передается в заголовке Authorization: Bearer или в параметре token. Поле id события SSE содержит его номер sequence. После переподключения события продолжаются с события, следующего за Last-Event-ID
+// @Tags  	    Wallet
+// @Produce     text/event-stream
+// @Param walletId path string true "ID кошелька"
+// @Param token query string false "Токен подписки"
+// @Param last_event_id query int false "Номер (sequence) последнего полученного события"
+// @Param Last-Event-ID header int false "Номер (sequence) последнего полученного события"
+// @Success     200 {object} entity.WalletEvent "Поток событий"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Неверный токен подписки"
+// @Router      /wallet/{walletId}/events [get]
+func (r *eventRoutes) streamEvents(c *gin.Context) {
+	lastEventId, ok := r.authorize(c, "http - v1 - streamEvents")
+	if !ok {
+		return
+	}
+
+	events, err := r.e.Subscribe(c.Request.Context(), c.Param("walletId"), lastEventId)
+	if err != nil {
+		r.l.Error(err, "http - v1 - streamEvents")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		// The stream ends when the client disconnects or falls behind and has to resume
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				r.l.Error(err, "http - v1 - streamEvents")
+
+				return
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// @Summary     Подписка на события кошелька (WebSocket)
+// @Description Передает события кошелька в виде JSON-сообщений WebSocket сразу после проведения операций.
+// @Description
+// @Description Токен подписки передается в заголовке Authorization: Bearer или в параметре token. После переподключения события продолжаются с события, следующего за номером last_event_id (поле sequence события)
+// @Tags  	    Wallet
+// @Param walletId path string true "ID кошелька"
+// @Param token query string false "Токен подписки"
+// @Param last_event_id query int false "Номер (sequence) последнего полученного события"
+// @Success     101 {object} entity.WalletEvent "Соединение WebSocket установлено"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Неверный токен подписки"
+// @Router      /wallet/{walletId}/events/ws [get]
+func (r *eventRoutes) websocketEvents(c *gin.Context) {
+	lastEventId, ok := r.authorize(c, "http - v1 - websocketEvents")
+	if !ok {
+		return
+	}
+
+	// The request context isn't cancelled after the upgrade, so the subscription ends with the reader
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events, err := r.e.Subscribe(ctx, c.Param("walletId"), lastEventId)
+	if err != nil {
+		r.l.Error(err, "http - v1 - websocketEvents")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	conn, err := eventsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		r.l.Error(err, "http - v1 - websocketEvents")
+
+		return
+	}
+	defer conn.Close()
+
+	// Reading control messages until the client disconnects
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// The client has fallen behind or disconnected, it resumes from the last received event
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, ""), time.Now().Add(eventsWriteTimeout))
+
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(eventsWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				r.l.Error(err, "http - v1 - websocketEvents")
+
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// @Summary     Выпуск токена подписки на события кошелька
+// @Description Возвращает токен, с которым клиент может подписаться на события кошелька. Токен не истекает и зависит только от ID кошелька и секрета EVENTS_SECRET
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param walletId path string true "ID кошелька"
+// @Success     200 {object} entity.EventSubscription "Токен выпущен"
+// @Failure     401 "Требуется токен администратора"
+// @Router      /admin/wallet/{walletId}/events-token [get]
+func (r *eventRoutes) getSubscriptionToken(c *gin.Context) {
+	c.JSON(http.StatusOK, r.e.SubscriptionToken(c.Param("walletId")))
+}
+
+// authorize - checking the subscription token and getting the last received event of the client.
+// Browsers can't set headers of EventSource and WebSocket requests, so both are accepted in the query as well.
+func (r *eventRoutes) authorize(c *gin.Context, op string) (int64, bool) {
+	token := c.Query("token")
+	if header := c.GetHeader("Authorization"); header != "" {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if !r.e.CheckSubscriptionToken(c.Param("walletId"), token) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+
+		return 0, false
+	}
+
+	lastEventId := c.Query("last_event_id")
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		lastEventId = header
+	}
+	if lastEventId == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseInt(lastEventId, 10, 64)
+	if err != nil || id < 0 {
+		r.l.Error(fmt.Errorf("wrong last event id %q", lastEventId), op)
+		c.AbortWithStatus(http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	return id, true
+}
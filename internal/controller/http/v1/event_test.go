@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// testEvents - a subscription which ends after the events
+func testEvents(events ...entity.WalletEvent) <-chan entity.WalletEvent {
+	ch := make(chan entity.WalletEvent, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+
+	return ch
+}
+
+func Test_streamEvents(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockEvent, id string)
+
+	sentAt, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35Z")
+
+	tests := []struct {
+		name                 string
+		id                   string
+		query                string
+		headers              map[string]string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			id:      "5b53700ed469fa6a09ea72bb78f36fd9",
+			headers: map[string]string{"Authorization": "Bearer token", "Last-Event-ID": "41"},
+			mockBehavior: func(r *mock_usecase.MockEvent, id string) {
+				r.EXPECT().CheckSubscriptionToken(id, "token").Return(true)
+				r.EXPECT().Subscribe(context.Background(), id, int64(41)).Return(testEvents(
+					entity.WalletEvent{
+						ID:              57,
+						WalletID:        id,
+						Sequence:        42,
+						Type:            entity.WalletEventIncomingTransfer,
+						Time:            sentAt,
+						Amount:          30.0,
+						Balance:         130.0,
+						Counterparty:    "eb376add88bf8e70f80787266a0801d5",
+						TransactionType: entity.TransactionTypeTransfer,
+					},
+				), nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: "id: 42\nevent: incoming_transfer\n" +
+				`data: {"id":57,"sequence":42,"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","type":"incoming_transfer","time":"2024-02-04T17:25:35Z","amount":30,"balance":130,"counterparty":"eb376add88bf8e70f80787266a0801d5","transaction_type":"transfer"}` +
+				"\n\n",
+		},
+		{
+			name:  "Ok - token and last event in query",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?token=token&last_event_id=7",
+			mockBehavior: func(r *mock_usecase.MockEvent, id string) {
+				r.EXPECT().CheckSubscriptionToken(id, "token").Return(true)
+				r.EXPECT().Subscribe(context.Background(), id, int64(7)).Return(testEvents(), nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Unauthorized",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?token=wrong",
+			mockBehavior: func(r *mock_usecase.MockEvent, id string) {
+				r.EXPECT().CheckSubscriptionToken(id, "wrong").Return(false)
+			},
+			expectedStatusCode:   401,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Wrong input - wrong last event id",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?token=token&last_event_id=last",
+			mockBehavior: func(r *mock_usecase.MockEvent, id string) {
+				r.EXPECT().CheckSubscriptionToken(id, "token").Return(true)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Something went wrong",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?token=token",
+			mockBehavior: func(r *mock_usecase.MockEvent, id string) {
+				r.EXPECT().CheckSubscriptionToken(id, "token").Return(true)
+				r.EXPECT().Subscribe(context.Background(), id, int64(0)).Return(nil, fmt.Errorf("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			event := mock_usecase.NewMockEvent(c)
+			test.mockBehavior(event, test.id)
+			handler := eventRoutes{
+				e: event,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/:walletId/events", handler.streamEvents)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/events%s", test.id, test.query), nil)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_websocketEvents(t *testing.T) {
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	id := "5b53700ed469fa6a09ea72bb78f36fd9"
+	event := mock_usecase.NewMockEvent(c)
+	event.EXPECT().CheckSubscriptionToken(id, "token").Return(true)
+	event.EXPECT().Subscribe(gomock.Any(), id, int64(41)).Return(testEvents(
+		entity.WalletEvent{ID: 57, WalletID: id, Sequence: 42, Type: entity.WalletEventBalanceChanged, Amount: 5.5, Balance: 105.5},
+	), nil)
+	handler := eventRoutes{
+		e: event,
+		l: logger.New(""),
+	}
+	// Init Endpoint
+	r := gin.New()
+	r.GET("/:walletId/events/ws", handler.websocketEvents)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	// Make Request
+	url := fmt.Sprintf("ws%s/%s/events/ws?token=token&last_event_id=41", strings.TrimPrefix(server.URL, "http"), id)
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Assert
+	assert.Equal(t, resp.StatusCode, http.StatusSwitchingProtocols)
+
+	var received entity.WalletEvent
+	if err := conn.ReadJSON(&received); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, received.Sequence, int64(42))
+	assert.Equal(t, received.Balance, 105.5)
+
+	// The subscription has ended, so the client is asked to reconnect
+	_, _, err = conn.ReadMessage()
+	assert.Equal(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), true)
+}
+
+func Test_getSubscriptionToken(t *testing.T) {
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	id := "5b53700ed469fa6a09ea72bb78f36fd9"
+	event := mock_usecase.NewMockEvent(c)
+	event.EXPECT().SubscriptionToken(id).Return(&entity.EventSubscription{WalletID: id, Token: "0a1b2c"})
+	handler := eventRoutes{
+		e: event,
+		l: logger.New(""),
+	}
+	// Init Endpoint
+	r := gin.New()
+	r.GET("/:walletId/events-token", handler.getSubscriptionToken)
+	// Create Request
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/%s/events-token", id), nil)
+	// Make Request
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, w.Code, 200)
+	assert.Equal(t, w.Body.String(), `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","token":"0a1b2c"}`)
+}
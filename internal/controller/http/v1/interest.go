@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type interestRoutes struct {
+	i usecase.Interest
+	l logger.Interface
+}
+
+func newInterestRoutes(handler *gin.RouterGroup, admin *gin.RouterGroup, i usecase.Interest, l logger.Interface) {
+	r := &interestRoutes{i, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.GET("/:walletId/interest", r.getInterestAccruals)
+	}
+
+	a := admin.Group("/interest")
+	{
+		a.POST("/accrue", r.accrueInterest)
+	}
+}
+
+// @Summary     Получение начислений процентов
+// @Description Возвращает ежедневные начисления процентов по сберегательному кошельку. Начисления зачисляются на баланс раз в месяц.
+// @Tags  	    Wallet
+// @Param walletId path string true "ID кошелька"
+// @Success     200 {object} []entity.InterestAccrual "Начисления получены"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /wallet/{walletId}/interest [get]
+func (r *interestRoutes) getInterestAccruals(c *gin.Context) {
+	accruals, err := r.i.GetInterestAccruals(c.Request.Context(), c.Param("walletId"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getInterestAccruals")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, accruals)
+}
+
+// @Summary     Начисление процентов за день
+// @Description Начисляет проценты по сберегательным кошелькам за указанный завершившийся день (UTC). Повторный запуск за тот же день не начисляет проценты повторно.
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param day query string true "День в формате YYYY-MM-DD"
+// @Success     200 {object} integer "Количество новых начислений"
+// @Failure     400 "Ошибка в запросе или день еще не завершился"
+// @Failure     401 "Требуется токен администратора"
+// @Router      /admin/interest/accrue [post]
+func (r *interestRoutes) accrueInterest(c *gin.Context) {
+	day, err := time.Parse(time.DateOnly, c.Query("day"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - accrueInterest")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	accrued, err := r.i.AccrueInterest(c.Request.Context(), day)
+	if errors.Is(err, entity.ErrDayIsNotOver) {
+		r.l.Error(err, "http - v1 - accrueInterest")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - accrueInterest")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, accrued)
+}
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_getInterestAccruals(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockInterest, id string)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockInterest, id string) {
+				day, _ := time.Parse(time.RFC3339, "2024-02-04T00:00:00Z")
+
+				r.EXPECT().GetInterestAccruals(context.Background(), id).Return([]entity.InterestAccrual{
+					{
+						WalletID:   id,
+						Day:        day,
+						Balance:    1000.0,
+						AnnualRate: 0.05,
+						Amount:     0.136986,
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","day":"2024-02-04T00:00:00Z","balance":1000,"annual_rate":0.05,"amount":0.136986}]`,
+		},
+		{
+			name: "Ok - no accruals",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockInterest, id string) {
+				r.EXPECT().GetInterestAccruals(context.Background(), id).Return([]entity.InterestAccrual{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[]`,
+		},
+		{
+			name: "Not Found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockInterest, id string) {
+				r.EXPECT().GetInterestAccruals(context.Background(), id).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			interest := mock_usecase.NewMockInterest(c)
+			test.mockBehavior(interest, test.id)
+			handler := interestRoutes{
+				i: interest,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/:walletId/interest", handler.getInterestAccruals)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/interest", test.id), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_accrueInterest(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockInterest, day time.Time)
+
+	tests := []struct {
+		name                 string
+		day                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			day:  "2024-02-04",
+			mockBehavior: func(r *mock_usecase.MockInterest, day time.Time) {
+				r.EXPECT().AccrueInterest(context.Background(), day).Return(3, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `3`,
+		},
+		{
+			name:                 "Wrong input - wrong day format",
+			day:                  "04.02.2024",
+			mockBehavior:         func(r *mock_usecase.MockInterest, day time.Time) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Day is not over",
+			day:  "2024-02-04",
+			mockBehavior: func(r *mock_usecase.MockInterest, day time.Time) {
+				r.EXPECT().AccrueInterest(context.Background(), day).Return(0, entity.ErrDayIsNotOver)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			day:  "2024-02-04",
+			mockBehavior: func(r *mock_usecase.MockInterest, day time.Time) {
+				r.EXPECT().AccrueInterest(context.Background(), day).Return(0, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			interest := mock_usecase.NewMockInterest(c)
+			day, _ := time.Parse(time.DateOnly, test.day)
+			test.mockBehavior(interest, day)
+			handler := interestRoutes{
+				i: interest,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/accrue", handler.accrueInterest)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/accrue?day=%s", test.day), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// requestIDHeader - header of the request id, it is returned in the response too.
+const requestIDHeader = "X-Request-ID"
+
+// adminAuth - allowing only requests with the admin bearer token.
+// If the token isn't configured, admin routes are unavailable.
+func adminAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatus(http.StatusUnauthorized)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// auditTrail - recording the requests changing the state in the audit log. Changes of the wallets are recorded
+// by the use case with their before and after values, other requests are recorded here as "<method> <route>".
+// Requests to the routes under adminPath are made by the admin.
+func auditTrail(au usecase.Audit, adminPath string, l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := entity.AuditActor{
+			Actor:     entity.AuditActorClient,
+			ClientIP:  c.ClientIP(),
+			RequestID: c.GetHeader(requestIDHeader),
+		}
+		if strings.HasPrefix(c.FullPath(), adminPath) {
+			actor.Actor = entity.AuditActorAdmin
+		}
+		if !validRequestID(actor.RequestID) {
+			actor.RequestID = usecase.NewRequestID()
+		}
+		c.Header(requestIDHeader, actor.RequestID)
+
+		ctx := usecase.WithAudit(c.Request.Context(), actor)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if c.FullPath() == "" || usecase.AuditRecorded(ctx) {
+			return
+		}
+
+		// Rejected admin requests are recorded as made by an unknown actor
+		if c.Writer.Status() == http.StatusUnauthorized {
+			actor.Actor = entity.AuditActorUnauthorized
+			ctx = usecase.WithAudit(ctx, actor)
+		}
+		entry := entity.AuditEntry{
+			Operation: c.Request.Method + " " + c.FullPath(),
+			WalletID:  c.Param("walletId"),
+		}
+		if c.Writer.Status() >= http.StatusBadRequest {
+			entry.Error = http.StatusText(c.Writer.Status())
+		}
+
+		// The request is recorded even if the client is gone
+		if err := au.Record(context.WithoutCancel(ctx), entry); err != nil {
+			l.Error(err, "http - v1 - auditTrail")
+		}
+	}
+}
+
+// validRequestID - the request id of the client is kept if it is short and printable.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > entity.MaxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
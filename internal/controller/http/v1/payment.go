@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// gatewaySignatureHeader - header with the signature of a gateway notification.
+const gatewaySignatureHeader = "X-Gateway-Signature"
+
+type paymentRoutes struct {
+	p usecase.Payment
+	l logger.Interface
+}
+
+func newPaymentRoutes(handler *gin.RouterGroup, p usecase.Payment, l logger.Interface) {
+	r := &paymentRoutes{p, l}
+
+	w := handler.Group("/wallet")
+	{
+		w.POST("/:walletId/deposit", r.deposit)
+		w.POST("/:walletId/withdraw", r.withdraw)
+	}
+
+	h := handler.Group("/payments")
+	{
+		h.POST("/callback", r.handleCallback)
+		h.GET("/:paymentId", r.getPaymentById)
+	}
+}
+
+// @Summary     Пополнение кошелька через платежный шлюз
+// @Description Создает платеж в статусе pending. Кошелек пополняется после подтверждения платежа шлюзом.
+// @Tags  	    Payment
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.PaymentRequest true "Запрос пополнения"
+// @Success     202 {object} entity.Payment "Платеж создан"
+// @Failure     400 "Ошибка в запросе, кошелек заморожен или закрыт, ошибка платежного шлюза"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /wallet/{walletId}/deposit [post]
+func (r *paymentRoutes) deposit(c *gin.Context) {
+	var request entity.PaymentRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - deposit")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	payment, err := r.p.Deposit(c.Request.Context(), c.Param("walletId"), request.Amount)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - deposit")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - deposit")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, payment)
+}
+
+// @Summary     Вывод средств через платежный шлюз
+// @Description Создает платеж в статусе pending и резервирует средства. Если шлюз отклонит платеж, средства вернутся на кошелек.
+// @Tags  	    Payment
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.PaymentRequest true "Запрос вывода средств"
+// @Success     202 {object} entity.Payment "Платеж создан"
+// @Failure     400 "Ошибка в запросе, недостаточно средств или ошибка платежного шлюза"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /wallet/{walletId}/withdraw [post]
+func (r *paymentRoutes) withdraw(c *gin.Context) {
+	var request entity.PaymentRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - withdraw")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	payment, err := r.p.Withdraw(c.Request.Context(), c.Param("walletId"), request.Amount)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - withdraw")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - withdraw")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, payment)
+}
+
+// @Summary     Уведомление платежного шлюза
+// @Description Принимает подписанное уведомление о результате платежа. Повторные уведомления с тем же статусом игнорируются.
+// @Tags  	    Payment
+// @Param X-Gateway-Signature header string true "Подпись уведомления"
+// @Param input body entity.PaymentNotification true "Уведомление"
+// @Success     200 {object} entity.Payment "Уведомление обработано"
+// @Failure     400 "Ошибка в уведомлении или кошелек заморожен или закрыт"
+// @Failure     401 "Неверная подпись"
+// @Failure     404 "Платеж не найден"
+// @Failure     409 "Платеж уже завершен с другим статусом"
+// @Router      /payments/callback [post]
+func (r *paymentRoutes) handleCallback(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		r.l.Error(err, "http - v1 - handleCallback")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	payment, err := r.p.HandleNotification(c.Request.Context(), payload, c.GetHeader(gatewaySignatureHeader))
+	if errors.Is(err, entity.ErrWrongSignature) {
+		r.l.Error(err, "http - v1 - handleCallback")
+		c.Status(http.StatusUnauthorized)
+
+		return
+	}
+	if errors.Is(err, entity.ErrPaymentNotFound) {
+		r.l.Error(err, "http - v1 - handleCallback")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrPaymentAlreadyCompleted) {
+		r.l.Error(err, "http - v1 - handleCallback")
+		c.Status(http.StatusConflict)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - handleCallback")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary     Получение состояния платежа
+// @Tags  	    Payment
+// @Param paymentId path string true "ID платежа"
+// @Success     200 {object} entity.Payment "OK"
+// @Failure     404 "Платеж не найден"
+// @Router      /payments/{paymentId} [get]
+func (r *paymentRoutes) getPaymentById(c *gin.Context) {
+	payment, err := r.p.GetPaymentById(c.Request.Context(), c.Param("paymentId"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getPaymentById")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_deposit(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockPayment, id string, request entity.PaymentRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              entity.PaymentRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.PaymentRequest{
+				Amount: 50.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPayment, id string, request entity.PaymentRequest) {
+				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
+
+				r.EXPECT().Deposit(context.Background(), id, request.Amount).Return(&entity.Payment{
+					ID:               "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e",
+					WalletID:         id,
+					Type:             entity.PaymentTypeDeposit,
+					Amount:           50.0,
+					Status:           entity.PaymentStatusPending,
+					GatewayReference: "fake-3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e",
+					CreatedAt:        t,
+					UpdatedAt:        t,
+				}, nil)
+			},
+			expectedStatusCode:   202,
+			expectedResponseBody: `{"id":"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","type":"deposit","amount":50,"status":"pending","gateway_reference":"fake-3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","created_at":"2024-02-04T17:25:35.448Z","updated_at":"2024-02-04T17:25:35.448Z"}`,
+		},
+		{
+			name: "Not found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.PaymentRequest{
+				Amount: 50.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPayment, id string, request entity.PaymentRequest) {
+				r.EXPECT().Deposit(context.Background(), id, request.Amount).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Wrong input - amount less 0",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.PaymentRequest{
+				Amount: -10.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPayment, id string, request entity.PaymentRequest) {
+				r.EXPECT().Deposit(context.Background(), id, request.Amount).Return(nil, entity.ErrWrongAmount)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			payment := mock_usecase.NewMockPayment(c)
+			test.mockBehavior(payment, test.id, test.request)
+			handler := paymentRoutes{
+				p: payment,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/:walletId/deposit", handler.deposit)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/deposit", test.id), bytes.NewBuffer(reqBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_handleCallback(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockPayment, payload []byte, signature string)
+
+	tests := []struct {
+		name                 string
+		payload              string
+		signature            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			payload:   `{"payment_id":"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","gateway_reference":"fake-3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","status":"succeeded"}`,
+			signature: "c0ffee",
+			mockBehavior: func(r *mock_usecase.MockPayment, payload []byte, signature string) {
+				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
+
+				r.EXPECT().HandleNotification(context.Background(), payload, signature).Return(&entity.Payment{
+					ID:               "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e",
+					WalletID:         "5b53700ed469fa6a09ea72bb78f36fd9",
+					Type:             entity.PaymentTypeDeposit,
+					Amount:           50.0,
+					Status:           entity.PaymentStatusSucceeded,
+					GatewayReference: "fake-3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e",
+					CreatedAt:        t,
+					UpdatedAt:        t,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","type":"deposit","amount":50,"status":"succeeded","gateway_reference":"fake-3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","created_at":"2024-02-04T17:25:35.448Z","updated_at":"2024-02-04T17:25:35.448Z"}`,
+		},
+		{
+			name:      "Wrong signature",
+			payload:   `{"payment_id":"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","status":"succeeded"}`,
+			signature: "bad",
+			mockBehavior: func(r *mock_usecase.MockPayment, payload []byte, signature string) {
+				r.EXPECT().HandleNotification(context.Background(), payload, signature).Return(nil, entity.ErrWrongSignature)
+			},
+			expectedStatusCode:   401,
+			expectedResponseBody: "",
+		},
+		{
+			name:      "Payment not found",
+			payload:   `{"payment_id":"unknown","status":"succeeded"}`,
+			signature: "c0ffee",
+			mockBehavior: func(r *mock_usecase.MockPayment, payload []byte, signature string) {
+				r.EXPECT().HandleNotification(context.Background(), payload, signature).Return(nil, entity.ErrPaymentNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:      "Payment is already completed",
+			payload:   `{"payment_id":"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e","status":"failed"}`,
+			signature: "c0ffee",
+			mockBehavior: func(r *mock_usecase.MockPayment, payload []byte, signature string) {
+				r.EXPECT().HandleNotification(context.Background(), payload, signature).Return(nil, entity.ErrPaymentAlreadyCompleted)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: "",
+		},
+		{
+			name:      "Something went wrong",
+			payload:   `{}`,
+			signature: "c0ffee",
+			mockBehavior: func(r *mock_usecase.MockPayment, payload []byte, signature string) {
+				r.EXPECT().HandleNotification(context.Background(), payload, signature).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			payment := mock_usecase.NewMockPayment(c)
+			test.mockBehavior(payment, []byte(test.payload), test.signature)
+			handler := paymentRoutes{
+				p: payment,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/callback", handler.handleCallback)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/callback", bytes.NewBufferString(test.payload))
+			req.Header.Set(gatewaySignatureHeader, test.signature)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type pocketRoutes struct {
+	p usecase.Pocket
+	l logger.Interface
+}
+
+func newPocketRoutes(handler *gin.RouterGroup, p usecase.Pocket, l logger.Interface) {
+	r := &pocketRoutes{p, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.POST("/:walletId/pockets", r.createPocket)
+		h.POST("/:walletId/pockets/:pocketId/move", r.movePocketFunds)
+		h.DELETE("/:walletId/pockets/:pocketId", r.closePocket)
+	}
+}
+
+// @Summary     Создание копилки
+// @Description Создает пустую копилку под указанным кошельком. Баланс копилки учитывается в общем балансе кошелька.
+// @Tags  	    Pocket
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.PocketRequest true "Запрос создания копилки"
+// @Success     200 {object} entity.Wallet "Копилка создана"
+// @Failure     400 "Ошибка в запросе или кошелек заморожен"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /wallet/{walletId}/pockets [post]
+func (r *pocketRoutes) createPocket(c *gin.Context) {
+	var request entity.PocketRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - createPocket")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	pocket, err := r.p.CreatePocket(c.Request.Context(), c.Param("walletId"), request.Name)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - createPocket")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - createPocket")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pocket)
+}
+
+// @Summary     Перемещение средств между кошельком и копилкой
+// @Description Перемещает средства из кошелька в копилку (in) или обратно (out).
+// @Tags  	    Pocket
+// @Param walletId path string true "ID кошелька"
+// @Param pocketId path string true "ID копилки"
+// @Param input body entity.PocketTransferRequest true "Запрос перемещения"
+// @Success     200 {object} entity.Transaction "Средства перемещены"
+// @Failure     400 "Ошибка в запросе или недостаточно средств"
+// @Failure     404 "Кошелек или копилка не найдены"
+// @Router      /wallet/{walletId}/pockets/{pocketId}/move [post]
+func (r *pocketRoutes) movePocketFunds(c *gin.Context) {
+	var request entity.PocketTransferRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - movePocketFunds")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	transaction, err := r.p.MovePocketFunds(c.Request.Context(), c.Param("walletId"), c.Param("pocketId"), request)
+	if errors.Is(err, entity.ErrWalletNotFound) || errors.Is(err, entity.ErrPocketNotFound) {
+		r.l.Error(err, "http - v1 - movePocketFunds")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - movePocketFunds")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+// @Summary     Закрытие копилки
+// @Description Закрывает копилку, остаток средств возвращается в кошелек.
+// @Tags  	    Pocket
+// @Param walletId path string true "ID кошелька"
+// @Param pocketId path string true "ID копилки"
+// @Success     200 "Копилка закрыта"
+// @Failure     400 "Кошелек или копилка заморожены или уже закрыты"
+// @Failure     404 "Кошелек или копилка не найдены"
+// @Router      /wallet/{walletId}/pockets/{pocketId} [delete]
+func (r *pocketRoutes) closePocket(c *gin.Context) {
+	err := r.p.ClosePocket(c.Request.Context(), c.Param("walletId"), c.Param("pocketId"))
+	if errors.Is(err, entity.ErrWalletNotFound) || errors.Is(err, entity.ErrPocketNotFound) {
+		r.l.Error(err, "http - v1 - closePocket")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - closePocket")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,275 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_createPocket(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockPocket, id string, request entity.PocketRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              entity.PocketRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.PocketRequest{
+				Name: "Vacation",
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, request entity.PocketRequest) {
+				r.EXPECT().CreatePocket(context.Background(), id, request.Name).Return(&entity.Wallet{
+					ID:       "eb376add88bf8e70f80787266a0801d5",
+					Type:     entity.WalletTypeStandard,
+					Status:   entity.WalletStatusActive,
+					ParentID: id,
+					Name:     request.Name,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"eb376add88bf8e70f80787266a0801d5","balance":0,"type":"standard","status":"active","parent_id":"5b53700ed469fa6a09ea72bb78f36fd9","name":"Vacation"}`,
+		},
+		{
+			name: "Wallet not found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.PocketRequest{
+				Name: "Vacation",
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, request entity.PocketRequest) {
+				r.EXPECT().CreatePocket(context.Background(), id, request.Name).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Pocket of a pocket",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.PocketRequest{
+				Name: "Vacation",
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, request entity.PocketRequest) {
+				r.EXPECT().CreatePocket(context.Background(), id, request.Name).Return(nil, entity.ErrNestedPocket)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockPocket(c)
+			test.mockBehavior(repo, test.id, test.request)
+			handler := pocketRoutes{
+				p: repo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/:walletId/pockets", handler.createPocket)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/pockets", test.id), bytes.NewBuffer(reqBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_movePocketFunds(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockPocket, id string, pocketId string, request entity.PocketTransferRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		pocketId             string
+		request              entity.PocketTransferRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:     "Ok",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			request: entity.PocketTransferRequest{
+				Amount:    50.0,
+				Direction: entity.PocketDirectionIn,
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string, request entity.PocketTransferRequest) {
+				r.EXPECT().MovePocketFunds(context.Background(), id, pocketId, request).Return(&entity.Transaction{
+					From:   id,
+					To:     pocketId,
+					Amount: request.Amount,
+					Type:   entity.TransactionTypePocketTransfer,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"time":"0001-01-01T00:00:00Z","from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":50,"type":"pocket_transfer"}`,
+		},
+		{
+			name:     "Pocket not found",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			request: entity.PocketTransferRequest{
+				Amount:    50.0,
+				Direction: entity.PocketDirectionOut,
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string, request entity.PocketTransferRequest) {
+				r.EXPECT().MovePocketFunds(context.Background(), id, pocketId, request).Return(nil, entity.ErrPocketNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:     "Insufficient funds",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			request: entity.PocketTransferRequest{
+				Amount:    500.0,
+				Direction: entity.PocketDirectionOut,
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string, request entity.PocketTransferRequest) {
+				r.EXPECT().MovePocketFunds(context.Background(), id, pocketId, request).Return(nil, entity.ErrInsufficientFunds)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:     "Frozen wallet",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			request: entity.PocketTransferRequest{
+				Amount:    50.0,
+				Direction: entity.PocketDirectionIn,
+			},
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string, request entity.PocketTransferRequest) {
+				r.EXPECT().MovePocketFunds(context.Background(), id, pocketId, request).Return(nil, entity.ErrWalletFrozen)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockPocket(c)
+			test.mockBehavior(repo, test.id, test.pocketId, test.request)
+			handler := pocketRoutes{
+				p: repo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/:walletId/pockets/:pocketId/move", handler.movePocketFunds)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/pockets/%s/move", test.id, test.pocketId), bytes.NewBuffer(reqBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_closePocket(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockPocket, id string, pocketId string)
+
+	tests := []struct {
+		name               string
+		id                 string
+		pocketId           string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:     "Ok",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string) {
+				r.EXPECT().ClosePocket(context.Background(), id, pocketId).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:     "Pocket not found",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string) {
+				r.EXPECT().ClosePocket(context.Background(), id, pocketId).Return(entity.ErrPocketNotFound)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:     "Already closed",
+			id:       "5b53700ed469fa6a09ea72bb78f36fd9",
+			pocketId: "eb376add88bf8e70f80787266a0801d5",
+			mockBehavior: func(r *mock_usecase.MockPocket, id string, pocketId string) {
+				r.EXPECT().ClosePocket(context.Background(), id, pocketId).Return(entity.ErrWalletClosed)
+			},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockPocket(c)
+			test.mockBehavior(repo, test.id, test.pocketId)
+			handler := pocketRoutes{
+				p: repo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.DELETE("/:walletId/pockets/:pocketId", handler.closePocket)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/%s/pockets/%s", test.id, test.pocketId), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+		})
+	}
+}
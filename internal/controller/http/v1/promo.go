@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type promoRoutes struct {
+	p usecase.Promo
+	l logger.Interface
+}
+
+func newPromoRoutes(handler *gin.RouterGroup, p usecase.Promo, l logger.Interface) {
+	r := &promoRoutes{p, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.POST("/:walletId/promo", r.grantPromo)
+	}
+}
+
+// @Summary     Начисление промо-баланса
+// @Description Начисляет кошельку бонусные средства, которые сгорают по истечении срока действия.
+// @Description
+// @Description Промо-баланс расходуется в порядке, заданном в конфигурации
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.PromoGrantRequest true "Запрос начисления"
+// @Success     200 {object} entity.PromoGrant "Промо-баланс начислен"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /admin/wallet/{walletId}/promo [post]
+func (r *promoRoutes) grantPromo(c *gin.Context) {
+	var request entity.PromoGrantRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - grantPromo")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	grant, err := r.p.GrantPromo(c.Request.Context(), c.Param("walletId"), request.Amount)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - grantPromo")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - grantPromo")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, grant)
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_grantPromo(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockPromo, id string, request entity.PromoGrantRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		token                string
+		request              entity.PromoGrantRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			token: "Bearer secret",
+			request: entity.PromoGrantRequest{
+				Amount: 50.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPromo, id string, request entity.PromoGrantRequest) {
+				grantedAt, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
+				expiresAt, _ := time.Parse(time.RFC3339, "2024-03-05T17:25:35.448Z")
+
+				r.EXPECT().GrantPromo(context.Background(), id, request.Amount).Return(&entity.PromoGrant{
+					ID:        1,
+					WalletID:  id,
+					Amount:    50.0,
+					Remaining: 50.0,
+					GrantedAt: grantedAt,
+					ExpiresAt: expiresAt,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","amount":50,"remaining":50,"granted_at":"2024-02-04T17:25:35.448Z","expires_at":"2024-03-05T17:25:35.448Z"}`,
+		},
+		{
+			name:  "Unauthorized",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			token: "Bearer wrong",
+			request: entity.PromoGrantRequest{
+				Amount: 50.0,
+			},
+			mockBehavior:         func(r *mock_usecase.MockPromo, id string, request entity.PromoGrantRequest) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Not found",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			token: "Bearer secret",
+			request: entity.PromoGrantRequest{
+				Amount: 50.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPromo, id string, request entity.PromoGrantRequest) {
+				r.EXPECT().GrantPromo(context.Background(), id, request.Amount).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Wrong input - amount less 0",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			token: "Bearer secret",
+			request: entity.PromoGrantRequest{
+				Amount: -10.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPromo, id string, request entity.PromoGrantRequest) {
+				r.EXPECT().GrantPromo(context.Background(), id, request.Amount).Return(nil, entity.ErrWrongAmount)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Something went wrong",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			token: "Bearer secret",
+			request: entity.PromoGrantRequest{
+				Amount: 50.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockPromo, id string, request entity.PromoGrantRequest) {
+				r.EXPECT().GrantPromo(context.Background(), id, request.Amount).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			promo := mock_usecase.NewMockPromo(c)
+			test.mockBehavior(promo, test.id, test.request)
+			handler := promoRoutes{
+				p: promo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/:walletId/promo", adminAuth("secret"), handler.grantPromo)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/promo", test.id), bytes.NewBuffer(reqBody))
+			req.Header.Set("Authorization", test.token)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
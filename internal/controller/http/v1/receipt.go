@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type receiptRoutes struct {
+	rp usecase.Receipt
+	l  logger.Interface
+}
+
+func newReceiptRoutes(handler *gin.RouterGroup, rp usecase.Receipt, l logger.Interface) {
+	r := &receiptRoutes{rp, l}
+
+	t := handler.Group("/transaction")
+	{
+		t.GET("/:transactionId/receipt", r.getReceipt)
+	}
+
+	h := handler.Group("/receipts")
+	{
+		h.POST("/verify", r.verifyReceipt)
+		h.GET("/key", r.getPublicKey)
+	}
+}
+
+// @Summary     Получение квитанции о переводе
+// @Description Возвращает подписанную квитанцию о переводе для одного из его участников. Перевод другого кошелька не будет найден
+// @Tags  	    Receipt
+// @Param transactionId path int true "ID перевода"
+// @Param wallet_id query string true "ID исходящего или входящего кошелька перевода"
+// @Success     200 {object} entity.Receipt "Квитанция получена"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     404 "Перевод не найден или сервер не выдает квитанции"
+// @Failure     500 "Внутренняя ошибка сервера"
+// @Router      /transaction/{transactionId}/receipt [get]
+func (r *receiptRoutes) getReceipt(c *gin.Context) {
+	transactionId, err := strconv.ParseInt(c.Param("transactionId"), 10, 64)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getReceipt")
+		c.AbortWithStatus(http.StatusBadRequest)
+
+		return
+	}
+
+	receipt, err := r.rp.GetReceipt(c.Request.Context(), transactionId, c.Query("wallet_id"))
+	if errors.Is(err, entity.ErrTransactionNotFound) || errors.Is(err, entity.ErrReceiptSigningKeyMissing) {
+		r.l.Error(err, "http - v1 - getReceipt")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - getReceipt")
+		c.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// @Summary     Проверка квитанции о переводе
+// @Description Проверяет, что квитанция подписана текущим ключом сервера и не изменена. Квитанцию можно проверить и без сервера открытым ключом из /receipts/key
+// @Tags  	    Receipt
+// @Param input body entity.Receipt true "Квитанция о переводе"
+// @Success     200 {object} entity.ReceiptVerification "Квитанция проверена"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     404 "Сервер не выдает квитанции"
+// @Router      /receipts/verify [post]
+func (r *receiptRoutes) verifyReceipt(c *gin.Context) {
+	var receipt entity.Receipt
+
+	if err := c.BindJSON(&receipt); err != nil {
+		r.l.Error(err, "http - v1 - verifyReceipt")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	verification, err := r.rp.VerifyReceipt(receipt)
+	if err != nil {
+		r.l.Error(err, "http - v1 - verifyReceipt")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// @Summary     Получение открытого ключа квитанций
+// @Description Возвращает открытый ключ ed25519, которым проверяется подпись строки "receipt:<transaction_id>:<from>:<to>:<amount>:<time>" квитанции. Сумма записывается в кратчайшей десятичной форме, время - в RFC 3339 в UTC
+// @Tags  	    Receipt
+// @Success     200 {object} entity.ReceiptKey "Ключ получен"
+// @Failure     404 "Сервер не выдает квитанции"
+// @Router      /receipts/key [get]
+func (r *receiptRoutes) getPublicKey(c *gin.Context) {
+	key, err := r.rp.GetPublicKey()
+	if err != nil {
+		r.l.Error(err, "http - v1 - getPublicKey")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func testReceipt() *entity.Receipt {
+	return &entity.Receipt{
+		TransactionID: 1024,
+		From:          "5b53700ed469fa6a09ea72bb78f36fd9",
+		To:            "eb376add88bf8e70f80787266a0801d5",
+		Amount:        30.0,
+		Time:          time.Date(2024, 2, 4, 17, 25, 35, 448000000, time.UTC),
+		PublicKey:     "cHVibGljLWtleQ==",
+		Signature:     "c2lnbmF0dXJl",
+	}
+}
+
+const testReceiptBody = `{"transaction_id":1024,"from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30,` +
+	`"time":"2024-02-04T17:25:35.448Z","public_key":"cHVibGljLWtleQ==","signature":"c2lnbmF0dXJl"}`
+
+func Test_getReceipt(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockReceipt)
+
+	tests := []struct {
+		name                 string
+		target               string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Ok",
+			target: "/transaction/1024/receipt?wallet_id=5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().GetReceipt(context.Background(), int64(1024), "5b53700ed469fa6a09ea72bb78f36fd9").Return(testReceipt(), nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: testReceiptBody,
+		},
+		{
+			name:                 "Wrong transaction id",
+			target:               "/transaction/abc/receipt?wallet_id=5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior:         func(r *mock_usecase.MockReceipt) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:   "Not found",
+			target: "/transaction/1024/receipt?wallet_id=0c4f8a7b2e6d4c1f9a3b5d7e8f0a1b2c",
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().GetReceipt(context.Background(), int64(1024), "0c4f8a7b2e6d4c1f9a3b5d7e8f0a1b2c").Return(nil, entity.ErrTransactionNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:   "Receipts are not issued",
+			target: "/transaction/1024/receipt?wallet_id=5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().GetReceipt(context.Background(), int64(1024), "5b53700ed469fa6a09ea72bb78f36fd9").Return(nil, entity.ErrReceiptSigningKeyMissing)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:   "Something went wrong",
+			target: "/transaction/1024/receipt?wallet_id=5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().GetReceipt(context.Background(), int64(1024), "5b53700ed469fa6a09ea72bb78f36fd9").Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			receipt := mock_usecase.NewMockReceipt(c)
+			test.mockBehavior(receipt)
+			handler := receiptRoutes{
+				rp: receipt,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/transaction/:transactionId/receipt", handler.getReceipt)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.target, nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_verifyReceipt(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockReceipt)
+
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Valid",
+			requestBody: testReceiptBody,
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().VerifyReceipt(*testReceipt()).Return(&entity.ReceiptVerification{Valid: true}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"valid":true}`,
+		},
+		{
+			name:        "Invalid",
+			requestBody: strings.Replace(testReceiptBody, `"amount":30`, `"amount":300`, 1),
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				receipt := testReceipt()
+				receipt.Amount = 300.0
+				r.EXPECT().VerifyReceipt(*receipt).Return(&entity.ReceiptVerification{Valid: false}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"valid":false}`,
+		},
+		{
+			name:                 "Wrong input",
+			requestBody:          `{"transaction_id":"abc"}`,
+			mockBehavior:         func(r *mock_usecase.MockReceipt) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:        "Receipts are not issued",
+			requestBody: testReceiptBody,
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().VerifyReceipt(*testReceipt()).Return(nil, entity.ErrReceiptSigningKeyMissing)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			receipt := mock_usecase.NewMockReceipt(c)
+			test.mockBehavior(receipt)
+			handler := receiptRoutes{
+				rp: receipt,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/receipts/verify", handler.verifyReceipt)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/receipts/verify", strings.NewReader(test.requestBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_getPublicKey(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockReceipt)
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().GetPublicKey().Return(&entity.ReceiptKey{Algorithm: entity.ReceiptAlgorithm, PublicKey: "cHVibGljLWtleQ=="}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"algorithm":"ed25519","public_key":"cHVibGljLWtleQ=="}`,
+		},
+		{
+			name: "Receipts are not issued",
+			mockBehavior: func(r *mock_usecase.MockReceipt) {
+				r.EXPECT().GetPublicKey().Return(nil, entity.ErrReceiptSigningKeyMissing)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			receipt := mock_usecase.NewMockReceipt(c)
+			test.mockBehavior(receipt)
+			handler := receiptRoutes{
+				rp: receipt,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/receipts/key", handler.getPublicKey)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/receipts/key", nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type reconciliationRoutes struct {
+	r usecase.Reconciliation
+	l logger.Interface
+}
+
+func newReconciliationRoutes(admin *gin.RouterGroup, rc usecase.Reconciliation, l logger.Interface) {
+	r := &reconciliationRoutes{rc, l}
+
+	a := admin.Group("/reconciliation")
+	{
+		a.GET("", r.getLastReconciliation)
+	}
+}
+
+// @Summary     Получение результата последней сверки
+// @Description Возвращает результат последней сверки балансов кошельков с начальными балансами и операциями, а также проверку сохранения общей суммы средств.
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Success     200 {object} entity.Reconciliation "Результат сверки получен"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Сверка еще не проводилась"
+// @Failure     500 "Внутренняя ошибка сервера"
+// @Router      /admin/reconciliation [get]
+func (r *reconciliationRoutes) getLastReconciliation(c *gin.Context) {
+	reconciliation, err := r.r.GetLastReconciliation(c.Request.Context())
+	if errors.Is(err, entity.ErrReconciliationNotFound) {
+		r.l.Error(err, "http - v1 - getLastReconciliation")
+		c.AbortWithStatus(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - getLastReconciliation")
+		c.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliation)
+}
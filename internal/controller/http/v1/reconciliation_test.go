@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_getLastReconciliation(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockReconciliation)
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_usecase.MockReconciliation) {
+				startedAt, _ := time.Parse(time.RFC3339, "2024-02-05T03:00:00Z")
+				finishedAt, _ := time.Parse(time.RFC3339, "2024-02-05T03:02:15Z")
+
+				r.EXPECT().GetLastReconciliation(context.Background()).Return(&entity.Reconciliation{
+					ID:                  42,
+					StartedAt:           startedAt,
+					FinishedAt:          finishedAt,
+					Wallets:             3,
+					Mismatched:          1,
+					TotalBalance:        310.0,
+					TotalInitialBalance: 300.0,
+					Conserved:           false,
+					Mismatches: []entity.BalanceMismatch{
+						{
+							WalletID:       "5b53700ed469fa6a09ea72bb78f36fd9",
+							Balance:        140.0,
+							InitialBalance: 100.0,
+							Incoming:       30.0,
+							Expected:       130.0,
+							Difference:     10.0,
+						},
+					},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"id":42,"started_at":"2024-02-05T03:00:00Z","finished_at":"2024-02-05T03:02:15Z","wallets":3,"mismatched":1,"total_balance":310,"total_initial_balance":300,"conserved":false,` +
+				`"mismatches":[{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":140,"initial_balance":100,"incoming":30,"outgoing":0,"expected":130,"difference":10}]}`,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(r *mock_usecase.MockReconciliation) {
+				r.EXPECT().GetLastReconciliation(context.Background()).Return(nil, entity.ErrReconciliationNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			mockBehavior: func(r *mock_usecase.MockReconciliation) {
+				r.EXPECT().GetLastReconciliation(context.Background()).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reconciliation := mock_usecase.NewMockReconciliation(c)
+			test.mockBehavior(reconciliation)
+			handler := reconciliationRoutes{
+				r: reconciliation,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/reconciliation", handler.getLastReconciliation)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/reconciliation", nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
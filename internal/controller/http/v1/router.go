@@ -17,19 +17,66 @@ import (
 // @version     1.0
 // @host        localhost:8000
 // @BasePath    /api/v1
-func NewRouter(handler *gin.Engine, l logger.Interface, w usecase.Wallet) {
+// @securityDefinitions.apikey AdminToken
+// @in          header
+// @name        Authorization
+func NewRouter(handler *gin.Engine, l logger.Interface, w usecase.Wallet, p usecase.Promo, v usecase.Voucher, pm usecase.Payment, i usecase.Interest, bl usecase.Balance, pc usecase.Pocket, st usecase.Statement, b usecase.Bulk, e usecase.Event, wh usecase.Webhook, rc usecase.Reconciliation, ch usecase.Chain, rp usecase.Receipt, au usecase.Audit, adminToken string) {
 	// Options
 	handler.Use(gin.Logger())
 	handler.Use(gin.Recovery())
 
-	// For generation new Swagger documentation: 
-	// swag init -dir internal/controller/http/v1/ -generalInfo router.go --parseDependency internal/entity/ 
+	// For generation new Swagger documentation:
+	// swag init -dir internal/controller/http/v1/ -generalInfo router.go --parseDependency internal/entity/
 	swaggerHandler := ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, "DISABLE_SWAGGER_HTTP_HANDLER")
 	handler.GET("/swagger/*any", swaggerHandler)
 
-	// Routers
+	// Routers, admin routes are available only with the admin token.
+	// Requests changing the state are recorded in the audit log if it is kept.
+	// Use cases of the features disabled with the wallet storage are nil and have no routes
 	h := handler.Group("/api/v1")
+	if au != nil {
+		h.Use(auditTrail(au, h.BasePath()+"/admin", l))
+	}
+	a := h.Group("/admin", adminAuth(adminToken))
 	{
-		newWalletRoutes(h, w, l)
+		newWalletRoutes(h, a, w, rp, l)
+		newStatementRoutes(h, st, l)
+		newBulkRoutes(a, b, l)
+		if p != nil {
+			newPromoRoutes(a, p, l)
+		}
+		if v != nil {
+			newVoucherRoutes(h, a, v, l)
+		}
+		if pm != nil {
+			newPaymentRoutes(h, pm, l)
+		}
+		if i != nil {
+			newInterestRoutes(h, a, i, l)
+		}
+		if bl != nil {
+			newBalanceRoutes(h, a, bl, l)
+		}
+		if pc != nil {
+			newPocketRoutes(h, pc, l)
+		}
+		if e != nil {
+			newEventRoutes(h, a, e, l)
+		}
+		if wh != nil {
+			newWebhookRoutes(a, wh, l)
+		}
+		if rc != nil {
+			newReconciliationRoutes(a, rc, l)
+		}
+		if ch != nil {
+			newChainRoutes(a, ch, l)
+		}
+		if rp != nil {
+			newReceiptRoutes(h, rp, l)
+		}
+		if au != nil {
+			newAuditRoutes(a, au, l)
+		}
 	}
 }
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_NewRouterWithoutPostgresFeatures(t *testing.T) {
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	// Features disabled with the wallet storage and the audit log are nil
+	handler := gin.New()
+	NewRouter(handler, logger.New(""), mock_usecase.NewMockWallet(c), nil, nil, nil, nil, nil, nil,
+		mock_usecase.NewMockStatement(c), mock_usecase.NewMockBulk(c), nil, nil, nil, nil, nil, nil, "token")
+
+	routes := make(map[string]bool)
+	for _, route := range handler.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	// Assert
+	assert.Equal(t, routes["POST /api/v1/wallet/:walletId/send"], true)
+	assert.Equal(t, routes["GET /api/v1/wallet/:walletId/statement"], true)
+	assert.Equal(t, routes["POST /api/v1/admin/wallet/:walletId/promo"], false)
+	assert.Equal(t, routes["GET /api/v1/admin/audit"], false)
+}
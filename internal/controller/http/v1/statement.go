@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/statement"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// Downloadable statement formats
+var statementRenderers = map[string]struct {
+	contentType string
+	extension   string
+	write       func(w io.Writer, s *entity.Statement) error
+}{
+	entity.StatementFormatCSV:  {"text/csv; charset=utf-8", "csv", statement.WriteCSV},
+	entity.StatementFormatPDF:  {"application/pdf", "pdf", statement.WritePDF},
+	entity.StatementFormatCamt: {"application/xml; charset=utf-8", "xml", statement.WriteCamt053},
+	entity.StatementFormatOFX:  {"application/x-ofx", "ofx", statement.WriteOFX},
+}
+
+type statementRoutes struct {
+	s usecase.Statement
+	l logger.Interface
+}
+
+func newStatementRoutes(handler *gin.RouterGroup, s usecase.Statement, l logger.Interface) {
+	r := &statementRoutes{s, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.GET("/:walletId/statement", r.getStatement)
+	}
+}
+
+// @Summary     Получение выписки по кошельку
+// @Description Возвращает входящий остаток, операции с остатком после каждой из них и исходящий остаток за период.
+// @Description
+// @Description Период задается датами в UTC включительно, по умолчанию - текущий месяц.
+// @Description
+// @Description Форматы: json, csv, pdf, camt053 (ISO 20022 camt.053.001.02) и ofx (OFX 2.2) для импорта в учетные системы
+// @Tags  	    Wallet
+// @Produce     json,text/csv,application/pdf,application/xml,application/x-ofx
+// @Param walletId path string true "ID кошелька"
+// @Param from query string false "Первый день периода в формате YYYY-MM-DD"
+// @Param to query string false "Последний день периода в формате YYYY-MM-DD"
+// @Param format query string false "Формат выписки (json, csv, pdf, camt053, ofx)"
+// @Success     200 {object} entity.Statement "Выписка получена"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /wallet/{walletId}/statement [get]
+func (r *statementRoutes) getStatement(c *gin.Context) {
+	from, to, err := statementPeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getStatement")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	format := c.DefaultQuery("format", entity.StatementFormatJSON)
+	renderer, ok := statementRenderers[format]
+	if !ok && format != entity.StatementFormatJSON {
+		r.l.Error(entity.ErrWrongStatementFormat, "http - v1 - getStatement")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	s, err := r.s.GetStatement(c.Request.Context(), c.Param("walletId"), from, to)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - getStatement")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - getStatement")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	if format == entity.StatementFormatJSON {
+		c.JSON(http.StatusOK, s)
+
+		return
+	}
+
+	// The document is rendered before writing the response to report rendering errors properly
+	var buf bytes.Buffer
+	if err := renderer.write(&buf, s); err != nil {
+		r.l.Error(err, "http - v1 - getStatement")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%s-%s-%s.%s", s.WalletID, from.Format(time.DateOnly), to.AddDate(0, 0, -1).Format(time.DateOnly), renderer.extension)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, renderer.contentType, buf.Bytes())
+}
+
+// statementPeriod - parsing inclusive dates of the period into [from, to), the current month is used by default.
+func statementPeriod(fromQuery string, toQuery string) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	var err error
+	if fromQuery != "" {
+		from, err = time.Parse(time.DateOnly, fromQuery)
+		if err != nil {
+			return from, to, err
+		}
+	}
+	if toQuery != "" {
+		to, err = time.Parse(time.DateOnly, toQuery)
+		if err != nil {
+			return from, to, err
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return from, to, entity.ErrWrongPeriod
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_getStatement(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time)
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	moved := time.Date(2024, 2, 4, 17, 25, 35, 0, time.UTC)
+
+	statement := &entity.Statement{
+		WalletID:       "5b53700ed469fa6a09ea72bb78f36fd9",
+		From:           from,
+		To:             to,
+		Currency:       "XXX",
+		GeneratedAt:    to,
+		OpeningBalance: 100.0,
+		ClosingBalance: 70.0,
+		Lines: []entity.StatementLine{
+			{
+				Time:         moved,
+				Type:         entity.TransactionTypeTransfer,
+				Counterparty: "eb376add88bf8e70f80787266a0801d5",
+				Amount:       -30.0,
+				Balance:      70.0,
+			},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		id                   string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?from=2024-02-01&to=2024-02-29",
+			mockBehavior: func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time) {
+				r.EXPECT().GetStatement(context.Background(), id, from, to).Return(statement, nil)
+			},
+			expectedStatusCode:   200,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","from":"2024-02-01T00:00:00Z","to":"2024-03-01T00:00:00Z","currency":"XXX","generated_at":"2024-03-01T00:00:00Z","opening_balance":100,"closing_balance":70,"lines":[{"time":"2024-02-04T17:25:35Z","type":"transfer","counterparty":"eb376add88bf8e70f80787266a0801d5","amount":-30,"balance":70}]}`,
+		},
+		{
+			name:  "Ok - csv",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?from=2024-02-01&to=2024-02-29&format=csv",
+			mockBehavior: func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time) {
+				r.EXPECT().GetStatement(context.Background(), id, from, to).Return(statement, nil)
+			},
+			expectedStatusCode:  200,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedResponseBody: "time,type,counterparty,description,external_reference,amount,balance\n" +
+				"2024-02-01T00:00:00Z,opening_balance,,,,,100.00\n" +
+				"2024-02-04T17:25:35Z,transfer,eb376add88bf8e70f80787266a0801d5,,,-30.00,70.00\n" +
+				"2024-03-01T00:00:00Z,closing_balance,,,,,70.00\n",
+		},
+		{
+			name:  "Not found",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?from=2024-02-01&to=2024-02-29",
+			mockBehavior: func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time) {
+				r.EXPECT().GetStatement(context.Background(), id, from, to).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Wrong period",
+			id:                   "5b53700ed469fa6a09ea72bb78f36fd9",
+			query:                "?from=2024-02-29&to=2024-02-01",
+			mockBehavior:         func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Wrong date",
+			id:                   "5b53700ed469fa6a09ea72bb78f36fd9",
+			query:                "?from=01.02.2024",
+			mockBehavior:         func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Wrong format",
+			id:                   "5b53700ed469fa6a09ea72bb78f36fd9",
+			query:                "?from=2024-02-01&to=2024-02-29&format=xlsx",
+			mockBehavior:         func(r *mock_usecase.MockStatement, id string, from time.Time, to time.Time) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockStatement(c)
+			test.mockBehavior(repo, test.id, from, to)
+			handler := statementRoutes{
+				s: repo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/:walletId/statement", handler.getStatement)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/statement%s", test.id, test.query), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+			if test.expectedContentType != "" {
+				assert.Equal(t, w.Header().Get("Content-Type"), test.expectedContentType)
+			}
+		})
+	}
+}
+
+func Test_getStatementPDF(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockStatement(c)
+	repo.EXPECT().GetStatement(gomock.Any(), "5b53700ed469fa6a09ea72bb78f36fd9", gomock.Any(), gomock.Any()).Return(&entity.Statement{
+		WalletID:       "5b53700ed469fa6a09ea72bb78f36fd9",
+		From:           time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: 100.0,
+		ClosingBalance: 100.0,
+	}, nil)
+	handler := statementRoutes{
+		s: repo,
+		l: logger.New(""),
+	}
+	r := gin.New()
+	r.GET("/:walletId/statement", handler.getStatement)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/5b53700ed469fa6a09ea72bb78f36fd9/statement?from=2024-02-01&to=2024-02-29&format=pdf", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, 200)
+	assert.Equal(t, w.Header().Get("Content-Type"), "application/pdf")
+	assert.Equal(t, w.Header().Get("Content-Disposition"), `attachment; filename="statement-5b53700ed469fa6a09ea72bb78f36fd9-2024-02-01-2024-02-29.pdf"`)
+	assert.Equal(t, bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")), true)
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type voucherRoutes struct {
+	v usecase.Voucher
+	l logger.Interface
+}
+
+func newVoucherRoutes(handler *gin.RouterGroup, admin *gin.RouterGroup, v usecase.Voucher, l logger.Interface) {
+	r := &voucherRoutes{v, l}
+
+	h := handler.Group("/wallet")
+	{
+		h.POST("/:walletId/redeem", r.redeemVoucher)
+	}
+
+	a := admin.Group("/vouchers")
+	{
+		a.POST("", r.issueVouchers)
+	}
+}
+
+// @Summary     Выпуск партии ваучеров
+// @Description Генерирует коды ваучеров фиксированного номинала. Полная стоимость партии списывается с исходного кошелька.
+// @Description
+// @Description Коды возвращаются только в ответе на этот запрос, в базе хранятся их хэши
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param input body entity.VoucherBatchRequest true "Запрос выпуска ваучеров"
+// @Success     200 {object} entity.VoucherBatch "Ваучеры выпущены"
+// @Failure     400 "Ошибка в запросе или недостаточно средств"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Исходный кошелек не найден"
+// @Router      /admin/vouchers [post]
+func (r *voucherRoutes) issueVouchers(c *gin.Context) {
+	var request entity.VoucherBatchRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - issueVouchers")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	batch, err := r.v.IssueVouchers(c.Request.Context(), request)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - issueVouchers")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - issueVouchers")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// @Summary     Активация ваучера
+// @Description Зачисляет номинал ваучера на кошелек. Количество неудачных попыток активации для кошелька ограничено.
+// @Tags  	    Wallet
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.RedeemRequest true "Запрос активации ваучера"
+// @Success     200 {object} entity.Transaction "Ваучер активирован"
+// @Failure     400 "Неверный, просроченный или уже использованный код, кошелек заморожен или закрыт"
+// @Failure     404 "Указанный кошелек не найден"
+// @Failure     429 "Слишком много неудачных попыток"
+// @Router      /wallet/{walletId}/redeem [post]
+func (r *voucherRoutes) redeemVoucher(c *gin.Context) {
+	var request entity.RedeemRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - redeemVoucher")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	transaction, err := r.v.RedeemVoucher(c.Request.Context(), c.Param("walletId"), request.Code)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - redeemVoucher")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrTooManyAttempts) {
+		r.l.Error(err, "http - v1 - redeemVoucher")
+		c.Status(http.StatusTooManyRequests)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - redeemVoucher")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
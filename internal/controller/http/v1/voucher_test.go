@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_issueVouchers(t *testing.T) {
+	expiresAt, _ := time.Parse(time.RFC3339, "2024-12-31T23:59:59Z")
+
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockVoucher, request entity.VoucherBatchRequest)
+
+	tests := []struct {
+		name                 string
+		token                string
+		request              entity.VoucherBatchRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			token: "Bearer secret",
+			request: entity.VoucherBatchRequest{
+				SourceWalletID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				Amount:         10.0,
+				Count:          2,
+				MaxRedemptions: 1,
+				ExpiresAt:      expiresAt,
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, request entity.VoucherBatchRequest) {
+				r.EXPECT().IssueVouchers(context.Background(), request).Return(&entity.VoucherBatch{
+					ID:             "9f86d081884c7d659a2feaa0c55ad015",
+					SourceWalletID: request.SourceWalletID,
+					Amount:         request.Amount,
+					MaxRedemptions: request.MaxRedemptions,
+					ExpiresAt:      request.ExpiresAt,
+					Codes:          []string{"K7QF-9M2X-PLT4-ZC8N", "A2B3-C4D5-E6F7-G8H9"},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"9f86d081884c7d659a2feaa0c55ad015","source_wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","amount":10,"max_redemptions":1,"expires_at":"2024-12-31T23:59:59Z","codes":["K7QF-9M2X-PLT4-ZC8N","A2B3-C4D5-E6F7-G8H9"]}`,
+		},
+		{
+			name:  "Unauthorized",
+			token: "",
+			request: entity.VoucherBatchRequest{
+				SourceWalletID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				Amount:         10.0,
+				Count:          2,
+				MaxRedemptions: 1,
+				ExpiresAt:      expiresAt,
+			},
+			mockBehavior:         func(r *mock_usecase.MockVoucher, request entity.VoucherBatchRequest) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Source wallet not found",
+			token: "Bearer secret",
+			request: entity.VoucherBatchRequest{
+				SourceWalletID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				Amount:         10.0,
+				Count:          2,
+				MaxRedemptions: 1,
+				ExpiresAt:      expiresAt,
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, request entity.VoucherBatchRequest) {
+				r.EXPECT().IssueVouchers(context.Background(), request).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name:  "Wrong input - wrong batch",
+			token: "Bearer secret",
+			request: entity.VoucherBatchRequest{
+				SourceWalletID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				Amount:         10.0,
+				Count:          0,
+				MaxRedemptions: 1,
+				ExpiresAt:      expiresAt,
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, request entity.VoucherBatchRequest) {
+				r.EXPECT().IssueVouchers(context.Background(), request).Return(nil, entity.ErrWrongVoucherBatch)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			voucher := mock_usecase.NewMockVoucher(c)
+			test.mockBehavior(voucher, test.request)
+			handler := voucherRoutes{
+				v: voucher,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/vouchers", adminAuth("secret"), handler.issueVouchers)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("POST", "/vouchers", bytes.NewBuffer(reqBody))
+			req.Header.Set("Authorization", test.token)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_redeemVoucher(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              entity.RedeemRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "eb376add88bf8e70f80787266a0801d5",
+			request: entity.RedeemRequest{
+				Code: "K7QF-9M2X-PLT4-ZC8N",
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest) {
+				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
+
+				r.EXPECT().RedeemVoucher(context.Background(), id, request.Code).Return(&entity.Transaction{
+					Time:              t,
+					From:              entity.SystemVoucherWalletID,
+					To:                id,
+					Amount:            10.0,
+					ExternalReference: "9f86d081884c7d659a2feaa0c55ad015",
+					Type:              entity.TransactionTypeVoucherRedeem,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"time":"2024-02-04T17:25:35.448Z","from":"system-vouchers","to":"eb376add88bf8e70f80787266a0801d5","amount":10,"external_reference":"9f86d081884c7d659a2feaa0c55ad015","type":"voucher_redeem"}`,
+		},
+		{
+			name: "Wallet not found",
+			id:   "eb376add88bf8e70f80787266a0801d5",
+			request: entity.RedeemRequest{
+				Code: "K7QF-9M2X-PLT4-ZC8N",
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest) {
+				r.EXPECT().RedeemVoucher(context.Background(), id, request.Code).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Wrong code",
+			id:   "eb376add88bf8e70f80787266a0801d5",
+			request: entity.RedeemRequest{
+				Code: "AAAA-AAAA-AAAA-AAAA",
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest) {
+				r.EXPECT().RedeemVoucher(context.Background(), id, request.Code).Return(nil, entity.ErrVoucherNotFound)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Voucher is already used",
+			id:   "eb376add88bf8e70f80787266a0801d5",
+			request: entity.RedeemRequest{
+				Code: "K7QF-9M2X-PLT4-ZC8N",
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest) {
+				r.EXPECT().RedeemVoucher(context.Background(), id, request.Code).Return(nil, entity.ErrVoucherUsed)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Too many attempts",
+			id:   "eb376add88bf8e70f80787266a0801d5",
+			request: entity.RedeemRequest{
+				Code: "K7QF-9M2X-PLT4-ZC8N",
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest) {
+				r.EXPECT().RedeemVoucher(context.Background(), id, request.Code).Return(nil, entity.ErrTooManyAttempts)
+			},
+			expectedStatusCode:   429,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			id:   "eb376add88bf8e70f80787266a0801d5",
+			request: entity.RedeemRequest{
+				Code: "K7QF-9M2X-PLT4-ZC8N",
+			},
+			mockBehavior: func(r *mock_usecase.MockVoucher, id string, request entity.RedeemRequest) {
+				r.EXPECT().RedeemVoucher(context.Background(), id, request.Code).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			voucher := mock_usecase.NewMockVoucher(c)
+			test.mockBehavior(voucher, test.id, test.request)
+			handler := voucherRoutes{
+				v: voucher,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/:walletId/redeem", handler.redeemVoucher)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/redeem", test.id), bytes.NewBuffer(reqBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -12,12 +16,13 @@ import (
 )
 
 type walletRoutes struct {
-	w usecase.Wallet
-	l logger.Interface
+	w  usecase.Wallet
+	rp usecase.Receipt
+	l  logger.Interface
 }
 
-func newWalletRoutes(handler *gin.RouterGroup, w usecase.Wallet, l logger.Interface) {
-	r := &walletRoutes{w, l}
+func newWalletRoutes(handler *gin.RouterGroup, admin *gin.RouterGroup, w usecase.Wallet, rp usecase.Receipt, l logger.Interface) {
+	r := &walletRoutes{w, rp, l}
 
 	h := handler.Group("/wallet")
 	{
@@ -26,18 +31,38 @@ func newWalletRoutes(handler *gin.RouterGroup, w usecase.Wallet, l logger.Interf
 		h.GET("/:walletId/history", r.getWalletHistoryById)
 		h.GET("/:walletId", r.getWalletById)
 	}
+
+	a := admin.Group("/wallet")
+	{
+		a.PUT("/:walletId/credit-limit", r.setCreditLimit)
+		a.PUT("/:walletId/status", r.setWalletStatus)
+	}
 }
 
 // @Summary     Создание кошелька
 // @Description Создает новый кошелек с уникальным ID. Идентификатор генерируется сервером.
 // @Description
 // @Description Созданный кошелек должен иметь сумму 100.0 у.е. на балансе
+// @Description
+// @Description Тело запроса необязательно, при его отсутствии создается обычный кошелек. Сберегательный кошелек получает текущую процентную ставку
 // @Tags  	    Wallet
+// @Param input body entity.CreateWalletRequest false "Запрос создания кошелька"
 // @Success     200 {object} entity.Wallet "Кошелек создан"
 // @Failure     400 "Ошибка в запросе"
 // @Router      /wallet [post]
 func (r *walletRoutes) createNewWallet(c *gin.Context) {
-	wallet, err := r.w.CreateNewWalletWithDefaultBalance(c.Request.Context())
+	var request entity.CreateWalletRequest
+
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&request); err != nil {
+			r.l.Error(err, "http - v1 - createNewWallet")
+			c.Status(http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	wallet, err := r.w.CreateNewWalletWithDefaultBalance(c.Request.Context(), request.Type)
 	if err != nil {
 		r.l.Error(err, "http - v1 - createNewWallet")
 		c.AbortWithStatus(http.StatusBadRequest)
@@ -49,12 +74,15 @@ func (r *walletRoutes) createNewWallet(c *gin.Context) {
 }
 
 // @Summary     Перевод средств с одного кошелька на другой
+// @Description Возвращает подписанную квитанцию о переводе, если сервер выдает квитанции
 // @Tags  	    Wallet
 // @Param walletId path string true "ID кошелька"
 // @Param input body entity.TransactionRequest true "Запрос перевода средств"
-// @Success     200 "Перевод успешно проведен"
+// @Param If-Match header string false "ETag исходящего кошелька, перевод проводится только если кошелек не изменился"
+// @Success     200 {object} entity.Receipt "Перевод успешно проведен"
 // @Failure     404 "Исходящий кошелек не найден"
 // @Failure     400 "Ошибка в пользовательском запросе или ошибка перевода"
+// @Failure     412 "Кошелек изменился"
 // @Router      /wallet/{walletId}/send [post]
 func (r *walletRoutes) sendFunds(c *gin.Context) {
 	var TransactionRequest entity.TransactionRequest
@@ -66,13 +94,19 @@ func (r *walletRoutes) sendFunds(c *gin.Context) {
 		return
 	}
 
-	err := r.w.SendFunds(c.Request.Context(), c.Param("walletId"), TransactionRequest.To, TransactionRequest.Amount)
+	transaction, err := r.w.SendFunds(expectedVersions(c), c.Param("walletId"), TransactionRequest)
 	if errors.Is(err, entity.ErrWalletNotFound) {
 		r.l.Error(err, "http - v1 - sendFunds")
 		c.Status(http.StatusNotFound)
 
 		return
 	}
+	if errors.Is(err, entity.ErrVersionMismatch) {
+		r.l.Error(err, "http - v1 - sendFunds")
+		c.Status(http.StatusPreconditionFailed)
+
+		return
+	}
 	if err != nil {
 		r.l.Error(err, "http - v1 - sendFunds")
 		c.Status(http.StatusBadRequest)
@@ -80,32 +114,70 @@ func (r *walletRoutes) sendFunds(c *gin.Context) {
 		return
 	}
 
-	c.Status(http.StatusOK)
+	// The transfer is done anyway, the receipt is returned only if the server issues them
+	if r.rp == nil {
+		c.Status(http.StatusOK)
+
+		return
+	}
+	receipt, err := r.rp.IssueReceipt(transaction)
+	if err != nil {
+		// Receipts are disabled without the signing key, other failures are logged
+		if !errors.Is(err, entity.ErrReceiptSigningKeyMissing) && !errors.Is(err, entity.ErrReceiptUnavailable) {
+			r.l.Error(err, "http - v1 - sendFunds - r.rp.IssueReceipt")
+		}
+		c.Status(http.StatusOK)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
 }
 
 // @Summary     Получение историй входящих и исходящих транзакций
 // @Description Возвращает историю транзакций по указанному кошельку.
+// @Description
+// @Description При указании external_reference возвращаются только переводы с данным внешним идентификатором
+// @Description
+// @Description При include_pockets=true в историю включаются операции копилок кошелька
 // @Tags  	    Wallet
 // @Param walletId path string true "ID кошелька"
+// @Param external_reference query string false "Внешний идентификатор перевода"
+// @Param include_pockets query bool false "Включить операции копилок"
 // @Success     200 {object} []entity.Transaction "История транзакций получена"
 // @Failure     404 "Указанный кошелек не найден"
 // @Router      /wallet/{walletId}/history [get]
 func (r *walletRoutes) getWalletHistoryById(c *gin.Context) {
-	transactions, err := r.w.GetWalletHistoryById(c.Request.Context(), c.Param("walletId"))
+	filter := entity.HistoryFilter{
+		ExternalReference: c.Query("external_reference"),
+		IncludePockets:    c.Query("include_pockets") == "true",
+	}
+
+	transactions, err := r.w.GetWalletHistoryById(c.Request.Context(), c.Param("walletId"), filter)
 	if err != nil {
 		r.l.Error(err, "http - v1 - getWalletHistoryById")
 		c.AbortWithStatus(http.StatusNotFound)
 
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, transactions)
 }
 
 // @Summary     Получение текущего состояния кошелька
+// @Description Возвращает баланс кошелька с разбивкой на основную и промо-часть, а также предстоящие сгорания промо-баланса.
+// @Description
+// @Description Для кошельков с кредитным лимитом возвращается доступный остаток лимита
+// @Description
+// @Description Для кошельков с копилками возвращается список копилок и общий баланс
+// @Description
+// @Description Версия кошелька возвращается в заголовке ETag, она меняется при каждом изменении баланса, кредитного лимита или статуса
 // @Tags  	    Wallet
 // @Param walletId path string true "ID кошелька"
+// @Param If-None-Match header string false "ETag кошелька, полученный ранее"
 // @Success     200 {object} entity.Wallet "OK"
+// @Header      200 {string} ETag "Версия кошелька"
+// @Success     304 "Кошелек не изменился"
 // @Failure     404 "Указанный кошелек не найден"
 // @Router      /wallet/{walletId} [get]
 func (r *walletRoutes) getWalletById(c *gin.Context) {
@@ -117,5 +189,168 @@ func (r *walletRoutes) getWalletById(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", walletETag(wallet.Version))
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		versions, anyVersion := entityVersions(header, true)
+		if anyVersion || containsVersion(versions, wallet.Version) {
+			c.Status(http.StatusNotModified)
+
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// @Summary     Изменение кредитного лимита
+// @Description Устанавливает кредитный лимит кошелька, баланс может опускаться до -credit_limit. Лимит не может быть меньше текущей задолженности.
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.CreditLimitRequest true "Запрос изменения лимита"
+// @Param If-Match header string false "ETag кошелька, лимит изменяется только если кошелек не изменился"
+// @Success     200 {object} entity.Wallet "Лимит изменен"
+// @Header      200 {string} ETag "Новая версия кошелька"
+// @Failure     400 "Ошибка в запросе или лимит меньше задолженности"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Указанный кошелек не найден"
+// @Failure     412 "Кошелек изменился"
+// @Router      /admin/wallet/{walletId}/credit-limit [put]
+func (r *walletRoutes) setCreditLimit(c *gin.Context) {
+	var request entity.CreditLimitRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - setCreditLimit")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	wallet, err := r.w.SetCreditLimit(expectedVersions(c), c.Param("walletId"), request.CreditLimit)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - setCreditLimit")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrVersionMismatch) {
+		r.l.Error(err, "http - v1 - setCreditLimit")
+		c.Status(http.StatusPreconditionFailed)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - setCreditLimit")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.Header("ETag", walletETag(wallet.Version))
 	c.JSON(http.StatusOK, wallet)
-}
\ No newline at end of file
+}
+
+// @Summary     Изменение статуса кошелька
+// @Description Замораживает, размораживает или закрывает кошелек вместе с его копилками. Замороженные и закрытые кошельки не участвуют в переводах.
+// @Description
+// @Description При закрытии средства копилок возвращаются в кошелек, итоговый баланс должен быть нулевым. Закрытый кошелек нельзя открыть снова
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param walletId path string true "ID кошелька"
+// @Param input body entity.WalletStatusRequest true "Запрос изменения статуса"
+// @Param If-Match header string false "ETag кошелька, статус изменяется только если кошелек не изменился"
+// @Success     200 {object} entity.Wallet "Статус изменен"
+// @Header      200 {string} ETag "Новая версия кошелька"
+// @Failure     400 "Ошибка в запросе, кошелек закрыт или его баланс не нулевой"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Указанный кошелек не найден"
+// @Failure     412 "Кошелек изменился"
+// @Router      /admin/wallet/{walletId}/status [put]
+func (r *walletRoutes) setWalletStatus(c *gin.Context) {
+	var request entity.WalletStatusRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - setWalletStatus")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	wallet, err := r.w.SetWalletStatus(expectedVersions(c), c.Param("walletId"), request.Status)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - setWalletStatus")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrVersionMismatch) {
+		r.l.Error(err, "http - v1 - setWalletStatus")
+		c.Status(http.StatusPreconditionFailed)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - setWalletStatus")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	c.Header("ETag", walletETag(wallet.Version))
+	c.JSON(http.StatusOK, wallet)
+}
+
+// walletETag - entity tag of the wallet version.
+func walletETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// expectedVersions - context of the request, the wallet is changed only if it has one of the versions from If-Match.
+func expectedVersions(c *gin.Context) context.Context {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return c.Request.Context()
+	}
+
+	versions, anyVersion := entityVersions(header, false)
+	if anyVersion {
+		return c.Request.Context()
+	}
+	return usecase.WithExpectedVersions(c.Request.Context(), versions...)
+}
+
+// entityVersions - wallet versions of the entity tags in the If-Match or If-None-Match header, unknown tags are skipped.
+// Weak tags are compared only by If-None-Match. Reports if the header is "*", which matches any version.
+func entityVersions(header string, weak bool) ([]int64, bool) {
+	versions := make([]int64, 0)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, false
+}
+
+// containsVersion - checking that the version is one of the versions.
+func containsVersion(versions []int64, version int64) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	"github.com/magiconair/properties/assert"
 
 	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/memory"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
 	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
 	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
 )
@@ -25,6 +28,7 @@ func Test_createNewWallet(t *testing.T) {
 
 	tests := []struct {
 		name                 string
+		requestBody          string
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
@@ -32,20 +36,43 @@ func Test_createNewWallet(t *testing.T) {
 		{
 			name: "Ok",
 			mockBehavior: func(r *mock_usecase.MockWallet, name string) {
-				r.EXPECT().CreateNewWalletWithDefaultBalance(context.Background()).Return(&entity.Wallet{
-					ID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				r.EXPECT().CreateNewWalletWithDefaultBalance(context.Background(), "").Return(&entity.Wallet{
+					ID:      "5b53700ed469fa6a09ea72bb78f36fd9",
 					Balance: 100.0,
 				}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode:   200,
 			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":100}`,
 		},
+		{
+			name:        "Ok - savings wallet",
+			requestBody: `{"type":"savings"}`,
+			mockBehavior: func(r *mock_usecase.MockWallet, name string) {
+				r.EXPECT().CreateNewWalletWithDefaultBalance(context.Background(), entity.WalletTypeSavings).Return(&entity.Wallet{
+					ID:         "5b53700ed469fa6a09ea72bb78f36fd9",
+					Balance:    100.0,
+					Type:       entity.WalletTypeSavings,
+					AnnualRate: 0.05,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":100,"type":"savings","annual_rate":0.05}`,
+		},
+		{
+			name:        "Wrong input - unknown wallet type",
+			requestBody: `{"type":"premium"}`,
+			mockBehavior: func(r *mock_usecase.MockWallet, name string) {
+				r.EXPECT().CreateNewWalletWithDefaultBalance(context.Background(), "premium").Return(nil, entity.ErrWrongWalletType)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
 		{
 			name: "Something went wrong",
 			mockBehavior: func(r *mock_usecase.MockWallet, name string) {
-				r.EXPECT().CreateNewWalletWithDefaultBalance(context.Background()).Return(nil, errors.New("something went wrong"))
+				r.EXPECT().CreateNewWalletWithDefaultBalance(context.Background(), "").Return(nil, errors.New("something went wrong"))
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 	}
@@ -67,7 +94,7 @@ func Test_createNewWallet(t *testing.T) {
 			r.POST("/", handler.createNewWallet)
 			// Create Request
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("POST", "/", nil)
+			req := httptest.NewRequest("POST", "/", strings.NewReader(test.requestBody))
 			// Make Request
 			r.ServeHTTP(w, req)
 
@@ -80,7 +107,7 @@ func Test_createNewWallet(t *testing.T) {
 
 func Test_sendFunds(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest)
+	type mockBehavior func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest)
 
 	tests := []struct {
 		name                 string
@@ -92,114 +119,157 @@ func Test_sendFunds(t *testing.T) {
 	}{
 		{
 			name: "Ok",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
-				To: "eb376add88bf8e70f80787266a0801d5",
+				To:     "eb376add88bf8e70f80787266a0801d5",
 				Amount: 100.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(nil)
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				transaction := &entity.Transaction{ID: 1024, From: id, To: transactionRequest.To, Amount: transactionRequest.Amount}
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(transaction, nil)
+				rp.EXPECT().IssueReceipt(transaction).Return(&entity.Receipt{
+					TransactionID: 1024,
+					From:          id,
+					To:            transactionRequest.To,
+					Amount:        transactionRequest.Amount,
+					Time:          time.Date(2024, 2, 4, 17, 25, 35, 448000000, time.UTC),
+					PublicKey:     "cHVibGljLWtleQ==",
+					Signature:     "c2lnbmF0dXJl",
+				}, nil)
 			},
 			expectedStatusCode: 200,
-			expectedResponseBody: "",
+			expectedResponseBody: `{"transaction_id":1024,"from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":100,` +
+				`"time":"2024-02-04T17:25:35.448Z","public_key":"cHVibGljLWtleQ==","signature":"c2lnbmF0dXJl"}`,
 		},
 		{
 			name: "Not found",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
-				To: "eb376add88bf8e70f80787266a0801d5",
+				To:     "eb376add88bf8e70f80787266a0801d5",
 				Amount: 100.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(entity.ErrWalletNotFound)
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, entity.ErrWalletNotFound)
 			},
-			expectedStatusCode: 404,
+			expectedStatusCode:   404,
 			expectedResponseBody: "",
 		},
 		{
 			name: "Wrong input - without reciever id",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
 				Amount: 100.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(errors.New("something went wrong"))
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, errors.New("something went wrong"))
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 		{
 			name: "Wrong input - without amount",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
 				To: "eb376add88bf8e70f80787266a0801d5",
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(errors.New("something went wrong"))
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, errors.New("something went wrong"))
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 		{
 			name: "Wrong input - amount is 0",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
-				To: "eb376add88bf8e70f80787266a0801d5",
+				To:     "eb376add88bf8e70f80787266a0801d5",
 				Amount: 0.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(entity.ErrWrongAmount)
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, entity.ErrWrongAmount)
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 		{
 			name: "Wrong input - amount less 0",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
-				To: "eb376add88bf8e70f80787266a0801d5",
+				To:     "eb376add88bf8e70f80787266a0801d5",
 				Amount: -10.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(entity.ErrWrongAmount)
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, entity.ErrWrongAmount)
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 		{
-			name: "Wrong input - empty request body",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			name:               "Wrong input - empty request body",
+			id:                 "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(errors.New("something went wrong"))
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Ok - with description and external reference",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			transactionRequest: entity.TransactionRequest{
+				To:                "eb376add88bf8e70f80787266a0801d5",
+				Amount:            100.0,
+				Description:       "Invoice payment",
+				ExternalReference: "INV-2024-0042",
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				transaction := &entity.Transaction{ID: 1025}
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(transaction, nil)
+				// Without the signing key the transfer is done without a receipt
+				rp.EXPECT().IssueReceipt(transaction).Return(nil, entity.ErrReceiptSigningKeyMissing)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Wrong input - description is too long",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			transactionRequest: entity.TransactionRequest{
+				To:          "eb376add88bf8e70f80787266a0801d5",
+				Amount:      100.0,
+				Description: strings.Repeat("a", entity.MaxDescriptionLength+1),
 			},
-			expectedStatusCode: 400,
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, entity.ErrDescriptionTooLong)
+			},
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 		{
 			name: "Sender is reciever",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
-				To: "5b53700ed469fa6a09ea72bb78f36fd9",
+				To:     "5b53700ed469fa6a09ea72bb78f36fd9",
 				Amount: 100.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(entity.ErrSenderIsReceiver)
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, entity.ErrSenderIsReceiver)
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 		{
 			name: "Something went wrong",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			transactionRequest: entity.TransactionRequest{
-				To: "eb376add88bf8e70f80787266a0801d5",
+				To:     "eb376add88bf8e70f80787266a0801d5",
 				Amount: 100.0,
 			},
-			mockBehavior: func(r *mock_usecase.MockWallet, id string, transactionRequest entity.TransactionRequest) {
-				r.EXPECT().SendFunds(context.Background(), id, transactionRequest.To, transactionRequest.Amount).Return(errors.New("something went wrong"))
+			mockBehavior: func(r *mock_usecase.MockWallet, rp *mock_usecase.MockReceipt, id string, transactionRequest entity.TransactionRequest) {
+				r.EXPECT().SendFunds(context.Background(), id, transactionRequest).Return(nil, errors.New("something went wrong"))
 			},
-			expectedStatusCode: 400,
+			expectedStatusCode:   400,
 			expectedResponseBody: "",
 		},
 	}
@@ -211,10 +281,12 @@ func Test_sendFunds(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_usecase.NewMockWallet(c)
-			test.mockBehavior(repo, test.id, test.transactionRequest)
+			receipt := mock_usecase.NewMockReceipt(c)
+			test.mockBehavior(repo, receipt, test.id, test.transactionRequest)
 			handler := walletRoutes{
-				w: repo,
-				l: logger.New(""),
+				w:  repo,
+				rp: receipt,
+				l:  logger.New(""),
 			}
 			// Init Endpoint
 			r := gin.New()
@@ -223,7 +295,7 @@ func Test_sendFunds(t *testing.T) {
 			w := httptest.NewRecorder()
 			reqBody, _ := json.Marshal(test.transactionRequest)
 			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/send", test.id), bytes.NewBuffer(reqBody))
-			
+
 			// Make Request
 			r.ServeHTTP(w, req)
 
@@ -234,93 +306,202 @@ func Test_sendFunds(t *testing.T) {
 	}
 }
 
+// errorLogger - logger counting the logged errors.
+type errorLogger struct {
+	logger.Interface
+	errors int
+}
+
+func (l *errorLogger) Error(message interface{}, args ...interface{}) {
+	l.errors++
+}
+
+func Test_sendFundsReceiptFailure(t *testing.T) {
+	tests := []struct {
+		name           string
+		receiptErr     error
+		expectedErrors int
+	}{
+		{
+			name:           "Receipts are disabled",
+			receiptErr:     fmt.Errorf("ReceiptUseCase - IssueReceipt: %w", entity.ErrReceiptSigningKeyMissing),
+			expectedErrors: 0,
+		},
+		{
+			name:           "Receipt is unavailable",
+			receiptErr:     fmt.Errorf("ReceiptUseCase - IssueReceipt: %w", entity.ErrReceiptUnavailable),
+			expectedErrors: 0,
+		},
+		{
+			name:           "Receipt failed",
+			receiptErr:     errors.New("signing failed"),
+			expectedErrors: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			request := entity.TransactionRequest{To: "eb376add88bf8e70f80787266a0801d5", Amount: 100.0}
+			transaction := &entity.Transaction{ID: 1025}
+			w := mock_usecase.NewMockWallet(c)
+			w.EXPECT().SendFunds(context.Background(), "5b53700ed469fa6a09ea72bb78f36fd9", request).Return(transaction, nil)
+			rp := mock_usecase.NewMockReceipt(c)
+			rp.EXPECT().IssueReceipt(transaction).Return(nil, test.receiptErr)
+			l := &errorLogger{Interface: logger.New("")}
+
+			r := gin.New()
+			r.POST("/:walletId/send", (&walletRoutes{w: w, rp: rp, l: l}).sendFunds)
+			rec := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(request)
+			r.ServeHTTP(rec, httptest.NewRequest("POST", "/5b53700ed469fa6a09ea72bb78f36fd9/send", bytes.NewBuffer(reqBody)))
+
+			// The transfer is done anyway
+			assert.Equal(t, rec.Code, 200)
+			assert.Equal(t, rec.Body.String(), "")
+			assert.Equal(t, l.errors, test.expectedErrors)
+		})
+	}
+}
+
 func Test_getWalletHistoryById(t *testing.T) {
 	// Init Test Table
-	type mockBehavior func(r *mock_usecase.MockWallet, id string)
+	type mockBehavior func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter)
 
 	tests := []struct {
 		name                 string
 		id                   string
+		query                string
+		filter               entity.HistoryFilter
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
 	}{
 		{
 			name: "Ok - history exists (sending and receiving)",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
-			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
 				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
 
-				r.EXPECT().GetWalletHistoryById(context.Background(), id).Return([]entity.Transaction{
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return([]entity.Transaction{
 					{
-						Time: t,
-						From: "5b53700ed469fa6a09ea72bb78f36fd9",
-						To: "eb376add88bf8e70f80787266a0801d5",
+						Time:   t,
+						From:   "5b53700ed469fa6a09ea72bb78f36fd9",
+						To:     "eb376add88bf8e70f80787266a0801d5",
 						Amount: 30.0,
 					},
 					{
-						Time: t,
-						From: "eb376add88bf8e70f80787266a0801d5",
-						To: "5b53700ed469fa6a09ea72bb78f36fd9",
+						Time:   t,
+						From:   "eb376add88bf8e70f80787266a0801d5",
+						To:     "5b53700ed469fa6a09ea72bb78f36fd9",
 						Amount: 30.0,
 					},
 				}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode:   200,
 			expectedResponseBody: `[{"time":"2024-02-04T17:25:35.448Z","from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30},{"time":"2024-02-04T17:25:35.448Z","from":"eb376add88bf8e70f80787266a0801d5","to":"5b53700ed469fa6a09ea72bb78f36fd9","amount":30}]`,
 		},
 		{
 			name: "Ok - history exists (only sending)",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
-			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
 				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
 
-				r.EXPECT().GetWalletHistoryById(context.Background(), id).Return([]entity.Transaction{
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return([]entity.Transaction{
 					{
-						Time: t,
-						From: "5b53700ed469fa6a09ea72bb78f36fd9",
-						To: "eb376add88bf8e70f80787266a0801d5",
+						Time:   t,
+						From:   "5b53700ed469fa6a09ea72bb78f36fd9",
+						To:     "eb376add88bf8e70f80787266a0801d5",
 						Amount: 30.0,
 					},
 				}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode:   200,
 			expectedResponseBody: `[{"time":"2024-02-04T17:25:35.448Z","from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30}]`,
 		},
 		{
 			name: "Ok - history exists (only recieving)",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
-			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
 				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
 
-				r.EXPECT().GetWalletHistoryById(context.Background(), id).Return([]entity.Transaction{
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return([]entity.Transaction{
 					{
-						Time: t,
-						From: "eb376add88bf8e70f80787266a0801d5",
-						To: "5b53700ed469fa6a09ea72bb78f36fd9",
+						Time:   t,
+						From:   "eb376add88bf8e70f80787266a0801d5",
+						To:     "5b53700ed469fa6a09ea72bb78f36fd9",
 						Amount: 30.0,
 					},
 				}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode:   200,
 			expectedResponseBody: `[{"time":"2024-02-04T17:25:35.448Z","from":"eb376add88bf8e70f80787266a0801d5","to":"5b53700ed469fa6a09ea72bb78f36fd9","amount":30}]`,
 		},
 		{
 			name: "Ok - history is empty",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
-			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
-				r.EXPECT().GetWalletHistoryById(context.Background(), id).Return([]entity.Transaction{}, nil)
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return([]entity.Transaction{}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode:   200,
 			expectedResponseBody: `[]`,
 		},
+		{
+			name:  "Ok - search by external reference",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?external_reference=INV-2024-0042",
+			filter: entity.HistoryFilter{
+				ExternalReference: "INV-2024-0042",
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
+				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
+
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return([]entity.Transaction{
+					{
+						Time:              t,
+						From:              "5b53700ed469fa6a09ea72bb78f36fd9",
+						To:                "eb376add88bf8e70f80787266a0801d5",
+						Amount:            30.0,
+						Description:       "Invoice payment",
+						ExternalReference: "INV-2024-0042",
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"time":"2024-02-04T17:25:35.448Z","from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30,"description":"Invoice payment","external_reference":"INV-2024-0042"}]`,
+		},
+		{
+			name:  "Ok - with pockets",
+			id:    "5b53700ed469fa6a09ea72bb78f36fd9",
+			query: "?include_pockets=true",
+			filter: entity.HistoryFilter{
+				IncludePockets: true,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
+				t, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35.448Z")
+
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return([]entity.Transaction{
+					{
+						Time:   t,
+						From:   "5b53700ed469fa6a09ea72bb78f36fd9",
+						To:     "eb376add88bf8e70f80787266a0801d5",
+						Amount: 30.0,
+						Type:   entity.TransactionTypePocketTransfer,
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"time":"2024-02-04T17:25:35.448Z","from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30,"type":"pocket_transfer"}]`,
+		},
 		{
 			name: "Not Found",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
-			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
-				r.EXPECT().GetWalletHistoryById(context.Background(), id).Return(nil, errors.New("something went wrong"))
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, filter entity.HistoryFilter) {
+				r.EXPECT().GetWalletHistoryById(context.Background(), id, filter).Return(nil, errors.New("something went wrong"))
 			},
-			expectedStatusCode: 404,
+			expectedStatusCode:   404,
 			expectedResponseBody: "",
 		},
 	}
@@ -332,7 +513,7 @@ func Test_getWalletHistoryById(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_usecase.NewMockWallet(c)
-			test.mockBehavior(repo, test.id)
+			test.mockBehavior(repo, test.id, test.filter)
 			handler := walletRoutes{
 				w: repo,
 				l: logger.New(""),
@@ -342,7 +523,7 @@ func Test_getWalletHistoryById(t *testing.T) {
 			r.GET("/:walletId/history", handler.getWalletHistoryById)
 			// Create Request
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/history", test.id), nil)
+			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/history%s", test.id, test.query), nil)
 			// Make Request
 			r.ServeHTTP(w, req)
 
@@ -366,23 +547,69 @@ func Test_getWalletById(t *testing.T) {
 	}{
 		{
 			name: "Ok",
-			id: "5b53700ed469fa6a09ea72bb78f36fd9",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
 			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
 				r.EXPECT().GetWalletById(context.Background(), id).Return(&entity.Wallet{
-					ID: id,
+					ID:      id,
 					Balance: 100.0,
 				}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode:   200,
 			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":100}`,
 		},
+		{
+			name: "Ok - with promo balance",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+				t, _ := time.Parse(time.RFC3339, "2024-03-05T17:25:35.448Z")
+
+				r.EXPECT().GetWalletById(context.Background(), id).Return(&entity.Wallet{
+					ID:      id,
+					Balance: 120.0,
+					Buckets: []entity.BalanceBucket{
+						{Name: entity.BucketMain, Amount: 100.0},
+						{Name: entity.BucketPromo, Amount: 20.0},
+					},
+					Expirations: []entity.PromoExpiration{
+						{Amount: 20.0, ExpiresAt: t},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":120,"buckets":[{"name":"main","amount":100},{"name":"promo","amount":20}],"expirations":[{"amount":20,"expires_at":"2024-03-05T17:25:35.448Z"}]}`,
+		},
+		{
+			name: "Ok - with pockets",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
+				total := 150.0
+
+				r.EXPECT().GetWalletById(context.Background(), id).Return(&entity.Wallet{
+					ID:           id,
+					Balance:      100.0,
+					Status:       entity.WalletStatusActive,
+					TotalBalance: &total,
+					Pockets: []entity.Wallet{
+						{
+							ID:       "eb376add88bf8e70f80787266a0801d5",
+							Balance:  50.0,
+							Status:   entity.WalletStatusActive,
+							ParentID: id,
+							Name:     "Vacation",
+						},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":100,"status":"active","total_balance":150,"pockets":[{"id":"eb376add88bf8e70f80787266a0801d5","balance":50,"status":"active","parent_id":"5b53700ed469fa6a09ea72bb78f36fd9","name":"Vacation"}]}`,
+		},
 		{
 			name: "Not Found",
-			id: "abc",
+			id:   "abc",
 			mockBehavior: func(r *mock_usecase.MockWallet, id string) {
 				r.EXPECT().GetWalletById(context.Background(), id).Return(nil, errors.New("something went wrong"))
 			},
-			expectedStatusCode: 404,
+			expectedStatusCode:   404,
 			expectedResponseBody: "",
 		},
 	}
@@ -413,4 +640,309 @@ func Test_getWalletById(t *testing.T) {
 			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func Test_setCreditLimit(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWallet, id string, request entity.CreditLimitRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              entity.CreditLimitRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.CreditLimitRequest{
+				CreditLimit: 500.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.CreditLimitRequest) {
+				available := 350.0
+
+				r.EXPECT().SetCreditLimit(context.Background(), id, request.CreditLimit).Return(&entity.Wallet{
+					ID:              id,
+					Balance:         -150.0,
+					CreditLimit:     500.0,
+					AvailableCredit: &available,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":-150,"credit_limit":500,"available_credit":350}`,
+		},
+		{
+			name: "Not found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.CreditLimitRequest{
+				CreditLimit: 500.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.CreditLimitRequest) {
+				r.EXPECT().SetCreditLimit(context.Background(), id, request.CreditLimit).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Credit limit is lower than debt",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.CreditLimitRequest{
+				CreditLimit: 100.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.CreditLimitRequest) {
+				r.EXPECT().SetCreditLimit(context.Background(), id, request.CreditLimit).Return(nil, entity.ErrCreditLimitTooLow)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Wrong input - credit limit less 0",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.CreditLimitRequest{
+				CreditLimit: -100.0,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.CreditLimitRequest) {
+				r.EXPECT().SetCreditLimit(context.Background(), id, request.CreditLimit).Return(nil, entity.ErrWrongCreditLimit)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockWallet(c)
+			test.mockBehavior(repo, test.id, test.request)
+			handler := walletRoutes{
+				w: repo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.PUT("/:walletId/credit-limit", handler.setCreditLimit)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/%s/credit-limit", test.id), bytes.NewBuffer(reqBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_setWalletStatus(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWallet, id string, request entity.WalletStatusRequest)
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              entity.WalletStatusRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.WalletStatusRequest{
+				Status: entity.WalletStatusFrozen,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.WalletStatusRequest) {
+				r.EXPECT().SetWalletStatus(context.Background(), id, request.Status).Return(&entity.Wallet{
+					ID:      id,
+					Balance: 100.0,
+					Status:  entity.WalletStatusFrozen,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":"5b53700ed469fa6a09ea72bb78f36fd9","balance":100,"status":"frozen"}`,
+		},
+		{
+			name: "Not found",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.WalletStatusRequest{
+				Status: entity.WalletStatusFrozen,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.WalletStatusRequest) {
+				r.EXPECT().SetWalletStatus(context.Background(), id, request.Status).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Closing wallet with balance",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.WalletStatusRequest{
+				Status: entity.WalletStatusClosed,
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.WalletStatusRequest) {
+				r.EXPECT().SetWalletStatus(context.Background(), id, request.Status).Return(nil, entity.ErrWalletNotEmpty)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Wrong status",
+			id:   "5b53700ed469fa6a09ea72bb78f36fd9",
+			request: entity.WalletStatusRequest{
+				Status: "deleted",
+			},
+			mockBehavior: func(r *mock_usecase.MockWallet, id string, request entity.WalletStatusRequest) {
+				r.EXPECT().SetWalletStatus(context.Background(), id, request.Status).Return(nil, entity.ErrWrongWalletStatus)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockWallet(c)
+			test.mockBehavior(repo, test.id, test.request)
+			handler := walletRoutes{
+				w: repo,
+				l: logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.PUT("/:walletId/status", handler.setWalletStatus)
+			// Create Request
+			w := httptest.NewRecorder()
+			reqBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/%s/status", test.id), bytes.NewBuffer(reqBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_walletRoutesInMemory(t *testing.T) {
+	// Init Dependencies
+	wallets := memory.NewWalletRepo()
+	w := usecase.New(wallets, memory.NewOutboxRepo(wallets), 100.0, 0.05)
+
+	// Init Endpoint
+	r := gin.New()
+	newWalletRoutes(r.Group("/api/v1"), r.Group("/api/v1/admin"), w, usecase.NewReceipt(nil, nil), logger.New(""))
+
+	do := func(method string, target string, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(method, target, bytes.NewBufferString(body)))
+		return rec
+	}
+
+	// Create Request
+	ids := make([]string, 2)
+	for i := range ids {
+		rec := do("POST", "/api/v1/wallet", "")
+		assert.Equal(t, rec.Code, 200)
+
+		var wallet entity.Wallet
+		assert.Equal(t, json.Unmarshal(rec.Body.Bytes(), &wallet), nil)
+		ids[i] = wallet.ID
+	}
+
+	// Make Request
+	rec := do("POST", "/api/v1/wallet/"+ids[0]+"/send", fmt.Sprintf(`{"to":"%s","amount":30}`, ids[1]))
+	assert.Equal(t, rec.Code, 200)
+	rec = do("POST", "/api/v1/wallet/"+ids[0]+"/send", fmt.Sprintf(`{"to":"%s","amount":80}`, ids[1]))
+	assert.Equal(t, rec.Code, 400)
+	rec = do("POST", "/api/v1/wallet/eb376add88bf8e70f80787266a0801d5/send", fmt.Sprintf(`{"to":"%s","amount":10}`, ids[1]))
+	assert.Equal(t, rec.Code, 404)
+
+	// Assert
+	rec = do("GET", "/api/v1/wallet/"+ids[1], "")
+	assert.Equal(t, rec.Code, 200)
+	var wallet entity.Wallet
+	assert.Equal(t, json.Unmarshal(rec.Body.Bytes(), &wallet), nil)
+	assert.Equal(t, wallet.Balance, 130.0)
+
+	rec = do("GET", "/api/v1/wallet/"+ids[0]+"/history", "")
+	assert.Equal(t, rec.Code, 200)
+	var history []entity.Transaction
+	assert.Equal(t, json.Unmarshal(rec.Body.Bytes(), &history), nil)
+	assert.Equal(t, len(history), 1)
+	assert.Equal(t, history[0].From, ids[0])
+	assert.Equal(t, history[0].To, ids[1])
+	assert.Equal(t, history[0].Amount, 30.0)
+}
+
+func Test_walletVersions(t *testing.T) {
+	// Init Dependencies
+	wallets := memory.NewWalletRepo()
+	w := usecase.New(wallets, memory.NewOutboxRepo(wallets), 100.0, 0.05)
+
+	// Init Endpoint
+	r := gin.New()
+	newWalletRoutes(r.Group("/api/v1"), r.Group("/api/v1/admin"), w, usecase.NewReceipt(nil, nil), logger.New(""))
+
+	do := func(method string, target string, body string, header string, etag string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+		if etag != "" {
+			req.Header.Set(header, etag)
+		}
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Create Request
+	ids := make([]string, 2)
+	for i := range ids {
+		rec := do("POST", "/api/v1/wallet", "", "", "")
+		assert.Equal(t, rec.Code, 200)
+
+		var wallet entity.Wallet
+		assert.Equal(t, json.Unmarshal(rec.Body.Bytes(), &wallet), nil)
+		ids[i] = wallet.ID
+	}
+
+	// The version is returned as ETag, the unchanged wallet is not sent again
+	rec := do("GET", "/api/v1/wallet/"+ids[0], "", "", "")
+	assert.Equal(t, rec.Code, 200)
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, etag, `"1"`)
+	rec = do("GET", "/api/v1/wallet/"+ids[0], "", "If-None-Match", etag)
+	assert.Equal(t, rec.Code, 304)
+	assert.Equal(t, rec.Body.Len(), 0)
+
+	// The transfer changes the version
+	rec = do("POST", "/api/v1/wallet/"+ids[0]+"/send", fmt.Sprintf(`{"to":"%s","amount":30}`, ids[1]), "If-Match", etag)
+	assert.Equal(t, rec.Code, 200)
+	rec = do("POST", "/api/v1/wallet/"+ids[0]+"/send", fmt.Sprintf(`{"to":"%s","amount":30}`, ids[1]), "If-Match", etag)
+	assert.Equal(t, rec.Code, 412)
+	rec = do("PUT", "/api/v1/admin/wallet/"+ids[0]+"/status", `{"status":"frozen"}`, "If-Match", `W/"2"`)
+	assert.Equal(t, rec.Code, 412)
+
+	rec = do("GET", "/api/v1/wallet/"+ids[0], "", "If-None-Match", etag)
+	assert.Equal(t, rec.Code, 200)
+	assert.Equal(t, rec.Header().Get("ETag"), `"2"`)
+
+	rec = do("PUT", "/api/v1/admin/wallet/"+ids[0]+"/status", `{"status":"frozen"}`, "If-Match", `"1", "2"`)
+	assert.Equal(t, rec.Code, 200)
+	assert.Equal(t, rec.Header().Get("ETag"), `"3"`)
+	rec = do("PUT", "/api/v1/admin/wallet/"+ids[0]+"/credit-limit", `{"credit_limit":50}`, "If-Match", `"2"`)
+	assert.Equal(t, rec.Code, 412)
+	rec = do("PUT", "/api/v1/admin/wallet/"+ids[0]+"/credit-limit", `{"credit_limit":50}`, "If-Match", "*")
+	assert.Equal(t, rec.Code, 200)
+	assert.Equal(t, rec.Header().Get("ETag"), `"4"`)
+}
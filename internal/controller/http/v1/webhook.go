@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type webhookRoutes struct {
+	wh usecase.Webhook
+	l  logger.Interface
+}
+
+func newWebhookRoutes(admin *gin.RouterGroup, wh usecase.Webhook, l logger.Interface) {
+	r := &webhookRoutes{wh, l}
+
+	a := admin.Group("/webhooks")
+	{
+		a.POST("", r.createWebhook)
+		a.GET("", r.getWebhooks)
+		a.DELETE("/:webhookId", r.deleteWebhook)
+		a.GET("/:webhookId/deliveries", r.getDeliveries)
+		a.POST("/:webhookId/deliveries/:deliveryId/replay", r.replayDelivery)
+	}
+}
+
+// @Summary     Создание подписки на события кошелька
+// @Description Подписывает внешнюю систему на события кошелька. События отправляются POST-запросом на указанный адрес; неудачные попытки повторяются с экспоненциальной задержкой.
+// @Description
+// @Description Запрос подписывается заголовком X-Webhook-Signature: HMAC-SHA256 строки "<X-Webhook-Timestamp>.<тело запроса>" с секретом подписки в hex. Секрет возвращается только в ответе на этот запрос
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param input body entity.WebhookRequest true "Запрос создания подписки"
+// @Success     200 {object} entity.Webhook "Подписка создана"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Указанный кошелек не найден"
+// @Router      /admin/webhooks [post]
+func (r *webhookRoutes) createWebhook(c *gin.Context) {
+	var request entity.WebhookRequest
+
+	if err := c.BindJSON(&request); err != nil {
+		r.l.Error(err, "http - v1 - createWebhook")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	webhook, err := r.wh.CreateWebhook(c.Request.Context(), request)
+	if errors.Is(err, entity.ErrWalletNotFound) {
+		r.l.Error(err, "http - v1 - createWebhook")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrWrongWebhook) {
+		r.l.Error(err, "http - v1 - createWebhook")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - createWebhook")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary     Получение подписок кошелька
+// @Description Возвращает подписки внешних систем на события кошелька без их секретов
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param wallet_id query string true "ID кошелька"
+// @Success     200 {object} []entity.Webhook "Подписки получены"
+// @Failure     400 "Не указан кошелек"
+// @Failure     401 "Требуется токен администратора"
+// @Router      /admin/webhooks [get]
+func (r *webhookRoutes) getWebhooks(c *gin.Context) {
+	walletId := c.Query("wallet_id")
+	if walletId == "" {
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	webhooks, err := r.wh.GetWebhooks(c.Request.Context(), walletId)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getWebhooks")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary     Удаление подписки
+// @Description Удаляет подписку вместе с историей доставок, неотправленные события не доставляются
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param webhookId path int true "ID подписки"
+// @Success     200 "Подписка удалена"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Подписка не найдена"
+// @Router      /admin/webhooks/{webhookId} [delete]
+func (r *webhookRoutes) deleteWebhook(c *gin.Context) {
+	webhookId, err := strconv.ParseInt(c.Param("webhookId"), 10, 64)
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteWebhook")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	err = r.wh.DeleteWebhook(c.Request.Context(), webhookId)
+	if errors.Is(err, entity.ErrWebhookNotFound) {
+		r.l.Error(err, "http - v1 - deleteWebhook")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteWebhook")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary     Получение доставок по подписке
+// @Description Возвращает доставки событий по подписке, начиная с последних. Доставки в статусе failed исчерпали попытки и могут быть отправлены повторно
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param webhookId path int true "ID подписки"
+// @Param status query string false "Статус доставки (pending, delivered, failed)"
+// @Success     200 {object} []entity.WebhookDelivery "Доставки получены"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Подписка не найдена"
+// @Router      /admin/webhooks/{webhookId}/deliveries [get]
+func (r *webhookRoutes) getDeliveries(c *gin.Context) {
+	webhookId, err := strconv.ParseInt(c.Param("webhookId"), 10, 64)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getDeliveries")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	deliveries, err := r.wh.GetDeliveries(c.Request.Context(), webhookId, c.Query("status"))
+	if errors.Is(err, entity.ErrWebhookNotFound) {
+		r.l.Error(err, "http - v1 - getDeliveries")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrWrongDeliveryStatus) {
+		r.l.Error(err, "http - v1 - getDeliveries")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - getDeliveries")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary     Повторная отправка доставки
+// @Description Возвращает доставку в статусе failed в очередь с новым счетчиком попыток
+// @Tags  	    Admin
+// @Security    AdminToken
+// @Param webhookId path int true "ID подписки"
+// @Param deliveryId path int true "ID доставки"
+// @Success     200 {object} entity.WebhookDelivery "Доставка поставлена в очередь"
+// @Failure     400 "Ошибка в запросе"
+// @Failure     401 "Требуется токен администратора"
+// @Failure     404 "Доставка не найдена"
+// @Failure     409 "Доставка не находится в статусе failed"
+// @Router      /admin/webhooks/{webhookId}/deliveries/{deliveryId}/replay [post]
+func (r *webhookRoutes) replayDelivery(c *gin.Context) {
+	webhookId, err := strconv.ParseInt(c.Param("webhookId"), 10, 64)
+	if err != nil {
+		r.l.Error(err, "http - v1 - replayDelivery")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+	deliveryId, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		r.l.Error(err, "http - v1 - replayDelivery")
+		c.Status(http.StatusBadRequest)
+
+		return
+	}
+
+	delivery, err := r.wh.ReplayDelivery(c.Request.Context(), webhookId, deliveryId)
+	if errors.Is(err, entity.ErrDeliveryNotFound) {
+		r.l.Error(err, "http - v1 - replayDelivery")
+		c.Status(http.StatusNotFound)
+
+		return
+	}
+	if errors.Is(err, entity.ErrDeliveryNotReplayable) {
+		r.l.Error(err, "http - v1 - replayDelivery")
+		c.Status(http.StatusConflict)
+
+		return
+	}
+	if err != nil {
+		r.l.Error(err, "http - v1 - replayDelivery")
+		c.Status(http.StatusInternalServerError)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
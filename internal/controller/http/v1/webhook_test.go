@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+func Test_createWebhook(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWebhook, request entity.WebhookRequest)
+
+	createdAt, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35Z")
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		inputRequest         entity.WebhookRequest
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","url":"https://partner.example/hooks","event_types":["incoming_transfer"]}`,
+			inputRequest: entity.WebhookRequest{
+				WalletID:   "5b53700ed469fa6a09ea72bb78f36fd9",
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{entity.WalletEventIncomingTransfer},
+			},
+			mockBehavior: func(r *mock_usecase.MockWebhook, request entity.WebhookRequest) {
+				r.EXPECT().CreateWebhook(context.Background(), request).Return(&entity.Webhook{
+					ID:         1,
+					WalletID:   request.WalletID,
+					URL:        request.URL,
+					EventTypes: request.EventTypes,
+					Secret:     "0a1b2c",
+					CreatedAt:  createdAt,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","url":"https://partner.example/hooks","event_types":["incoming_transfer"],"secret":"0a1b2c","created_at":"2024-02-04T17:25:35Z"}`,
+		},
+		{
+			name:      "Wrong input - wrong webhook",
+			inputBody: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","url":"/hooks"}`,
+			inputRequest: entity.WebhookRequest{
+				WalletID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				URL:      "/hooks",
+			},
+			mockBehavior: func(r *mock_usecase.MockWebhook, request entity.WebhookRequest) {
+				r.EXPECT().CreateWebhook(context.Background(), request).Return(nil, entity.ErrWrongWebhook)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Wrong input - wrong body",
+			inputBody:            `{"wallet_id":`,
+			mockBehavior:         func(r *mock_usecase.MockWebhook, request entity.WebhookRequest) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:      "Wallet not found",
+			inputBody: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","url":"https://partner.example/hooks"}`,
+			inputRequest: entity.WebhookRequest{
+				WalletID: "5b53700ed469fa6a09ea72bb78f36fd9",
+				URL:      "https://partner.example/hooks",
+			},
+			mockBehavior: func(r *mock_usecase.MockWebhook, request entity.WebhookRequest) {
+				r.EXPECT().CreateWebhook(context.Background(), request).Return(nil, entity.ErrWalletNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			webhook := mock_usecase.NewMockWebhook(c)
+			test.mockBehavior(webhook, test.inputRequest)
+			handler := webhookRoutes{
+				wh: webhook,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/webhooks", handler.createWebhook)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/webhooks", bytes.NewBufferString(test.inputBody))
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_getDeliveries(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWebhook)
+
+	createdAt, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35Z")
+
+	tests := []struct {
+		name                 string
+		webhookId            string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			webhookId: "1",
+			query:     "?status=failed",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().GetDeliveries(context.Background(), int64(1), entity.WebhookDeliveryFailed).Return([]entity.WebhookDelivery{
+					{
+						ID:             7,
+						WebhookID:      1,
+						EventID:        42,
+						EventType:      entity.WalletEventIncomingTransfer,
+						Payload:        `{"id":42}`,
+						Status:         entity.WebhookDeliveryFailed,
+						Attempts:       8,
+						NextAttemptAt:  createdAt,
+						LastStatusCode: 503,
+						LastError:      "unexpected status 503",
+						CreatedAt:      createdAt,
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":7,"webhook_id":1,"event_id":42,"event_type":"incoming_transfer","payload":"{\"id\":42}","status":"failed","attempts":8,"next_attempt_at":"2024-02-04T17:25:35Z","last_status_code":503,"last_error":"unexpected status 503","created_at":"2024-02-04T17:25:35Z"}]`,
+		},
+		{
+			name:      "Wrong input - wrong status",
+			webhookId: "1",
+			query:     "?status=lost",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().GetDeliveries(context.Background(), int64(1), "lost").Return(nil, entity.ErrWrongDeliveryStatus)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Wrong input - wrong webhook id",
+			webhookId:            "first",
+			mockBehavior:         func(r *mock_usecase.MockWebhook) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "",
+		},
+		{
+			name:      "Webhook not found",
+			webhookId: "2",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().GetDeliveries(context.Background(), int64(2), "").Return(nil, entity.ErrWebhookNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			webhook := mock_usecase.NewMockWebhook(c)
+			test.mockBehavior(webhook)
+			handler := webhookRoutes{
+				wh: webhook,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/webhooks/:webhookId/deliveries", handler.getDeliveries)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/webhooks/%s/deliveries%s", test.webhookId, test.query), nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
+func Test_replayDelivery(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_usecase.MockWebhook)
+
+	nextAttemptAt, _ := time.Parse(time.RFC3339, "2024-02-04T17:25:35Z")
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().ReplayDelivery(context.Background(), int64(1), int64(7)).Return(&entity.WebhookDelivery{
+					ID:            7,
+					WebhookID:     1,
+					EventID:       42,
+					EventType:     entity.WalletEventIncomingTransfer,
+					Payload:       "{}",
+					Status:        entity.WebhookDeliveryPending,
+					NextAttemptAt: nextAttemptAt,
+					CreatedAt:     nextAttemptAt,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":7,"webhook_id":1,"event_id":42,"event_type":"incoming_transfer","payload":"{}","status":"pending","attempts":0,"next_attempt_at":"2024-02-04T17:25:35Z","created_at":"2024-02-04T17:25:35Z"}`,
+		},
+		{
+			name: "Delivery is not failed",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().ReplayDelivery(context.Background(), int64(1), int64(7)).Return(nil, entity.ErrDeliveryNotReplayable)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Delivery not found",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().ReplayDelivery(context.Background(), int64(1), int64(7)).Return(nil, entity.ErrDeliveryNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "",
+		},
+		{
+			name: "Something went wrong",
+			mockBehavior: func(r *mock_usecase.MockWebhook) {
+				r.EXPECT().ReplayDelivery(context.Background(), int64(1), int64(7)).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			webhook := mock_usecase.NewMockWebhook(c)
+			test.mockBehavior(webhook)
+			handler := webhookRoutes{
+				wh: webhook,
+				l:  logger.New(""),
+			}
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/webhooks/:webhookId/deliveries/:deliveryId/replay", handler.replayDelivery)
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/webhooks/1/deliveries/7/replay", nil)
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
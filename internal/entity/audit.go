@@ -0,0 +1,66 @@
+package entity
+
+import "time"
+
+const (
+	// Actors of the audited operations
+	AuditActorAdmin        = "admin"
+	AuditActorClient       = "client"
+	AuditActorGRPC         = "grpc"
+	AuditActorUnauthorized = "unauthorized"
+	AuditActorSystem       = "system"
+
+	// Audited operations of the wallets, other requests are recorded as "<method> <route>"
+	AuditOperationCreateWallet          = "create_wallet"
+	AuditOperationSendFunds             = "send_funds"
+	AuditOperationSetCreditLimit        = "set_credit_limit"
+	AuditOperationSetWalletStatus       = "set_wallet_status"
+	AuditOperationGrantPromo            = "grant_promo"
+	AuditOperationIssueVouchers         = "issue_vouchers"
+	AuditOperationMovePocketFunds       = "move_pocket_funds"
+	AuditOperationClosePocket           = "close_pocket"
+	AuditOperationDeposit               = "deposit"
+	AuditOperationWithdraw              = "withdraw"
+	AuditOperationCompletePayment       = "complete_payment"
+	AuditOperationReplayWebhookDelivery = "replay_webhook_delivery"
+
+	// Limits of the audit log page
+	DefaultAuditEntries = 100
+	MaxAuditEntries     = 1000
+
+	// Request ids longer than that are replaced by generated ones
+	MaxRequestIDLength = 64
+)
+
+// AuditActor - who made the audited request and from where.
+type AuditActor struct {
+	Actor     string
+	ClientIP  string
+	RequestID string
+}
+
+// @Description Запись журнала аудита
+type AuditEntry struct {
+	ID        int64                  `json:"id"                   example:"42"                                description:"ID записи"`
+	Time      time.Time              `json:"time"                 example:"2024-02-04T17:25:35.448Z"          description:"Время операции"                                                      format:"date-time"`
+	Actor     string                 `json:"actor"                example:"admin"                             description:"Кто выполнил операцию (admin, client, grpc, unauthorized, system)"`
+	ClientIP  string                 `json:"client_ip,omitempty"  example:"192.168.0.10"                      description:"IP-адрес клиента"`
+	RequestID string                 `json:"request_id,omitempty" example:"8f14e45fceea167a5a36dedd4bea2543"  description:"ID запроса (заголовок X-Request-ID)"`
+	Operation string                 `json:"operation"            example:"set_credit_limit"                  description:"Операция (create_wallet, send_funds, set_credit_limit, set_wallet_status, grant_promo, issue_vouchers, move_pocket_funds, close_pocket, deposit, withdraw, complete_payment, replay_webhook_delivery или \"<метод> <путь>\" для остальных запросов)"`
+	WalletID  string                 `json:"wallet_id,omitempty"  example:"5b53700ed469fa6a09ea72bb78f36fd9"  description:"ID кошелька, над которым выполнена операция"`
+	Details   map[string]interface{} `json:"details,omitempty"                                                description:"Параметры операции"                                                  pg:",type:jsonb"`
+	Before    *Wallet                `json:"before,omitempty"                                                 description:"Кошелек до операции"                                                 pg:",type:jsonb"`
+	After     *Wallet                `json:"after,omitempty"                                                  description:"Кошелек после операции"                                              pg:",type:jsonb"`
+	Error     string                 `json:"error,omitempty"      example:"Not Found"                         description:"Ошибка, если операция не выполнена"`
+}
+
+// AuditFilter - optional conditions for selecting audit log entries.
+type AuditFilter struct {
+	WalletID  string
+	Actor     string
+	Operation string
+	RequestID string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
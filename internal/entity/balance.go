@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// @Description Баланс кошелька на момент времени
+type BalanceAt struct {
+	WalletID string    `json:"wallet_id" example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	At       time.Time `json:"at"        example:"2023-12-31T23:59:59Z"             description:"Момент времени" format:"date-time"`
+	Balance  float64   `json:"balance"   example:"250.0"                            description:"Баланс кошелька с учетом операций до указанного момента" format:"float"`
+}
@@ -0,0 +1,61 @@
+package entity
+
+const (
+	// Group statuses of the payment status report
+	BulkGroupAccepted          = "ACCP"
+	BulkGroupPartiallyAccepted = "PART"
+	BulkGroupRejected          = "RJCT"
+
+	// Statuses of the instructions
+	BulkInstructionSettled  = "ACSC"
+	BulkInstructionRejected = "RJCT"
+
+	// Reasons of the rejection
+	BulkReasonWrongAccount      = "AC01"
+	BulkReasonClosedAccount     = "AC04"
+	BulkReasonBlockedAccount    = "AC06"
+	BulkReasonTransferForbidden = "AG01"
+	BulkReasonWrongCurrency     = "AM03"
+	BulkReasonInsufficientFunds = "AM04"
+	BulkReasonDuplication       = "AM05"
+	BulkReasonWrongAmount       = "AM12"
+	BulkReasonNarrative         = "NARR"
+
+	// End-to-end identifier of instructions without a reference
+	EndToEndNotProvided = "NOTPROVIDED"
+)
+
+// BulkPayment - credit transfers of a parsed payment initiation file.
+type BulkPayment struct {
+	MessageID    string
+	Instructions []BulkInstruction
+}
+
+// BulkInstruction - a single credit transfer of a payment initiation file.
+type BulkInstruction struct {
+	PaymentInfoID string
+	InstructionID string
+	EndToEndID    string
+	From          string
+	To            string
+	Amount        float64
+	Currency      string
+	Description   string
+}
+
+// @Description Отчет о статусе платежей по формату pain.002
+type BulkStatusReport struct {
+	OriginalMessageID string                  `json:"original_message_id" example:"PAYOUTS-2024-02-29" description:"Идентификатор исходного файла"`
+	GroupStatus       string                  `json:"group_status"        example:"PART"               description:"Статус файла (ACCP, PART, RJCT)"`
+	Instructions      []BulkInstructionStatus `json:"instructions"                                     description:"Статусы платежей"`
+}
+
+// @Description Статус платежа из файла
+type BulkInstructionStatus struct {
+	PaymentInfoID string `json:"payment_info_id"          example:"BATCH-1"       description:"Идентификатор пакета платежей"`
+	InstructionID string `json:"instruction_id,omitempty" example:"1"             description:"Идентификатор платежа"`
+	EndToEndID    string `json:"end_to_end_id"            example:"INV-2024-0042" description:"Сквозной идентификатор платежа"`
+	Status        string `json:"status"                   example:"RJCT"          description:"Статус платежа (ACSC, RJCT)"`
+	Reason        string `json:"reason,omitempty"         example:"AM04"          description:"Код причины отказа ISO 20022"`
+	Details       string `json:"details,omitempty"        example:"insufficient funds" description:"Описание причины отказа"`
+}
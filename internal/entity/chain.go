@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// Reasons of a broken link of the transaction chain
+	ChainBreakNotChained   = "transaction is not chained"
+	ChainBreakPrevHash     = "previous hash doesn't match the hash of the previous transaction"
+	ChainBreakHash         = "hash doesn't match the content of the transaction"
+	ChainBreakHead         = "chain head doesn't match the last transaction"
+	ChainBreakSignedHead   = "signed chain head doesn't match the transaction"
+	ChainBreakSignature    = "signature of the chain head is invalid"
+	ChainBreakMissingEntry = "transaction of the signed chain head is missing"
+)
+
+// TransactionChain - the last chained transaction, new transactions are linked to its hash and get the next position.
+type TransactionChain struct {
+	ID            bool   `pg:",pk"`
+	TransactionID int64  `pg:",use_zero"`
+	Hash          string `pg:",use_zero"`
+	Position      int64  `pg:",use_zero"`
+}
+
+// ChainHash - hash of the transaction content linked to the hash of the previous transaction of the chain.
+func (t *Transaction) ChainHash() string {
+	// The fields are encoded as a JSON array, so the same content always gives the same bytes
+	content, _ := json.Marshal([]interface{}{
+		t.PrevHash,
+		t.ID,
+		t.Time.UTC().Format(time.RFC3339Nano),
+		t.From,
+		t.To,
+		t.Amount,
+		t.Description,
+		t.ExternalReference,
+		t.Type,
+		t.FromBalanceAfter,
+		t.ToBalanceAfter,
+	})
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// @Description Подписанная вершина цепочки операций
+type ChainHead struct {
+	ID            int64     `json:"id"             example:"42"                                                               description:"ID вершины"`
+	TransactionID int64     `json:"transaction_id" example:"1024"                                                             description:"ID последней операции цепочки"                      pg:",use_zero"`
+	Hash          string    `json:"hash"           example:"5f2b7e3c0a9d4e18b6c1f0a2d3e4b5c6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2" description:"Хэш последней операции цепочки в hex"             pg:",use_zero"`
+	SignedAt      time.Time `json:"signed_at"      example:"2024-02-05T03:00:00Z"                                             description:"Время подписи"                                                         format:"date-time"`
+	PublicKey     string    `json:"public_key"     example:"dGVzdC1wdWJsaWMta2V5LXRlc3QtcHVibGljLWtleSE="                     description:"Открытый ключ ed25519 в base64"`
+	Signature     string    `json:"signature"      example:"c2lnbmF0dXJl"                                                     description:"Подпись ed25519 строки \"<transaction_id>:<hash>:<signed_at>\" в base64"`
+}
+
+// Message - signed content of the chain head.
+func (h *ChainHead) Message() []byte {
+	return []byte(fmt.Sprintf("%d:%s:%s", h.TransactionID, h.Hash, h.SignedAt.UTC().Format(time.RFC3339)))
+}
+
+// ChainVerification - result of walking the transaction chain.
+type ChainVerification struct {
+	Transactions int
+	Unchained    int
+	Heads        int
+	Break        *ChainBreak
+}
+
+// ChainBreak - the first broken link of the transaction chain.
+type ChainBreak struct {
+	TransactionID int64
+	Reason        string
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// Domain event types
+	DomainEventWalletCreated       = "WalletCreated"
+	DomainEventFundsTransferred    = "FundsTransferred"
+	DomainEventCreditLimitChanged  = "CreditLimitChanged"
+	DomainEventWalletFrozen        = "WalletFrozen"
+	DomainEventWalletUnfrozen      = "WalletUnfrozen"
+	DomainEventWalletClosed        = "WalletClosed"
+	DomainEventPocketFundsMoved    = "PocketFundsMoved"
+	DomainEventPocketClosed        = "PocketClosed"
+	DomainEventVoucherRedeemed     = "VoucherRedeemed"
+	DomainEventVoucherRefunded     = "VoucherRefunded"
+	DomainEventPaymentSubmitted    = "PaymentSubmitted"
+	DomainEventPaymentCompleted    = "PaymentCompleted"
+	DomainEventPromoGranted        = "PromoGranted"
+	DomainEventPromoExpired        = "PromoExpired"
+	DomainEventInterestCapitalized = "InterestCapitalized"
+)
+
+// DomainEvent - state change of a wallet. It is stored in the outbox in the transaction of the change
+// and published to other components afterwards, at least once.
+type DomainEvent struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+	PublishedAt *time.Time      `json:"-"`
+}
+
+// WalletCreated - payload of the WalletCreated event.
+type WalletCreated struct {
+	WalletID   string  `json:"wallet_id"`
+	Type       string  `json:"type"`
+	Balance    float64 `json:"balance"`
+	AnnualRate float64 `json:"annual_rate,omitempty"`
+}
+
+// FundsTransferred - payload of the FundsTransferred event.
+type FundsTransferred struct {
+	From              string    `json:"from"`
+	To                string    `json:"to"`
+	Amount            float64   `json:"amount"`
+	Description       string    `json:"description,omitempty"`
+	ExternalReference string    `json:"external_reference,omitempty"`
+	Time              time.Time `json:"time"`
+}
+
+// CreditLimitChanged - payload of the CreditLimitChanged event.
+type CreditLimitChanged struct {
+	WalletID    string  `json:"wallet_id"`
+	CreditLimit float64 `json:"credit_limit"`
+}
+
+// WalletStatusChanged - payload of the WalletFrozen, WalletUnfrozen and WalletClosed events.
+type WalletStatusChanged struct {
+	WalletID string `json:"wallet_id"`
+	Status   string `json:"status"`
+}
+
+// FundsMoved - payload of the PocketFundsMoved, VoucherRedeemed, VoucherRefunded, PromoExpired and
+// InterestCapitalized events, the type of the recorded transaction tells the movements apart.
+type FundsMoved struct {
+	From              string    `json:"from"`
+	To                string    `json:"to"`
+	Amount            float64   `json:"amount"`
+	Type              string    `json:"type"`
+	Description       string    `json:"description,omitempty"`
+	ExternalReference string    `json:"external_reference,omitempty"`
+	Time              time.Time `json:"time"`
+}
+
+// PocketClosed - payload of the PocketClosed event, the rest of the pocket is returned to the wallet.
+type PocketClosed struct {
+	WalletID string  `json:"wallet_id"`
+	PocketID string  `json:"pocket_id"`
+	Returned float64 `json:"returned"`
+}
+
+// PaymentSubmitted - payload of the PaymentSubmitted event, funds of a withdrawal are held from the moment.
+type PaymentSubmitted struct {
+	PaymentID string  `json:"payment_id"`
+	WalletID  string  `json:"wallet_id"`
+	Type      string  `json:"type"`
+	Amount    float64 `json:"amount"`
+}
+
+// PaymentCompleted - payload of the PaymentCompleted event.
+type PaymentCompleted struct {
+	PaymentID string  `json:"payment_id"`
+	WalletID  string  `json:"wallet_id"`
+	Type      string  `json:"type"`
+	Status    string  `json:"status"`
+	Amount    float64 `json:"amount"`
+}
+
+// PromoGranted - payload of the PromoGranted event.
+type PromoGranted struct {
+	GrantID   int64     `json:"grant_id"`
+	WalletID  string    `json:"wallet_id"`
+	Amount    float64   `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -4,7 +4,79 @@ import "errors"
 
 var (
 	// Wallet errors
-	ErrWalletNotFound = errors.New("wallet not found")
-	ErrWrongAmount = errors.New("wrong amount")
-	ErrSenderIsReceiver = errors.New("sender is receiver")
-)
\ No newline at end of file
+	ErrWalletNotFound    = errors.New("wallet not found")
+	ErrWrongAmount       = errors.New("wrong amount")
+	ErrSenderIsReceiver  = errors.New("sender is receiver")
+	ErrReceiverNotFound  = errors.New("receiver wallet not found")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrWrongWalletType   = errors.New("wrong wallet type")
+	ErrWrongCreditLimit  = errors.New("wrong credit limit")
+	ErrCreditLimitTooLow = errors.New("credit limit is lower than the current debt")
+	ErrWrongWalletStatus = errors.New("wrong wallet status")
+	ErrWalletFrozen      = errors.New("wallet is frozen")
+	ErrWalletClosed      = errors.New("wallet is closed")
+	ErrWalletNotEmpty    = errors.New("wallet balance must be zero to close it")
+	ErrVersionMismatch   = errors.New("wallet version doesn't match")
+
+	// Transfer memo errors
+	ErrDescriptionTooLong       = errors.New("description is too long")
+	ErrExternalReferenceTooLong = errors.New("external reference is too long")
+	ErrDuplicateReference       = errors.New("external reference is already sent")
+
+	// Pocket errors
+	ErrPocketNotFound       = errors.New("pocket not found")
+	ErrWrongPocketName      = errors.New("wrong pocket name")
+	ErrWrongPocketDirection = errors.New("wrong pocket transfer direction")
+	ErrNestedPocket         = errors.New("pocket can't have its own pockets")
+	ErrPocketTransfer       = errors.New("pocket can only exchange funds with its parent wallet")
+
+	// Statement errors
+	ErrWrongPeriod          = errors.New("wrong period")
+	ErrWrongStatementFormat = errors.New("wrong statement format")
+
+	// Payment file errors
+	ErrWrongPaymentFile    = errors.New("wrong payment file")
+	ErrControlSumMismatch  = errors.New("control sum doesn't match the transactions")
+	ErrNumberOfTxsMismatch = errors.New("number of transactions doesn't match the transactions")
+
+	// Voucher errors
+	ErrWrongVoucherBatch = errors.New("wrong voucher batch")
+	ErrVoucherNotFound   = errors.New("voucher not found")
+	ErrVoucherExpired    = errors.New("voucher expired")
+	ErrVoucherUsed       = errors.New("voucher is already used")
+	ErrTooManyAttempts   = errors.New("too many failed attempts")
+
+	// Payment errors
+	ErrPaymentNotFound         = errors.New("payment not found")
+	ErrPaymentAlreadyCompleted = errors.New("payment is already completed")
+	ErrWrongPaymentStatus      = errors.New("wrong payment status")
+	ErrWrongSignature          = errors.New("wrong signature")
+
+	// Interest errors
+	ErrDayIsNotOver = errors.New("day is not over yet")
+
+	// Webhook errors
+	ErrWrongWebhook          = errors.New("wrong webhook")
+	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrDeliveryNotReplayable = errors.New("only failed webhook deliveries can be replayed")
+	ErrWrongDeliveryStatus   = errors.New("wrong webhook delivery status")
+
+	// Event store errors
+	ErrVersionConflict = errors.New("wallet was changed concurrently, try again")
+
+	// Reconciliation errors
+	ErrReconciliationNotFound = errors.New("reconciliation has not been run yet")
+
+	// Transaction chain errors
+	ErrChainSigningKeyMissing = errors.New("chain signing key is not configured")
+	ErrEmptyChain             = errors.New("transaction chain is empty")
+
+	// Receipt errors
+	ErrReceiptSigningKeyMissing = errors.New("receipt signing key is not configured")
+	ErrReceiptUnavailable       = errors.New("receipt is unavailable for the transaction without id")
+	ErrTransactionNotFound      = errors.New("transaction not found")
+
+	// Audit errors
+	ErrWrongAuditLimit = errors.New("wrong audit log limit")
+)
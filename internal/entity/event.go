@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+const (
+	// Wallet event types
+	WalletEventIncomingTransfer = "incoming_transfer"
+	WalletEventOutgoingTransfer = "outgoing_transfer"
+	WalletEventBalanceChanged   = "balance_changed"
+)
+
+// @Description Событие кошелька
+type WalletEvent struct {
+	ID              int64     `json:"id"               example:"42"                               description:"ID события"`
+	Sequence        int64     `json:"sequence"         example:"7"                                description:"Номер события кошелька в порядке проведения операций, используется для продолжения подписки"`
+	WalletID        string    `json:"wallet_id"        example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	Type            string    `json:"type"             example:"incoming_transfer"                description:"Тип события (incoming_transfer, outgoing_transfer, balance_changed)"`
+	Time            time.Time `json:"time"             example:"2024-02-04T17:25:35.448Z"         description:"Дата и время операции"                                          format:"date-time"`
+	Amount          float64   `json:"amount"           example:"30.0"                             description:"Изменение баланса, отрицательное для списаний"                  format:"float"`
+	Balance         float64   `json:"balance"          example:"130.0"                            description:"Баланс кошелька после операции"                                 format:"float"`
+	Counterparty    string    `json:"counterparty"     example:"eb376add88bf8e70f80787266a0801d5" description:"ID второго кошелька операции"`
+	TransactionType string    `json:"transaction_type" example:"transfer"                         description:"Тип операции"`
+}
+
+// @Description Токен подписки на события кошелька
+type EventSubscription struct {
+	WalletID string `json:"wallet_id" example:"5b53700ed469fa6a09ea72bb78f36fd9"                                 description:"ID кошелька"`
+	Token    string `json:"token"     example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" description:"Токен для подписки на события кошелька"`
+}
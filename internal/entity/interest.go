@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+const (
+	// System wallet paying the interest expense
+	SystemInterestWalletID = "system-interest"
+
+	// Interest is calculated on the actual/365 day count basis
+	InterestDaysInYear = 365
+)
+
+// @Description Начисление процентов за день. Отрицательная сумма - проценты за использование кредитного лимита
+type InterestAccrual struct {
+	WalletID      string     `json:"wallet_id"                example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	Day           time.Time  `json:"day"                      example:"2024-02-04T00:00:00Z"             description:"День начисления"                   format:"date-time"`
+	Balance       float64    `json:"balance"                  example:"1000.0"                           description:"Баланс на конец дня"               format:"float"`
+	AnnualRate    float64    `json:"annual_rate"              example:"0.05"                             description:"Годовая ставка"                    format:"float"`
+	Amount        float64    `json:"amount"                   example:"0.136986"                         description:"Начисленные проценты"              format:"float"`
+	CapitalizedAt *time.Time `json:"capitalized_at,omitempty" example:"2024-03-01T00:05:00Z"             description:"Дата зачисления процентов на баланс" format:"date-time"`
+}
+
+// InterestPeriod - month of not capitalized accruals of a wallet.
+type InterestPeriod struct {
+	WalletID string
+	Month    time.Time
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	// Payment types
+	PaymentTypeDeposit    = "deposit"
+	PaymentTypeWithdrawal = "withdrawal"
+
+	// Payment statuses
+	PaymentStatusPending   = "pending"
+	PaymentStatusSucceeded = "succeeded"
+	PaymentStatusFailed    = "failed"
+
+	// System wallet representing money held by the payment gateway
+	SystemGatewayWalletID = "system-gateway"
+)
+
+// @Description Пополнение или вывод средств через платежный шлюз
+type Payment struct {
+	ID               string    `json:"id"                          example:"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e" description:"ID платежа"`
+	WalletID         string    `json:"wallet_id"                   example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	Type             string    `json:"type"                        example:"deposit"                          description:"Тип платежа (deposit, withdrawal)"`
+	Amount           float64   `json:"amount"                      example:"50.0"                             description:"Сумма платежа"                        format:"float"`
+	Status           string    `json:"status"                      example:"pending"                          description:"Статус платежа (pending, succeeded, failed)"`
+	GatewayReference string    `json:"gateway_reference,omitempty" example:"fake-3f2a9c1e"                    description:"ID платежа в платежном шлюзе"`
+	CreatedAt        time.Time `json:"created_at"                  example:"2024-02-04T17:25:35.448Z"         description:"Дата создания"                        format:"date-time"`
+	UpdatedAt        time.Time `json:"updated_at"                  example:"2024-02-04T17:25:35.448Z"         description:"Дата последнего изменения"            format:"date-time"`
+}
+
+// @Description Запрос пополнения или вывода средств
+type PaymentRequest struct {
+	Amount float64 `json:"amount" example:"50.0" description:"Сумма платежа" validate:"required" format:"float" minimum:"0.0"`
+}
+
+// PaymentNotification - payment result reported by the gateway.
+type PaymentNotification struct {
+	PaymentID        string `json:"payment_id"`
+	GatewayReference string `json:"gateway_reference"`
+	Status           string `json:"status"`
+}
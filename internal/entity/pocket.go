@@ -0,0 +1,21 @@
+package entity
+
+const (
+	// Pocket limits
+	MaxPocketNameLength = 64
+
+	// Pocket transfer directions
+	PocketDirectionIn  = "in"
+	PocketDirectionOut = "out"
+)
+
+// @Description Запрос создания копилки
+type PocketRequest struct {
+	Name string `json:"name" example:"Отпуск" description:"Название копилки" validate:"required" maxLength:"64"`
+}
+
+// @Description Запрос перемещения средств между кошельком и копилкой
+type PocketTransferRequest struct {
+	Amount    float64 `json:"amount"    example:"50.0" description:"Сумма перемещения"                                                validate:"required" format:"float" minimum:"0.0"`
+	Direction string  `json:"direction" example:"in"   description:"Направление: in - из кошелька в копилку, out - из копилки в кошелек" validate:"required"`
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+const (
+	// Balance buckets
+	BucketMain  = "main"
+	BucketPromo = "promo"
+
+	// System wallet that funds promotional credit and receives expired grants
+	SystemPromoWalletID = "system-promo"
+)
+
+// PromoGrant - promotional credit granted to a wallet.
+type PromoGrant struct {
+	ID        int64      `json:"id"                   example:"1"                                description:"ID начисления"`
+	WalletID  string     `json:"wallet_id"            example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	Amount    float64    `json:"amount"               example:"50.0"                             description:"Начисленная сумма"        format:"float"`
+	Remaining float64    `json:"remaining"            example:"20.0"                             description:"Неизрасходованный остаток" format:"float" pg:",use_zero"`
+	GrantedAt time.Time  `json:"granted_at"           example:"2024-02-04T17:25:35.448Z"         description:"Дата начисления"          format:"date-time"`
+	ExpiresAt time.Time  `json:"expires_at"           example:"2024-03-05T17:25:35.448Z"         description:"Дата сгорания"            format:"date-time"`
+	ExpiredAt *time.Time `json:"expired_at,omitempty" example:"2024-03-05T17:30:00.000Z"         description:"Дата фактического сгорания" format:"date-time"`
+}
+
+// @Description Запрос начисления промо-баланса
+type PromoGrantRequest struct {
+	Amount float64 `json:"amount" example:"50.0" description:"Сумма начисления" validate:"required" format:"float" minimum:"0.0"`
+}
+
+// @Description Часть баланса кошелька
+type BalanceBucket struct {
+	Name   string  `json:"name"   example:"promo" description:"Название части баланса (main, promo)"`
+	Amount float64 `json:"amount" example:"20.0"  description:"Сумма"                               format:"float"`
+}
+
+// @Description Предстоящее сгорание промо-баланса
+type PromoExpiration struct {
+	Amount    float64   `json:"amount"     example:"20.0"                     description:"Сгораемая сумма" format:"float"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-03-05T17:25:35.448Z" description:"Дата сгорания"   format:"date-time"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ReceiptAlgorithm - signature algorithm of the transfer receipts.
+const ReceiptAlgorithm = "ed25519"
+
+// @Description Подписанная квитанция о переводе
+type Receipt struct {
+	TransactionID int64     `json:"transaction_id" example:"1024"                             description:"ID перевода"                   validate:"required"`
+	From          string    `json:"from"           example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID исходящего кошелька"        validate:"required"`
+	To            string    `json:"to"             example:"eb376add88bf8e70f80787266a0801d5" description:"ID входящего кошелька"         validate:"required"`
+	Amount        float64   `json:"amount"         example:"30.0"                             description:"Сумма перевода"                validate:"required" format:"float"`
+	Time          time.Time `json:"time"           example:"2024-02-04T17:25:35.448Z"         description:"Дата и время перевода"         validate:"required" format:"date-time"`
+	PublicKey     string    `json:"public_key"     example:"dGVzdC1wdWJsaWMta2V5LXRlc3QtcHVibGljLWtleSE=" description:"Открытый ключ ed25519 в base64" validate:"required"`
+	Signature     string    `json:"signature"      example:"c2lnbmF0dXJl"                     description:"Подпись ed25519 строки \"receipt:<transaction_id>:<from>:<to>:<amount>:<time>\" в base64" validate:"required"`
+}
+
+// NewReceipt - unsigned receipt of the transaction.
+func NewReceipt(transaction *Transaction) *Receipt {
+	return &Receipt{
+		TransactionID: transaction.ID,
+		From:          transaction.From,
+		To:            transaction.To,
+		Amount:        transaction.Amount,
+		Time:          transaction.Time.UTC(),
+	}
+}
+
+// Message - signed content of the receipt, the amount is in the shortest decimal form and the time is in UTC.
+func (r *Receipt) Message() []byte {
+	return []byte(fmt.Sprintf("receipt:%d:%s:%s:%s:%s",
+		r.TransactionID, r.From, r.To, strconv.FormatFloat(r.Amount, 'f', -1, 64), r.Time.UTC().Format(time.RFC3339Nano)))
+}
+
+// @Description Результат проверки квитанции
+type ReceiptVerification struct {
+	Valid bool `json:"valid" example:"true" description:"Подпись квитанции действительна"`
+}
+
+// @Description Открытый ключ для проверки квитанций
+type ReceiptKey struct {
+	Algorithm string `json:"algorithm"  example:"ed25519"                                      description:"Алгоритм подписи"`
+	PublicKey string `json:"public_key" example:"dGVzdC1wdWJsaWMta2V5LXRlc3QtcHVibGljLWtleSE=" description:"Открытый ключ в base64"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+const (
+	// Balances differing from the recorded movements by less are considered equal, they differ by float rounding errors
+	ReconciliationTolerance = 1e-6
+
+	// Only the first mismatches are stored in the report, all of them are counted
+	MaxReportedMismatches = 1000
+)
+
+// WalletLedger - balance of a wallet together with the sums of its recorded movements.
+type WalletLedger struct {
+	WalletID       string
+	Balance        float64
+	InitialBalance float64
+	Incoming       float64
+	Outgoing       float64
+}
+
+// Expected - balance of the wallet according to its movements.
+func (l WalletLedger) Expected() float64 {
+	return l.InitialBalance + l.Incoming - l.Outgoing
+}
+
+// @Description Результат сверки балансов кошельков с операциями
+type Reconciliation struct {
+	ID                  int64             `json:"id"                    example:"42"                   description:"ID сверки"`
+	StartedAt           time.Time         `json:"started_at"            example:"2024-02-05T03:00:00Z" description:"Время начала сверки"                                   format:"date-time"`
+	FinishedAt          time.Time         `json:"finished_at"           example:"2024-02-05T03:02:15Z" description:"Время окончания сверки"                                format:"date-time"`
+	Wallets             int               `json:"wallets"               example:"1000000"              description:"Количество проверенных кошельков"                                         pg:",use_zero"`
+	Mismatched          int               `json:"mismatched"            example:"1"                    description:"Количество кошельков с расхождениями"                                     pg:",use_zero"`
+	TotalBalance        float64           `json:"total_balance"         example:"100000000.0"          description:"Сумма балансов всех кошельков, включая системные"     format:"float"      pg:",use_zero"`
+	TotalInitialBalance float64           `json:"total_initial_balance" example:"100000000.0"          description:"Сумма начальных балансов всех кошельков"              format:"float"      pg:",use_zero"`
+	Conserved           bool              `json:"conserved"             example:"true"                 description:"Сумма балансов равна сумме начальных балансов"                            pg:",use_zero"`
+	Mismatches          []BalanceMismatch `json:"mismatches"            description:"Кошельки с расхождениями (не более 1000)"                                                                   pg:",type:jsonb"`
+}
+
+// @Description Расхождение баланса кошелька с операциями
+type BalanceMismatch struct {
+	WalletID       string  `json:"wallet_id"       example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	Balance        float64 `json:"balance"         example:"130.0"                            description:"Баланс кошелька"                       format:"float"`
+	InitialBalance float64 `json:"initial_balance" example:"100.0"                            description:"Начальный баланс кошелька"             format:"float"`
+	Incoming       float64 `json:"incoming"        example:"50.0"                             description:"Сумма входящих операций"               format:"float"`
+	Outgoing       float64 `json:"outgoing"        example:"30.0"                             description:"Сумма исходящих операций"              format:"float"`
+	Expected       float64 `json:"expected"        example:"120.0"                            description:"Баланс по операциям"                   format:"float"`
+	Difference     float64 `json:"difference"      example:"10.0"                             description:"Разница баланса и баланса по операциям" format:"float"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+const (
+	// Statement formats
+	StatementFormatJSON = "json"
+	StatementFormatCSV  = "csv"
+	StatementFormatPDF  = "pdf"
+	StatementFormatCamt = "camt053"
+	StatementFormatOFX  = "ofx"
+
+	// Statement rows which are not movements
+	StatementOpeningBalance = "opening_balance"
+	StatementClosingBalance = "closing_balance"
+)
+
+// @Description Выписка по кошельку за период
+type Statement struct {
+	WalletID       string          `json:"wallet_id"       example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька"`
+	From           time.Time       `json:"from"            example:"2024-02-01T00:00:00Z"             description:"Начало периода"                 format:"date-time"`
+	To             time.Time       `json:"to"              example:"2024-03-01T00:00:00Z"             description:"Конец периода (не включительно)" format:"date-time"`
+	Currency       string          `json:"currency"        example:"XXX"                              description:"Код валюты ISO 4217"`
+	GeneratedAt    time.Time       `json:"generated_at"    example:"2024-03-01T00:05:00Z"             description:"Дата и время формирования выписки" format:"date-time"`
+	OpeningBalance float64         `json:"opening_balance" example:"100.0"                            description:"Входящий остаток"               format:"float"`
+	ClosingBalance float64         `json:"closing_balance" example:"70.0"                             description:"Исходящий остаток"              format:"float"`
+	Lines          []StatementLine `json:"lines"                                                      description:"Операции за период"`
+}
+
+// @Description Операция в выписке
+type StatementLine struct {
+	Time              time.Time `json:"time"                         example:"2024-02-04T17:25:35.448Z"         description:"Дата и время операции"                 format:"date-time"`
+	Type              string    `json:"type"                         example:"transfer"                         description:"Тип операции"`
+	Counterparty      string    `json:"counterparty"                 example:"eb376add88bf8e70f80787266a0801d5" description:"ID кошелька контрагента"`
+	Description       string    `json:"description,omitempty"        example:"Оплата по счету №42"              description:"Назначение перевода"`
+	ExternalReference string    `json:"external_reference,omitempty" example:"INV-2024-0042"                    description:"Внешний идентификатор перевода"`
+	Amount            float64   `json:"amount"                       example:"-30.0"                            description:"Сумма, отрицательная для списаний" format:"float"`
+	Balance           float64   `json:"balance"                      example:"70.0"                             description:"Остаток после операции"            format:"float"`
+}
@@ -2,16 +2,59 @@ package entity
 
 import "time"
 
+const (
+	// Transfer memo limits
+	MaxDescriptionLength       = 255
+	MaxExternalReferenceLength = 64
+
+	// Transaction types
+	TransactionTypeTransfer           = "transfer"
+	TransactionTypePromoGrant         = "promo_grant"
+	TransactionTypePromoExpiry        = "promo_expiry"
+	TransactionTypeVoucherIssue       = "voucher_issue"
+	TransactionTypeVoucherRedeem      = "voucher_redeem"
+	TransactionTypeVoucherRefund      = "voucher_refund"
+	TransactionTypeDeposit            = "deposit"
+	TransactionTypeWithdrawal         = "withdrawal"
+	TransactionTypeWithdrawalReversal = "withdrawal_reversal"
+	TransactionTypeInterest           = "interest"
+	TransactionTypeOverdraftInterest  = "overdraft_interest"
+	TransactionTypePocketTransfer     = "pocket_transfer"
+)
+
 // @Description Денежный перевод
 type Transaction struct {
-	Time   time.Time `json:"time"   example:"2024-02-04T17:25:35.448Z"         description:"Дата и время перевода"  validate:"required" format:"date-time"`
-	From   string    `json:"from"   example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID исходящего кошелька" validate:"required" pg:"from_wallet_id"`
-	To     string    `json:"to"     example:"eb376add88bf8e70f80787266a0801d5" description:"ID входящего кошелька"  validate:"required" pg:"to_wallet_id"`
-	Amount float64   `json:"amount" example:"30.0"                             description:"Сумма перевода"         validate:"required" format:"float" minimum:"0.0"`
+	ID                int64     `json:"-"`
+	Time              time.Time `json:"time"                         example:"2024-02-04T17:25:35.448Z"         description:"Дата и время перевода"  validate:"required" format:"date-time"`
+	From              string    `json:"from"                         example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID исходящего кошелька" validate:"required" pg:"from_wallet_id"`
+	To                string    `json:"to"                           example:"eb376add88bf8e70f80787266a0801d5" description:"ID входящего кошелька"  validate:"required" pg:"to_wallet_id"`
+	Amount            float64   `json:"amount"                       example:"30.0"                             description:"Сумма перевода"         validate:"required" format:"float" minimum:"0.0"`
+	Description       string    `json:"description,omitempty"        example:"Оплата по счету №42"              description:"Назначение перевода"                        maxLength:"255"`
+	ExternalReference string    `json:"external_reference,omitempty" example:"INV-2024-0042"                    description:"Внешний идентификатор перевода"             maxLength:"64"`
+	Type              string    `json:"type,omitempty"               example:"transfer"                         description:"Тип операции (transfer, promo_grant, promo_expiry, voucher_issue, voucher_redeem, voucher_refund, deposit, withdrawal, withdrawal_reversal, interest, overdraft_interest, pocket_transfer)"`
+	FromBalanceAfter  float64   `json:"-" pg:",use_zero"`
+	ToBalanceAfter    float64   `json:"-" pg:",use_zero"`
+	PrevHash          string    `json:"-"`
+	Hash              string    `json:"-"`
+	ChainPosition     int64     `json:"-"`
+	// UniqueReference - the transfer is refused with ErrDuplicateReference if the sender has already sent a transfer
+	// with the external reference. The check and the transfer are made under the lock of the sender.
+	UniqueReference bool `json:"-" pg:"-"`
 }
 
 // @Description Запрос перевода средств
 type TransactionRequest struct {
-	To     string  `json:"to"     example:"eb376add88bf8e70f80787266a0801d5" description:"ID кошелька, куда нужно перевести деньги" validate:"required"`
-	Amount float64 `json:"amount" example:"100.0"                            description:"Сумма перевода"                           validate:"required" format:"float" minimum:"0.0"`
-}
\ No newline at end of file
+	To                string  `json:"to"                           example:"eb376add88bf8e70f80787266a0801d5" description:"ID кошелька, куда нужно перевести деньги" validate:"required"`
+	Amount            float64 `json:"amount"                       example:"100.0"                            description:"Сумма перевода"                           validate:"required" format:"float" minimum:"0.0"`
+	Description       string  `json:"description,omitempty"        example:"Оплата по счету №42"              description:"Назначение перевода"                                          maxLength:"255"`
+	ExternalReference string  `json:"external_reference,omitempty" example:"INV-2024-0042"                    description:"Внешний идентификатор перевода"                               maxLength:"64"`
+	UniqueReference   bool    `json:"-"`
+}
+
+// HistoryFilter - optional conditions for selecting wallet history.
+type HistoryFilter struct {
+	ExternalReference string
+	IncludePockets    bool
+	From              time.Time
+	To                time.Time
+}
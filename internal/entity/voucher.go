@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+const (
+	// Voucher batch limits
+	MaxVouchersInBatch = 1000
+
+	// System wallet holding funds of issued but not redeemed vouchers
+	SystemVoucherWalletID = "system-vouchers"
+)
+
+// Voucher - gift code worth a fixed amount. Only a hash of the code is stored.
+type Voucher struct {
+	ID             int64      `json:"id"`
+	BatchID        string     `json:"batch_id"`
+	CodeHash       string     `json:"-"`
+	SourceWalletID string     `json:"source_wallet_id"`
+	Amount         float64    `json:"amount"`
+	MaxRedemptions int        `json:"max_redemptions"`
+	Redemptions    int        `json:"redemptions"           pg:",use_zero"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RefundedAt     *time.Time `json:"refunded_at,omitempty"`
+}
+
+// VoucherRedemption - a wallet which redeemed the voucher.
+type VoucherRedemption struct {
+	VoucherID int64
+	WalletID  string
+	Time      time.Time
+}
+
+// VoucherAttempt - failed attempt to redeem a voucher, used for rate limiting.
+type VoucherAttempt struct {
+	WalletID string
+	Time     time.Time
+}
+
+// @Description Запрос выпуска ваучеров
+type VoucherBatchRequest struct {
+	SourceWalletID string    `json:"source_wallet_id" example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька, за счет которого выпускаются ваучеры" validate:"required"`
+	Amount         float64   `json:"amount"           example:"10.0"                             description:"Номинал ваучера"                                     validate:"required" format:"float" minimum:"0.0"`
+	Count          int       `json:"count"            example:"100"                              description:"Количество ваучеров"                                 validate:"required" minimum:"1" maximum:"1000"`
+	MaxRedemptions int       `json:"max_redemptions"  example:"1"                                description:"Количество активаций одного кода"                    validate:"required" minimum:"1"`
+	ExpiresAt      time.Time `json:"expires_at"       example:"2024-12-31T23:59:59Z"             description:"Дата окончания действия"                             validate:"required" format:"date-time"`
+}
+
+// @Description Выпущенная партия ваучеров
+type VoucherBatch struct {
+	ID             string    `json:"id"               example:"9f86d081884c7d659a2feaa0c55ad015" description:"ID партии"`
+	SourceWalletID string    `json:"source_wallet_id" example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"ID кошелька, за счет которого выпущены ваучеры"`
+	Amount         float64   `json:"amount"           example:"10.0"                             description:"Номинал ваучера"                    format:"float"`
+	MaxRedemptions int       `json:"max_redemptions"  example:"1"                                description:"Количество активаций одного кода"`
+	ExpiresAt      time.Time `json:"expires_at"       example:"2024-12-31T23:59:59Z"             description:"Дата окончания действия"            format:"date-time"`
+	Codes          []string  `json:"codes"            example:"K7QF-9M2X-PLT4-ZC8N"              description:"Коды ваучеров, показываются только при выпуске"`
+}
+
+// @Description Запрос активации ваучера
+type RedeemRequest struct {
+	Code string `json:"code" example:"K7QF-9M2X-PLT4-ZC8N" description:"Код ваучера" validate:"required"`
+}
@@ -1,7 +1,52 @@
 package entity
 
+import "time"
+
+const (
+	// Wallet types
+	WalletTypeStandard = "standard"
+	WalletTypeSavings  = "savings"
+
+	// Wallet statuses
+	WalletStatusActive = "active"
+	WalletStatusFrozen = "frozen"
+	WalletStatusClosed = "closed"
+)
+
 // @Description Состояние кошелька
 type Wallet struct {
-	ID      string  `json:"id"       example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"Уникальный ID кошелька" validate:"required"`
-	Balance float64 `json:"balance"  example:"100.0"                            description:"Баланс кошелька"        validate:"required" minimum:"0.0" format:"float"`
-}
\ No newline at end of file
+	ID              string            `json:"id"                         example:"5b53700ed469fa6a09ea72bb78f36fd9" description:"Уникальный ID кошелька"                                 validate:"required"`
+	Balance         float64           `json:"balance"                    example:"100.0"                            description:"Баланс кошелька"                                        validate:"required" format:"float"`
+	Type            string            `json:"type,omitempty"             example:"savings"                          description:"Тип кошелька (standard, savings)"`
+	AnnualRate      float64           `json:"annual_rate,omitempty"      example:"0.05"                             description:"Годовая процентная ставка сберегательного кошелька"                         format:"float"`
+	CreditLimit     float64           `json:"credit_limit,omitempty"     example:"500.0"                            description:"Кредитный лимит, баланс может опускаться до -credit_limit"                  format:"float"`
+	AvailableCredit *float64          `json:"available_credit,omitempty" example:"350.0"                            description:"Доступный остаток кредитного лимита"                                        format:"float" pg:"-"`
+	InterestDebt    float64           `json:"interest_debt,omitempty"    example:"12.5"                             description:"Проценты за овердрафт, не списанные сверх кредитного лимита"              format:"float"`
+	Status          string            `json:"status,omitempty"           example:"active"                           description:"Статус кошелька (active, frozen, closed)"`
+	ParentID        string            `json:"parent_id,omitempty"        example:"eb376add88bf8e70f80787266a0801d5" description:"ID родительского кошелька, если кошелек является копилкой"`
+	Name            string            `json:"name,omitempty"             example:"Отпуск"                           description:"Название копилки"`
+	TotalBalance    *float64          `json:"total_balance,omitempty"    example:"250.0"                            description:"Баланс кошелька вместе с копилками"                                        format:"float" pg:"-"`
+	InitialBalance  float64           `json:"-" pg:",use_zero"`
+	IsSystem        bool              `json:"-"`
+	Version         int64             `json:"-"`
+	EventSequence   int64             `json:"-"`
+	CreatedAt       time.Time         `json:"-"`
+	Buckets         []BalanceBucket   `json:"buckets,omitempty"          description:"Разбивка баланса по частям"                                                                                                 pg:"-"`
+	Expirations     []PromoExpiration `json:"expirations,omitempty"      description:"Предстоящие сгорания промо-баланса"                                                                                         pg:"-"`
+	Pockets         []Wallet          `json:"pockets,omitempty"          description:"Копилки кошелька"                                                                                                           pg:"-"`
+}
+
+// @Description Запрос создания кошелька
+type CreateWalletRequest struct {
+	Type string `json:"type" example:"savings" description:"Тип кошелька (standard, savings), по умолчанию standard"`
+}
+
+// @Description Запрос изменения кредитного лимита
+type CreditLimitRequest struct {
+	CreditLimit float64 `json:"credit_limit" example:"500.0" description:"Кредитный лимит" format:"float" minimum:"0.0"`
+}
+
+// @Description Запрос изменения статуса кошелька
+type WalletStatusRequest struct {
+	Status string `json:"status" example:"frozen" description:"Новый статус кошелька (active, frozen, closed)"`
+}
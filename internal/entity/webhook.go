@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+const (
+	// Webhook delivery statuses
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+
+	// Webhook limits
+	MaxWebhookURLLength = 2048
+)
+
+// @Description Подписка внешней системы на события кошелька
+type Webhook struct {
+	ID         int64     `json:"id"               example:"1"                                  description:"ID подписки"`
+	WalletID   string    `json:"wallet_id"        example:"5b53700ed469fa6a09ea72bb78f36fd9"   description:"ID кошелька"`
+	URL        string    `json:"url"              example:"https://partner.example/hooks/wallet" description:"Адрес, на который отправляются события"`
+	EventTypes []string  `json:"event_types"      example:"incoming_transfer"                  description:"Типы событий (incoming_transfer, outgoing_transfer, balance_changed)" pg:",array"`
+	Secret     string    `json:"secret,omitempty" example:"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e"   description:"Секрет для проверки подписи, возвращается только при создании"`
+	CreatedAt  time.Time `json:"created_at"       example:"2024-02-04T17:25:35.448Z"           description:"Дата создания"                                                      format:"date-time"`
+}
+
+// @Description Запрос создания подписки на события кошелька
+type WebhookRequest struct {
+	WalletID   string   `json:"wallet_id"   example:"5b53700ed469fa6a09ea72bb78f36fd9"   description:"ID кошелька"                                  validate:"required"`
+	URL        string   `json:"url"         example:"https://partner.example/hooks/wallet" description:"Адрес, на который отправляются события"      validate:"required"`
+	EventTypes []string `json:"event_types" example:"incoming_transfer"                  description:"Типы событий, по умолчанию все"`
+	Secret     string   `json:"secret"      example:"3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e"   description:"Секрет для подписи, по умолчанию генерируется"`
+}
+
+// @Description Доставка события по подписке
+type WebhookDelivery struct {
+	ID             int64      `json:"id"                         example:"7"                                 description:"ID доставки"`
+	WebhookID      int64      `json:"webhook_id"                 example:"1"                                 description:"ID подписки"`
+	EventID        int64      `json:"event_id"                   example:"42"                                description:"ID события кошелька"`
+	EventType      string     `json:"event_type"                 example:"incoming_transfer"                 description:"Тип события"`
+	Payload        string     `json:"payload"                    example:"{\"id\":42}"                       description:"Тело запроса"`
+	Status         string     `json:"status"                     example:"pending"                           description:"Статус доставки (pending, delivered, failed)"`
+	Attempts       int        `json:"attempts"                   example:"1"                                 description:"Количество попыток"                                 pg:",use_zero"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"            example:"2024-02-04T17:25:35.448Z"          description:"Время следующей попытки"                            format:"date-time"`
+	LastStatusCode int        `json:"last_status_code,omitempty" example:"503"                               description:"Код ответа последней попытки"                       pg:",use_zero"`
+	LastError      string     `json:"last_error,omitempty"       example:"unexpected status 503"             description:"Ошибка последней попытки"                           pg:",use_zero"`
+	CreatedAt      time.Time  `json:"created_at"                 example:"2024-02-04T17:25:35.448Z"          description:"Дата создания"                                      format:"date-time"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"     example:"2024-02-04T17:25:36.448Z"          description:"Дата доставки"                                      format:"date-time"`
+	Webhook        *Webhook   `json:"-"                          pg:"rel:has-one"`
+}
@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// SignatureHeader - header with HMAC-SHA256 signature of the notification body.
+const SignatureHeader = "X-Gateway-Signature"
+
+// FakeGateway - offline payment gateway for tests and development.
+// Every payment is confirmed after a delay with a signed notification sent to the callback URL.
+type FakeGateway struct {
+	secret       []byte
+	callbackURL  string
+	delay        time.Duration
+	declineAbove float64
+	client       *http.Client
+	l            logger.Interface
+}
+
+// NewFake - payments with amount above declineAbove are reported as failed, zero disables declining.
+func NewFake(secret string, callbackURL string, delay time.Duration, declineAbove float64, l logger.Interface) *FakeGateway {
+	return &FakeGateway{
+		secret:       []byte(secret),
+		callbackURL:  callbackURL,
+		delay:        delay,
+		declineAbove: declineAbove,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		l:            l,
+	}
+}
+
+// Deposit - accepting a top-up.
+func (g *FakeGateway) Deposit(ctx context.Context, payment entity.Payment) (string, error) {
+	return g.accept(payment), nil
+}
+
+// Withdraw - accepting a payout.
+func (g *FakeGateway) Withdraw(ctx context.Context, payment entity.Payment) (string, error) {
+	return g.accept(payment), nil
+}
+
+// ParseNotification - checking the signature and decoding the notification.
+func (g *FakeGateway) ParseNotification(payload []byte, signature string) (*entity.PaymentNotification, error) {
+	provided, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(provided, g.sign(payload)) {
+		return nil, entity.ErrWrongSignature
+	}
+
+	notification := new(entity.PaymentNotification)
+	if err := json.Unmarshal(payload, notification); err != nil {
+		return nil, fmt.Errorf("FakeGateway - ParseNotification - json.Unmarshal: %w", err)
+	}
+
+	return notification, nil
+}
+
+// accept - scheduling the notification about the payment result.
+func (g *FakeGateway) accept(payment entity.Payment) string {
+	notification := entity.PaymentNotification{
+		PaymentID:        payment.ID,
+		GatewayReference: "fake-" + payment.ID,
+		Status:           entity.PaymentStatusSucceeded,
+	}
+	if g.declineAbove > 0 && payment.Amount > g.declineAbove {
+		notification.Status = entity.PaymentStatusFailed
+	}
+
+	time.AfterFunc(g.delay, func() {
+		if err := g.notify(notification); err != nil {
+			g.l.Error(fmt.Errorf("FakeGateway - accept - g.notify: %w", err))
+		}
+	})
+
+	return notification.GatewayReference
+}
+
+// notify - sending the signed notification to the callback URL.
+func (g *FakeGateway) notify(notification entity.PaymentNotification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, g.callbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, hex.EncodeToString(g.sign(payload)))
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("callback responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign - calculating HMAC-SHA256 of the payload.
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
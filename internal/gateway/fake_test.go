@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+type callback struct {
+	payload   []byte
+	signature string
+}
+
+func newCallbackServer(t *testing.T) (*httptest.Server, chan callback) {
+	callbacks := make(chan callback, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		callbacks <- callback{payload, r.Header.Get(SignatureHeader)}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, callbacks
+}
+
+func Test_FakeGateway(t *testing.T) {
+	tests := []struct {
+		name           string
+		payment        entity.Payment
+		expectedStatus string
+	}{
+		{
+			name: "Deposit succeeded",
+			payment: entity.Payment{
+				ID:     "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e",
+				Type:   entity.PaymentTypeDeposit,
+				Amount: 50.0,
+			},
+			expectedStatus: entity.PaymentStatusSucceeded,
+		},
+		{
+			name: "Withdrawal declined",
+			payment: entity.Payment{
+				ID:     "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e",
+				Type:   entity.PaymentTypeWithdrawal,
+				Amount: 5000.0,
+			},
+			expectedStatus: entity.PaymentStatusFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, callbacks := newCallbackServer(t)
+			g := NewFake("secret", server.URL, time.Millisecond, 1000.0, logger.New(""))
+
+			send := g.Deposit
+			if test.payment.Type == entity.PaymentTypeWithdrawal {
+				send = g.Withdraw
+			}
+			reference, err := send(context.Background(), test.payment)
+			require.NoError(t, err)
+
+			select {
+			case cb := <-callbacks:
+				notification, err := g.ParseNotification(cb.payload, cb.signature)
+				require.NoError(t, err)
+				require.Equal(t, test.payment.ID, notification.PaymentID)
+				require.Equal(t, reference, notification.GatewayReference)
+				require.Equal(t, test.expectedStatus, notification.Status)
+
+				// Tampered payload must be rejected
+				_, err = g.ParseNotification(append(cb.payload, ' '), cb.signature)
+				require.ErrorIs(t, err, entity.ErrWrongSignature)
+			case <-time.After(5 * time.Second):
+				t.Fatal("notification was not sent")
+			}
+		})
+	}
+}
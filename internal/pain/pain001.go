@@ -0,0 +1,134 @@
+// Package pain parses ISO 20022 customer credit transfer initiation (pain.001) files.
+package pain
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// Elements are matched by local names, so pain.001.001.03 and later versions are accepted.
+type document struct {
+	XMLName    xml.Name `xml:"Document"`
+	Initiation *struct {
+		GrpHdr struct {
+			MsgId   string `xml:"MsgId"`
+			NbOfTxs string `xml:"NbOfTxs"`
+			CtrlSum string `xml:"CtrlSum"`
+		} `xml:"GrpHdr"`
+		PmtInf []paymentInformation `xml:"PmtInf"`
+	} `xml:"CstmrCdtTrfInitn"`
+}
+
+type paymentInformation struct {
+	PmtInfId    string           `xml:"PmtInfId"`
+	NbOfTxs     string           `xml:"NbOfTxs"`
+	CtrlSum     string           `xml:"CtrlSum"`
+	DbtrAcct    string           `xml:"DbtrAcct>Id>Othr>Id"`
+	CdtTrfTxInf []creditTransfer `xml:"CdtTrfTxInf"`
+}
+
+type creditTransfer struct {
+	InstrId    string `xml:"PmtId>InstrId"`
+	EndToEndId string `xml:"PmtId>EndToEndId"`
+	InstdAmt   struct {
+		Ccy   string `xml:"Ccy,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"Amt>InstdAmt"`
+	CdtrAcct string   `xml:"CdtrAcct>Id>Othr>Id"`
+	Ustrd    []string `xml:"RmtInf>Ustrd"`
+}
+
+// Parse - parsing a pain.001 file. The file is rejected as a whole when it is malformed or when the number of
+// transactions or the control sum of the group header or a payment information block doesn't match its transfers.
+// Accounts are expected in the other identification of the debtor and creditor accounts.
+func Parse(r io.Reader) (*entity.BulkPayment, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrWrongPaymentFile, err)
+	}
+	if doc.Initiation == nil || doc.Initiation.GrpHdr.MsgId == "" {
+		return nil, fmt.Errorf("%w: customer credit transfer initiation is expected", entity.ErrWrongPaymentFile)
+	}
+
+	payment := &entity.BulkPayment{
+		MessageID: doc.Initiation.GrpHdr.MsgId,
+	}
+	total := new(big.Rat)
+	for _, info := range doc.Initiation.PmtInf {
+		sum := new(big.Rat)
+		for _, transfer := range info.CdtTrfTxInf {
+			amount, ok := parseDecimal(transfer.InstdAmt.Value)
+			if !ok {
+				return nil, fmt.Errorf("%w: wrong amount %q of %s", entity.ErrWrongPaymentFile, transfer.InstdAmt.Value, transfer.EndToEndId)
+			}
+			sum.Add(sum, amount)
+			value, _ := amount.Float64()
+
+			payment.Instructions = append(payment.Instructions, entity.BulkInstruction{
+				PaymentInfoID: info.PmtInfId,
+				InstructionID: transfer.InstrId,
+				EndToEndID:    transfer.EndToEndId,
+				From:          strings.TrimSpace(info.DbtrAcct),
+				To:            strings.TrimSpace(transfer.CdtrAcct),
+				Amount:        value,
+				Currency:      transfer.InstdAmt.Ccy,
+				Description:   strings.Join(transfer.Ustrd, " "),
+			})
+		}
+
+		if err := checkTotals(info.NbOfTxs, info.CtrlSum, len(info.CdtTrfTxInf), sum); err != nil {
+			return nil, fmt.Errorf("payment information %s: %w", info.PmtInfId, err)
+		}
+		total.Add(total, sum)
+	}
+
+	// The number of transactions is mandatory in the group header
+	if doc.Initiation.GrpHdr.NbOfTxs == "" {
+		return nil, fmt.Errorf("%w: number of transactions is missing", entity.ErrWrongPaymentFile)
+	}
+	if err := checkTotals(doc.Initiation.GrpHdr.NbOfTxs, doc.Initiation.GrpHdr.CtrlSum, len(payment.Instructions), total); err != nil {
+		return nil, fmt.Errorf("group header: %w", err)
+	}
+
+	return payment, nil
+}
+
+// checkTotals - comparing the optional number of transactions and control sum with the actual ones,
+// the control sum is compared exactly without float rounding.
+func checkTotals(nbOfTxs string, ctrlSum string, count int, sum *big.Rat) error {
+	if nbOfTxs != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(nbOfTxs))
+		if err != nil {
+			return fmt.Errorf("%w: wrong number of transactions %q", entity.ErrWrongPaymentFile, nbOfTxs)
+		}
+		if n != count {
+			return fmt.Errorf("%w: %d declared, %d found", entity.ErrNumberOfTxsMismatch, n, count)
+		}
+	}
+	if ctrlSum != "" {
+		declared, ok := parseDecimal(ctrlSum)
+		if !ok {
+			return fmt.Errorf("%w: wrong control sum %q", entity.ErrWrongPaymentFile, ctrlSum)
+		}
+		if declared.Cmp(sum) != 0 {
+			return fmt.Errorf("%w: %s declared, %s found", entity.ErrControlSumMismatch, ctrlSum, sum.FloatString(2))
+		}
+	}
+
+	return nil
+}
+
+// parseDecimal - parsing an ISO 20022 decimal number, fractions and exponents are not allowed.
+func parseDecimal(s string) (*big.Rat, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, "/eE") {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
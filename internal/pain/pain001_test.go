@@ -0,0 +1,118 @@
+package pain
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+func readTestFile(t *testing.T) string {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/pain001.xml")
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestParse(t *testing.T) {
+	payment, err := Parse(strings.NewReader(readTestFile(t)))
+	require.NoError(t, err)
+
+	require.Equal(t, "PAYOUTS-2024-02-29", payment.MessageID)
+	require.Equal(t, []entity.BulkInstruction{
+		{
+			PaymentInfoID: "BATCH-1",
+			InstructionID: "1",
+			EndToEndID:    "INV-2024-0042",
+			From:          "5b53700ed469fa6a09ea72bb78f36fd9",
+			To:            "eb376add88bf8e70f80787266a0801d5",
+			Amount:        100.25,
+			Currency:      "XXX",
+			Description:   "Оплата по счету №42",
+		},
+		{
+			PaymentInfoID: "BATCH-1",
+			EndToEndID:    entity.EndToEndNotProvided,
+			From:          "5b53700ed469fa6a09ea72bb78f36fd9",
+			To:            "0e8cf3a4f1ad4c4f9d0e3e4b2a7c9d11",
+			Amount:        30.5,
+			Currency:      "XXX",
+		},
+		{
+			PaymentInfoID: "BATCH-2",
+			InstructionID: "3",
+			EndToEndID:    "SALARY-02",
+			From:          "eb376add88bf8e70f80787266a0801d5",
+			To:            "5b53700ed469fa6a09ea72bb78f36fd9",
+			Amount:        50,
+			Currency:      "XXX",
+			Description:   "Зарплата за февраль",
+		},
+	}, payment.Instructions)
+}
+
+func TestParseRejectsFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		old         string
+		new         string
+		expectedErr error
+	}{
+		{
+			name:        "Group control sum mismatch",
+			old:         "<CtrlSum>180.75</CtrlSum>",
+			new:         "<CtrlSum>180.76</CtrlSum>",
+			expectedErr: entity.ErrControlSumMismatch,
+		},
+		{
+			name:        "Payment information control sum mismatch",
+			old:         "<CtrlSum>130.75</CtrlSum>",
+			new:         "<CtrlSum>130.7</CtrlSum>",
+			expectedErr: entity.ErrControlSumMismatch,
+		},
+		{
+			name:        "Number of transactions mismatch",
+			old:         "<NbOfTxs>3</NbOfTxs>",
+			new:         "<NbOfTxs>4</NbOfTxs>",
+			expectedErr: entity.ErrNumberOfTxsMismatch,
+		},
+		{
+			name:        "Missing number of transactions",
+			old:         "<NbOfTxs>3</NbOfTxs>",
+			new:         "",
+			expectedErr: entity.ErrWrongPaymentFile,
+		},
+		{
+			name:        "Wrong amount",
+			old:         `<InstdAmt Ccy="XXX">50</InstdAmt>`,
+			new:         `<InstdAmt Ccy="XXX">5e1</InstdAmt>`,
+			expectedErr: entity.ErrWrongPaymentFile,
+		},
+		{
+			name:        "Not a credit transfer initiation",
+			old:         "CstmrCdtTrfInitn",
+			new:         "CstmrPmtStsRpt",
+			expectedErr: entity.ErrWrongPaymentFile,
+		},
+		{
+			name:        "Malformed XML",
+			old:         "</Document>",
+			new:         "",
+			expectedErr: entity.ErrWrongPaymentFile,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := strings.Replace(readTestFile(t), test.old, test.new, 1)
+
+			_, err := Parse(strings.NewReader(file))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
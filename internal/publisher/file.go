@@ -0,0 +1,52 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// File - publisher appending the events to a JSON Lines file, one event per line.
+type File struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFile - the file is created if it doesn't exist.
+func NewFile(path string) (*File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("File - NewFile - os.OpenFile: %w", err)
+	}
+
+	return &File{file: file}, nil
+}
+
+// Publish - appending the event and flushing it to the disk, so it isn't lost after it is marked as published.
+func (f *File) Publish(ctx context.Context, event entity.DomainEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("File - Publish - json.Marshal: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("File - Publish - f.file.Write: %w", err)
+	}
+	if err := f.file.Sync(); err != nil {
+		return fmt.Errorf("File - Publish - f.file.Sync: %w", err)
+	}
+
+	return nil
+}
+
+// Close -.
+func (f *File) Close() error {
+	return f.file.Close()
+}
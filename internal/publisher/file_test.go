@@ -0,0 +1,45 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+func Test_FilePublish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	events := []entity.DomainEvent{
+		{ID: 1, Type: entity.DomainEventWalletCreated, AggregateID: "5b53700ed469fa6a09ea72bb78f36fd9", Payload: json.RawMessage(`{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9"}`)},
+		{ID: 2, Type: entity.DomainEventFundsTransferred, AggregateID: "5b53700ed469fa6a09ea72bb78f36fd9", Payload: json.RawMessage(`{"amount":30}`)},
+	}
+
+	// Events are appended to the existing file after a restart
+	for _, event := range events {
+		publisher, err := NewFile(path)
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(context.Background(), event))
+		require.NoError(t, publisher.Close())
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	published := make([]entity.DomainEvent, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event entity.DomainEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		published = append(published, event)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, events, published)
+}
@@ -0,0 +1,40 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// Memory - publisher keeping the events in memory, for tests and local use.
+type Memory struct {
+	mu     sync.Mutex
+	events []entity.DomainEvent
+}
+
+// NewMemory -.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Publish - storing the event.
+func (m *Memory) Publish(ctx context.Context, event entity.DomainEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, event)
+
+	return nil
+}
+
+// Events - getting the published events in order of publishing.
+func (m *Memory) Events() []entity.DomainEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := make([]entity.DomainEvent, len(m.events))
+	copy(events, m.events)
+
+	return events
+}
@@ -0,0 +1,19 @@
+//go:build integration
+
+package eventstore
+
+import (
+	"testing"
+
+	repo "github.com/egor-denisov/wallet-infotecs/internal/repository/postgres"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+)
+
+func Test_WalletRepoConformance(t *testing.T) {
+	pg := repotest.Postgres(t)
+
+	repotest.WalletRepo(t, func(t *testing.T) (usecase.WalletRepo, usecase.OutboxRepo) {
+		return NewWalletRepo(pg, 2), repo.NewOutboxRepo(pg)
+	})
+}
@@ -0,0 +1,119 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// balanceRow - current state of the wallet in the balance projection.
+type balanceRow struct {
+	tableName struct{} `pg:"wallet_balance_projection"`
+
+	ID          string `pg:",pk"`
+	Type        string
+	Status      string
+	Balance     float64 `pg:",use_zero"`
+	CreditLimit float64 `pg:",use_zero"`
+	AnnualRate  float64 `pg:",use_zero"`
+	CreatedAt   time.Time
+	Version     int64
+}
+
+// historyRow - transfer in the history projection, it is keyed by the position of the FundsWithdrawn event.
+type historyRow struct {
+	tableName struct{} `pg:"wallet_history_projection"`
+
+	Position          int64 `pg:",pk"`
+	Time              time.Time
+	From              string `pg:"from_wallet_id"`
+	To                string `pg:"to_wallet_id"`
+	Amount            float64
+	Description       string
+	ExternalReference string
+	Type              string
+}
+
+// project - bringing the projections of the wallet up to date with its appended events,
+// the state of the wallet must already include them.
+func project(tx *pg.Tx, wallet *Wallet, events []Event) error {
+	// Each transfer is recorded in both streams, the history takes it from the sender
+	history := make([]historyRow, 0)
+	for _, event := range events {
+		if event.Type != EventFundsWithdrawn {
+			continue
+		}
+
+		var data FundsMoved
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		history = append(history, historyRow{
+			Position:          event.Position,
+			Time:              data.Time,
+			From:              wallet.ID,
+			To:                data.Counterparty,
+			Amount:            data.Amount,
+			Description:       data.Description,
+			ExternalReference: data.ExternalReference,
+			Type:              data.TransactionType,
+		})
+	}
+	if len(history) > 0 {
+		_, err := tx.Model(&history).
+			OnConflict("DO NOTHING").
+			Insert()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Model(&balanceRow{
+		ID:          wallet.ID,
+		Type:        wallet.Type,
+		Status:      wallet.Status,
+		Balance:     wallet.Balance,
+		CreditLimit: wallet.CreditLimit,
+		AnnualRate:  wallet.AnnualRate,
+		CreatedAt:   wallet.CreatedAt,
+		Version:     wallet.Version,
+	}).
+		OnConflict("(id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("balance = EXCLUDED.balance").
+		Set("credit_limit = EXCLUDED.credit_limit").
+		Set("version = EXCLUDED.version").
+		Insert()
+
+	return err
+}
+
+// wallet - the wallet as it is returned by the use cases.
+func (b *balanceRow) wallet() *entity.Wallet {
+	return &entity.Wallet{
+		ID:          b.ID,
+		Balance:     b.Balance,
+		Type:        b.Type,
+		AnnualRate:  b.AnnualRate,
+		CreditLimit: b.CreditLimit,
+		Status:      b.Status,
+		CreatedAt:   b.CreatedAt,
+		Version:     b.Version,
+	}
+}
+
+// transaction - the transfer as it is returned by the use cases.
+func (h *historyRow) transaction() entity.Transaction {
+	return entity.Transaction{
+		Time:              h.Time,
+		From:              h.From,
+		To:                h.To,
+		Amount:            h.Amount,
+		Description:       h.Description,
+		ExternalReference: h.ExternalReference,
+		Type:              h.Type,
+	}
+}
@@ -0,0 +1,155 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// appendAttempts - number of attempts of a change before a version conflict is returned to the caller.
+const appendAttempts = 3
+
+// snapshot - saved state of the wallet, the stream is replayed from its version.
+type snapshot struct {
+	tableName struct{} `pg:"wallet_stream_snapshots"`
+
+	StreamID  string `pg:",pk"`
+	Version   int64
+	State     json.RawMessage
+	CreatedAt time.Time
+}
+
+// load - rebuilding the wallet from its latest snapshot and the later events of the stream.
+func load(db orm.DB, walletId string) (*Wallet, error) {
+	wallet := &Wallet{ID: walletId}
+
+	saved := new(snapshot)
+	err := db.Model(saved).
+		Where("stream_id = ?", walletId).
+		Select()
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(saved.State, wallet); err != nil {
+			return nil, err
+		}
+		wallet.snapshotVersion = wallet.Version
+	case !errors.Is(err, pg.ErrNoRows):
+		return nil, err
+	}
+
+	events := make([]Event, 0)
+	err = db.Model(&events).
+		Where("stream_id = ?", walletId).
+		Where("version > ?", wallet.Version).
+		Order("version ASC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if err := wallet.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	// An empty stream means the wallet was never opened
+	if wallet.Version == 0 {
+		return nil, entity.ErrWalletNotFound
+	}
+
+	return wallet, nil
+}
+
+// commit - appending the recorded events of the wallets, updating the projections and saving snapshots of long streams.
+// Streams are appended in order of their ids, so concurrent transfers between the same wallets don't deadlock.
+// If another transaction has already appended an event with the same version, ErrVersionConflict is returned.
+func commit(tx *pg.Tx, snapshotEvery int, wallets ...*Wallet) error {
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].ID < wallets[j].ID
+	})
+
+	for _, wallet := range wallets {
+		if len(wallet.changes) == 0 {
+			continue
+		}
+
+		res, err := tx.Model(&wallet.changes).
+			OnConflict("DO NOTHING").
+			Returning("position").
+			Insert()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() != len(wallet.changes) {
+			return entity.ErrVersionConflict
+		}
+	}
+
+	for _, wallet := range wallets {
+		if err := project(tx, wallet, wallet.changes); err != nil {
+			return err
+		}
+		if snapshotEvery > 0 && wallet.Version-wallet.snapshotVersion >= int64(snapshotEvery) {
+			if err := saveSnapshot(tx, wallet); err != nil {
+				return err
+			}
+		}
+		wallet.changes = nil
+	}
+
+	return nil
+}
+
+// saveSnapshot - replacing the snapshot of the wallet with its current state.
+func saveSnapshot(tx *pg.Tx, wallet *Wallet) error {
+	state, err := json.Marshal(wallet)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Model(&snapshot{
+		StreamID:  wallet.ID,
+		Version:   wallet.Version,
+		State:     state,
+		CreatedAt: time.Now(),
+	}).
+		OnConflict("(stream_id) DO UPDATE").
+		Set("version = EXCLUDED.version").
+		Set("state = EXCLUDED.state").
+		Set("created_at = EXCLUDED.created_at").
+		Insert()
+	if err != nil {
+		return err
+	}
+	wallet.snapshotVersion = wallet.Version
+
+	return nil
+}
+
+// retry - running the change until its events are appended without a version conflict.
+// A conflicting attempt is rolled back to the savepoint, so the next one loads the streams again.
+func retry(tx *pg.Tx, change func() error) error {
+	for attempt := 1; ; attempt++ {
+		if _, err := tx.Exec("SAVEPOINT wallet_streams"); err != nil {
+			return err
+		}
+
+		err := change()
+		if err == nil {
+			_, err = tx.Exec("RELEASE SAVEPOINT wallet_streams")
+			return err
+		}
+		if !errors.Is(err, entity.ErrVersionConflict) || attempt == appendAttempts {
+			return err
+		}
+
+		if _, err := tx.Exec("ROLLBACK TO SAVEPOINT wallet_streams"); err != nil {
+			return err
+		}
+	}
+}
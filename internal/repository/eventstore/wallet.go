@@ -0,0 +1,238 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const (
+	// Event types of the wallet streams
+	EventWalletOpened    = "WalletOpened"
+	EventFundsWithdrawn  = "FundsWithdrawn"
+	EventFundsDeposited  = "FundsDeposited"
+	EventCreditLimitSet  = "CreditLimitSet"
+	EventWalletStatusSet = "WalletStatusSet"
+
+	// amountEpsilon - tolerance for float rounding errors in balance arithmetic.
+	amountEpsilon = 1e-9
+)
+
+// Event - immutable record of a wallet stream. Version is the number of the event in its stream,
+// position is the global order of the events used to rebuild the projections.
+type Event struct {
+	tableName struct{} `pg:"wallet_stream_events"`
+
+	StreamID   string `pg:",pk"`
+	Version    int64  `pg:",pk"`
+	Position   int64
+	Type       string
+	Data       json.RawMessage
+	RecordedAt time.Time
+}
+
+// WalletOpened - data of the WalletOpened event.
+type WalletOpened struct {
+	Type       string  `json:"type"`
+	Status     string  `json:"status"`
+	Balance    float64 `json:"balance"`
+	AnnualRate float64 `json:"annual_rate,omitempty"`
+}
+
+// FundsMoved - data of the FundsWithdrawn and FundsDeposited events.
+type FundsMoved struct {
+	Amount            float64   `json:"amount"`
+	Counterparty      string    `json:"counterparty"`
+	TransactionType   string    `json:"transaction_type"`
+	Description       string    `json:"description,omitempty"`
+	ExternalReference string    `json:"external_reference,omitempty"`
+	Time              time.Time `json:"time"`
+}
+
+// CreditLimitSet - data of the CreditLimitSet event.
+type CreditLimitSet struct {
+	CreditLimit float64 `json:"credit_limit"`
+}
+
+// WalletStatusSet - data of the WalletStatusSet event.
+type WalletStatusSet struct {
+	Status string `json:"status"`
+}
+
+// Wallet - state of the wallet aggregate rebuilt from its stream. Decisions check the state and record new events,
+// which are applied at once, so the state always matches the events to append.
+type Wallet struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Balance     float64   `json:"balance"`
+	CreditLimit float64   `json:"credit_limit"`
+	AnnualRate  float64   `json:"annual_rate"`
+	CreatedAt   time.Time `json:"created_at"`
+	Version     int64     `json:"version"`
+
+	// Version of the snapshot the state was loaded from
+	snapshotVersion int64
+	// Events recorded since the state was loaded
+	changes []Event
+}
+
+// Apply - changing the state by the next event of the stream.
+func (w *Wallet) Apply(event Event) error {
+	switch event.Type {
+	case EventWalletOpened:
+		var data WalletOpened
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		w.ID = event.StreamID
+		w.Type = data.Type
+		w.Status = data.Status
+		w.Balance = data.Balance
+		w.AnnualRate = data.AnnualRate
+		w.CreatedAt = event.RecordedAt
+	case EventFundsWithdrawn, EventFundsDeposited:
+		var data FundsMoved
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		if event.Type == EventFundsWithdrawn {
+			w.Balance -= data.Amount
+		} else {
+			w.Balance += data.Amount
+		}
+	case EventCreditLimitSet:
+		var data CreditLimitSet
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		w.CreditLimit = data.CreditLimit
+	case EventWalletStatusSet:
+		var data WalletStatusSet
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		w.Status = data.Status
+	default:
+		return fmt.Errorf("unknown event type %q", event.Type)
+	}
+
+	w.Version = event.Version
+	return nil
+}
+
+// Open - starting the stream of a new wallet.
+func (w *Wallet) Open(wallet *entity.Wallet) error {
+	return w.record(EventWalletOpened, WalletOpened{
+		Type:       wallet.Type,
+		Status:     wallet.Status,
+		Balance:    wallet.Balance,
+		AnnualRate: wallet.AnnualRate,
+	})
+}
+
+// Withdraw - sending funds of the transaction, the balance can go down to the credit limit.
+func (w *Wallet) Withdraw(transaction *entity.Transaction) error {
+	if err := w.checkActive(); err != nil {
+		return err
+	}
+	if w.Balance-transaction.Amount < -w.CreditLimit-amountEpsilon {
+		return entity.ErrInsufficientFunds
+	}
+
+	return w.record(EventFundsWithdrawn, FundsMoved{
+		Amount:            transaction.Amount,
+		Counterparty:      transaction.To,
+		TransactionType:   transaction.Type,
+		Description:       transaction.Description,
+		ExternalReference: transaction.ExternalReference,
+		Time:              transaction.Time,
+	})
+}
+
+// Deposit - receiving funds of the transaction.
+func (w *Wallet) Deposit(transaction *entity.Transaction) error {
+	if err := w.checkActive(); err != nil {
+		return err
+	}
+
+	return w.record(EventFundsDeposited, FundsMoved{
+		Amount:            transaction.Amount,
+		Counterparty:      transaction.From,
+		TransactionType:   transaction.Type,
+		Description:       transaction.Description,
+		ExternalReference: transaction.ExternalReference,
+		Time:              transaction.Time,
+	})
+}
+
+// SetCreditLimit - changing the credit limit, it can't be lower than the current debt.
+func (w *Wallet) SetCreditLimit(creditLimit float64) error {
+	if w.Balance < -creditLimit {
+		return entity.ErrCreditLimitTooLow
+	}
+
+	return w.record(EventCreditLimitSet, CreditLimitSet{CreditLimit: creditLimit})
+}
+
+// SetStatus - freezing, unfreezing or closing the wallet, only an empty wallet can be closed.
+func (w *Wallet) SetStatus(status string) error {
+	if w.Status == entity.WalletStatusClosed {
+		return entity.ErrWalletClosed
+	}
+	if status == entity.WalletStatusClosed && math.Abs(w.Balance) > amountEpsilon {
+		return entity.ErrWalletNotEmpty
+	}
+
+	return w.record(EventWalletStatusSet, WalletStatusSet{Status: status})
+}
+
+// Entity - the wallet as it is returned by the use cases.
+func (w *Wallet) Entity() *entity.Wallet {
+	return &entity.Wallet{
+		ID:          w.ID,
+		Balance:     w.Balance,
+		Type:        w.Type,
+		AnnualRate:  w.AnnualRate,
+		CreditLimit: w.CreditLimit,
+		Status:      w.Status,
+		CreatedAt:   w.CreatedAt,
+		Version:     w.Version,
+	}
+}
+
+// checkActive - frozen and closed wallets can't take part in transfers.
+func (w *Wallet) checkActive() error {
+	switch w.Status {
+	case entity.WalletStatusFrozen:
+		return entity.ErrWalletFrozen
+	case entity.WalletStatusClosed:
+		return entity.ErrWalletClosed
+	}
+	return nil
+}
+
+// record - applying a new event and keeping it for appending to the stream.
+func (w *Wallet) record(eventType string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		StreamID:   w.ID,
+		Version:    w.Version + 1,
+		Type:       eventType,
+		Data:       encoded,
+		RecordedAt: time.Now(),
+	}
+	if err := w.Apply(event); err != nil {
+		return err
+	}
+	w.changes = append(w.changes, event)
+
+	return nil
+}
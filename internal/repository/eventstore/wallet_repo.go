@@ -0,0 +1,336 @@
+// Package eventstore implements a WalletRepo on top of an append-only event store.
+// Every wallet is a stream of events, the balance and the history are projections of the streams,
+// which are updated in the transaction of the append and can be rebuilt from the events at any time.
+package eventstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// WalletRepo -.
+type WalletRepo struct {
+	*postgres.Postgres
+	snapshotEvery int
+}
+
+// NewWalletRepo - a snapshot of the wallet is saved after each snapshotEvery events of its stream, 0 disables snapshots.
+func NewWalletRepo(pg *postgres.Postgres, snapshotEvery int) *WalletRepo {
+	return &WalletRepo{pg, snapshotEvery}
+}
+
+// CreateNewWallet - opening the stream of a new wallet.
+func (r *WalletRepo) CreateNewWallet(ctx context.Context, wallet *entity.Wallet) (*entity.Wallet, error) {
+	var created *Wallet
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		// A taken id conflicts with the first event of its stream, so the next attempt gets a new one
+		return retry(tx, func() error {
+			id, err := newWalletId()
+			if err != nil {
+				return err
+			}
+
+			created = &Wallet{ID: id}
+			if err := created.Open(wallet); err != nil {
+				return err
+			}
+			return commit(tx, r.snapshotEvery, created)
+		})
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - CreateNewWallet - r.DB: %w", err)
+	}
+	return created.Entity(), nil
+}
+
+// SendFunds - appending the withdrawal to the stream of the sender and the deposit to the stream of the receiver.
+func (r *WalletRepo) SendFunds(ctx context.Context, transaction *entity.Transaction) error {
+	transaction.Time = time.Now()
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return retry(tx, func() error {
+			sender, err := load(tx, transaction.From)
+			if err != nil {
+				return err
+			}
+			// A concurrent transfer from the sender conflicts with the version of its stream, the retry sees it
+			if transaction.UniqueReference {
+				sent, err := tx.Model((*historyRow)(nil)).
+					Where("from_wallet_id = ?", transaction.From).
+					Where("external_reference = ?", transaction.ExternalReference).
+					Where("type = ?", entity.TransactionTypeTransfer).
+					Exists()
+				if err != nil {
+					return err
+				}
+				if sent {
+					return entity.ErrDuplicateReference
+				}
+			}
+			receiver, err := load(tx, transaction.To)
+			if errors.Is(err, entity.ErrWalletNotFound) {
+				return entity.ErrReceiverNotFound
+			}
+			if err != nil {
+				return err
+			}
+			if err := receiver.checkActive(); err != nil {
+				return err
+			}
+
+			if err := sender.Withdraw(transaction); err != nil {
+				return err
+			}
+			if err := receiver.Deposit(transaction); err != nil {
+				return err
+			}
+			return commit(tx, r.snapshotEvery, sender, receiver)
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+	}
+	return nil
+}
+
+// GetWalletHistoryById - getting transfers of the wallet from the history projection, in the transaction of
+// the context if there is one. Wallets of the event store have no pockets, so IncludePockets changes nothing.
+func (r *WalletRepo) GetWalletHistoryById(ctx context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
+	history := make([]historyRow, 0)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		exists, err := tx.Model((*balanceRow)(nil)).
+			Where("id = ?", walletId).
+			Exists()
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entity.ErrWalletNotFound
+		}
+
+		query := tx.Model(&history).
+			WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+				return q.Where("from_wallet_id = ?", walletId).
+					WhereOr("to_wallet_id = ?", walletId), nil
+			})
+		// Searching by external reference if it is specified
+		if filter.ExternalReference != "" {
+			query = query.Where("external_reference = ?", filter.ExternalReference)
+		}
+		// Limiting the period if it is specified
+		if !filter.From.IsZero() {
+			query = query.Where("time >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			query = query.Where("time < ?", filter.To)
+		}
+
+		return query.Order("time ASC", "position ASC").
+			Select()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - r.DB: %w", err)
+	}
+
+	transactions := make([]entity.Transaction, 0, len(history))
+	for i := range history {
+		transactions = append(transactions, history[i].transaction())
+	}
+	return transactions, nil
+}
+
+// GetWalletById - getting wallet info from the balance projection, in the transaction of the context if there is one.
+func (r *WalletRepo) GetWalletById(ctx context.Context, walletId string) (*entity.Wallet, error) {
+	row := new(balanceRow)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(row).
+			Where("id = ?", walletId).
+			Select()
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, fmt.Errorf("WalletRepo - GetWalletById - r.DB: %w", entity.ErrWalletNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletById - r.DB: %w", err)
+	}
+	return row.wallet(), nil
+}
+
+// SetCreditLimit - appending the new credit limit to the stream of the wallet.
+func (r *WalletRepo) SetCreditLimit(ctx context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	wallet, err := r.change(ctx, walletId, func(wallet *Wallet) error {
+		return wallet.SetCreditLimit(creditLimit)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - SetCreditLimit - r.DB: %w", err)
+	}
+	return wallet.Entity(), nil
+}
+
+// GetPromoGrants - wallets of the event store don't get promo grants.
+func (r *WalletRepo) GetPromoGrants(ctx context.Context, walletId string) ([]entity.PromoGrant, error) {
+	return make([]entity.PromoGrant, 0), nil
+}
+
+// SetWalletStatus - appending the new status to the stream of the wallet.
+func (r *WalletRepo) SetWalletStatus(ctx context.Context, walletId string, status string) (*entity.Wallet, error) {
+	wallet, err := r.change(ctx, walletId, func(wallet *Wallet) error {
+		return wallet.SetStatus(status)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - SetWalletStatus - r.DB: %w", err)
+	}
+	return wallet.Entity(), nil
+}
+
+// GetPockets - wallets of the event store have no pockets.
+func (r *WalletRepo) GetPockets(ctx context.Context, walletId string) ([]entity.Wallet, error) {
+	return make([]entity.Wallet, 0), nil
+}
+
+// GetWalletVersion - getting the version of the stream from the balance projection. The projection row is locked
+// till the end of the transaction, so appends to the stream wait for it.
+func (r *WalletRepo) GetWalletVersion(ctx context.Context, walletId string) (int64, error) {
+	row := new(balanceRow)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(row).
+			Column("version").
+			Where("id = ?", walletId).
+			For("UPDATE").
+			Select()
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return 0, fmt.Errorf("WalletRepo - GetWalletVersion - r.DB: %w", entity.ErrWalletNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - GetWalletVersion - r.DB: %w", err)
+	}
+	return row.Version, nil
+}
+
+// GetBalanceAt - getting the balance of the wallet at the moment, it is the projected balance without later transfers.
+// Read in the transaction of the context if there is one.
+func (r *WalletRepo) GetBalanceAt(ctx context.Context, walletId string, at time.Time) (float64, error) {
+	var balance float64
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.QueryOneContext(ctx, pg.Scan(&balance), `
+			SELECT w.balance - COALESCE(SUM(CASE WHEN h.to_wallet_id = w.id THEN h.amount ELSE -h.amount END), 0)
+			FROM wallet_balance_projection AS w
+			LEFT JOIN wallet_history_projection AS h ON (h.from_wallet_id = w.id OR h.to_wallet_id = w.id) AND h.time >= ?1
+			WHERE w.id = ?0
+			GROUP BY w.id, w.balance`,
+			walletId, at)
+		return err
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt - r.DB: %w", entity.ErrWalletNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt - r.DB: %w", err)
+	}
+	return balance, nil
+}
+
+// RebuildProjections - replacing the projections and the snapshots with the ones replayed from all the events.
+// Appends wait until the rebuild is committed. Returns the number of the rebuilt wallets.
+func (r *WalletRepo) RebuildProjections(ctx context.Context) (int, error) {
+	rebuilt := 0
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Exec("LOCK TABLE wallet_stream_events IN SHARE MODE")
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("TRUNCATE wallet_balance_projection, wallet_history_projection, wallet_stream_snapshots")
+		if err != nil {
+			return err
+		}
+
+		streams := make([]string, 0)
+		err = tx.Model((*Event)(nil)).
+			ColumnExpr("DISTINCT stream_id").
+			Order("stream_id ASC").
+			Select(&streams)
+		if err != nil {
+			return err
+		}
+
+		for _, streamId := range streams {
+			events := make([]Event, 0)
+			err := tx.Model(&events).
+				Where("stream_id = ?", streamId).
+				Order("version ASC").
+				Select()
+			if err != nil {
+				return err
+			}
+
+			wallet := &Wallet{ID: streamId}
+			for _, event := range events {
+				if err := wallet.Apply(event); err != nil {
+					return err
+				}
+			}
+			if err := project(tx, wallet, events); err != nil {
+				return err
+			}
+			if r.snapshotEvery > 0 && wallet.Version >= int64(r.snapshotEvery) {
+				if err := saveSnapshot(tx, wallet); err != nil {
+					return err
+				}
+			}
+			rebuilt++
+		}
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - RebuildProjections - r.DB: %w", err)
+	}
+	return rebuilt, nil
+}
+
+// change - loading the wallet, making the decision and appending its events.
+func (r *WalletRepo) change(ctx context.Context, walletId string, decide func(wallet *Wallet) error) (*Wallet, error) {
+	var wallet *Wallet
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return retry(tx, func() error {
+			var err error
+			wallet, err = load(tx, walletId)
+			if err != nil {
+				return err
+			}
+			if err := decide(wallet); err != nil {
+				return err
+			}
+			return commit(tx, r.snapshotEvery, wallet)
+		})
+	})
+
+	return wallet, err
+}
+
+// newWalletId - random id in the same format as the ids of the postgres store.
+func newWalletId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,96 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+func openWallet(t *testing.T, id string, balance float64) *Wallet {
+	wallet := &Wallet{ID: id}
+	require.NoError(t, wallet.Open(&entity.Wallet{
+		Type:    entity.WalletTypeStandard,
+		Status:  entity.WalletStatusActive,
+		Balance: balance,
+	}))
+	return wallet
+}
+
+func Test_WalletDecisions(t *testing.T) {
+	sender := openWallet(t, "5b53700ed469fa6a09ea72bb78f36fd9", 100)
+	receiver := openWallet(t, "eb376add88bf8e70f80787266a0801d5", 0)
+
+	transaction := &entity.Transaction{
+		From:   sender.ID,
+		To:     receiver.ID,
+		Amount: 130,
+		Type:   entity.TransactionTypeTransfer,
+	}
+	require.ErrorIs(t, sender.Withdraw(transaction), entity.ErrInsufficientFunds)
+
+	// The credit line covers the rest of the amount
+	require.NoError(t, sender.SetCreditLimit(50))
+	require.NoError(t, sender.Withdraw(transaction))
+	require.NoError(t, receiver.Deposit(transaction))
+	require.Equal(t, -30.0, sender.Balance)
+	require.Equal(t, 130.0, receiver.Balance)
+	require.ErrorIs(t, sender.SetCreditLimit(20), entity.ErrCreditLimitTooLow)
+
+	require.NoError(t, receiver.SetStatus(entity.WalletStatusFrozen))
+	require.ErrorIs(t, receiver.Withdraw(transaction), entity.ErrWalletFrozen)
+	require.ErrorIs(t, receiver.SetStatus(entity.WalletStatusClosed), entity.ErrWalletNotEmpty)
+
+	// Decisions which are rejected record nothing
+	require.Equal(t, []string{EventWalletOpened, EventCreditLimitSet, EventFundsWithdrawn}, eventTypes(sender.changes))
+	require.Equal(t, []string{EventWalletOpened, EventFundsDeposited, EventWalletStatusSet}, eventTypes(receiver.changes))
+	require.Equal(t, int64(3), sender.Version)
+}
+
+func Test_WalletReplay(t *testing.T) {
+	wallet := openWallet(t, "5b53700ed469fa6a09ea72bb78f36fd9", 100)
+	require.NoError(t, wallet.SetCreditLimit(50))
+	require.NoError(t, wallet.Withdraw(&entity.Transaction{To: "eb376add88bf8e70f80787266a0801d5", Amount: 120}))
+	require.NoError(t, wallet.SetStatus(entity.WalletStatusFrozen))
+
+	// Replaying the whole stream
+	replayed := &Wallet{ID: wallet.ID}
+	for _, event := range wallet.changes {
+		require.NoError(t, replayed.Apply(event))
+	}
+	require.Equal(t, wallet.Entity(), replayed.Entity())
+	require.Equal(t, wallet.Version, replayed.Version)
+
+	// Replaying the events after a snapshot
+	state, err := json.Marshal(&Wallet{
+		ID:        wallet.ID,
+		Type:      entity.WalletTypeStandard,
+		Status:    entity.WalletStatusActive,
+		Balance:   100,
+		CreatedAt: wallet.CreatedAt,
+		Version:   1,
+	})
+	require.NoError(t, err)
+
+	restored := new(Wallet)
+	require.NoError(t, json.Unmarshal(state, restored))
+	for _, event := range wallet.changes[restored.Version:] {
+		require.NoError(t, restored.Apply(event))
+	}
+	// The decoded time loses its monotonic reading
+	require.True(t, wallet.CreatedAt.Equal(restored.CreatedAt))
+	restored.CreatedAt = wallet.CreatedAt
+	require.Equal(t, wallet.Entity(), restored.Entity())
+
+	require.Error(t, restored.Apply(Event{StreamID: wallet.ID, Version: 5, Type: "Unknown"}))
+}
+
+func eventTypes(events []Event) []string {
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
@@ -0,0 +1,15 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+)
+
+func Test_WalletRepoConformance(t *testing.T) {
+	repotest.WalletRepo(t, func(t *testing.T) (usecase.WalletRepo, usecase.OutboxRepo) {
+		wallets := NewWalletRepo()
+		return wallets, NewOutboxRepo(wallets)
+	})
+}
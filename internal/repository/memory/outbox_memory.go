@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// txKey - context key of the transaction started by Atomic.
+type txKey struct{}
+
+// snapshotKey - context key of the reads made in ReadSnapshot.
+type snapshotKey struct{}
+
+// inTransaction - whether the call is made in Atomic, which holds the wallets locked.
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(bool)
+	return ok
+}
+
+// inSnapshot - whether the call is made in ReadSnapshot, which holds the wallets locked for reading.
+func inSnapshot(ctx context.Context) bool {
+	_, ok := ctx.Value(snapshotKey{}).(bool)
+	return ok
+}
+
+// OutboxRepo -.
+type OutboxRepo struct {
+	mu      sync.Mutex
+	wallets *WalletRepo
+	events  []entity.DomainEvent
+}
+
+// NewOutboxRepo - the outbox of the events of the wallets, they are changed together in Atomic.
+func NewOutboxRepo(wallets *WalletRepo) *OutboxRepo {
+	return &OutboxRepo{
+		wallets: wallets,
+		events:  make([]entity.DomainEvent, 0),
+	}
+}
+
+// Atomic - running fn with the wallets locked, so the calls made with its context are serialized with other changes.
+// If fn fails, the wallets and the events are restored. Nested calls join the outer transaction.
+func (r *OutboxRepo) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	r.wallets.mu.Lock()
+	defer r.wallets.mu.Unlock()
+
+	restore := r.wallets.snapshot()
+	r.mu.Lock()
+	events := len(r.events)
+	r.mu.Unlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		restore()
+		r.mu.Lock()
+		r.events = r.events[:events]
+		r.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// AddEvents - storing the events in the outbox, they get ids in order of addition.
+func (r *OutboxRepo) AddEvents(ctx context.Context, events ...entity.DomainEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		event.ID = int64(len(r.events) + 1)
+		r.events = append(r.events, event)
+	}
+
+	return nil
+}
+
+// LockUnpublishedEvents - getting the oldest unpublished events.
+func (r *OutboxRepo) LockUnpublishedEvents(ctx context.Context, limit int) ([]entity.DomainEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := make([]entity.DomainEvent, 0)
+	for _, event := range r.events {
+		if len(events) == limit {
+			break
+		}
+		if event.PublishedAt == nil {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// MarkPublished - marking the events as published, they are not relayed again.
+func (r *OutboxRepo) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		if id < 1 || id > int64(len(r.events)) {
+			continue
+		}
+		publishedAt := at
+		r.events[id-1].PublishedAt = &publishedAt
+	}
+
+	return nil
+}
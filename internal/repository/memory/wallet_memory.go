@@ -0,0 +1,378 @@
+// Package memory implements the wallet repositories in the memory of the process, for tests and demos.
+// The data is lost when the process stops.
+package memory
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// amountEpsilon - tolerance for float rounding errors in balance arithmetic.
+const amountEpsilon = 1e-9
+
+// WalletRepo -.
+type WalletRepo struct {
+	mu           sync.RWMutex
+	wallets      map[string]*entity.Wallet
+	transactions []entity.Transaction
+}
+
+// NewWalletRepo -.
+func NewWalletRepo() *WalletRepo {
+	return &WalletRepo{
+		wallets:      make(map[string]*entity.Wallet),
+		transactions: make([]entity.Transaction, 0),
+	}
+}
+
+// CreateNewWallet - storing the wallet under a new unique id.
+func (r *WalletRepo) CreateNewWallet(ctx context.Context, wallet *entity.Wallet) (*entity.Wallet, error) {
+	defer r.lock(ctx)()
+
+	wallet.ID = r.makeUid()
+	if wallet.Status == "" {
+		wallet.Status = entity.WalletStatusActive
+	}
+	if wallet.Type == "" {
+		wallet.Type = entity.WalletTypeStandard
+	}
+	wallet.CreatedAt = time.Now()
+	wallet.Version = 1
+
+	stored := *wallet
+	r.wallets[wallet.ID] = &stored
+
+	return wallet, nil
+}
+
+// SendFunds - decreasing the balance of the sender and an increasing the receiver. Adding an entry to the history.
+// The balance of the sender can't go below its credit limit.
+func (r *WalletRepo) SendFunds(ctx context.Context, transaction *entity.Transaction) error {
+	defer r.lock(ctx)()
+
+	sender, ok := r.wallets[transaction.From]
+	if !ok {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", entity.ErrWalletNotFound)
+	}
+	if err := checkWalletActive(sender); err != nil {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", err)
+	}
+	if transaction.UniqueReference && r.sent(transaction) {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", entity.ErrDuplicateReference)
+	}
+	receiver, ok := r.wallets[transaction.To]
+	if !ok {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", entity.ErrReceiverNotFound)
+	}
+	if err := checkWalletActive(receiver); err != nil {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", err)
+	}
+	// Pockets exchange funds only with their parent wallet
+	if sender.ParentID != "" || receiver.ParentID != "" {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", entity.ErrPocketTransfer)
+	}
+	if sender.Balance-transaction.Amount < -sender.CreditLimit-amountEpsilon {
+		return fmt.Errorf("WalletRepo - SendFunds: %w", entity.ErrInsufficientFunds)
+	}
+
+	sender.Balance -= transaction.Amount
+	sender.Version++
+	receiver.Balance += transaction.Amount
+	receiver.Version++
+
+	transaction.Time = time.Now()
+	if transaction.Type == "" {
+		transaction.Type = entity.TransactionTypeTransfer
+	}
+	r.transactions = append(r.transactions, *transaction)
+
+	return nil
+}
+
+// sent - checking whether the sender has already sent a transfer with the external reference of the transaction.
+func (r *WalletRepo) sent(transaction *entity.Transaction) bool {
+	for _, t := range r.transactions {
+		if t.From == transaction.From && t.ExternalReference == transaction.ExternalReference && t.Type == entity.TransactionTypeTransfer {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWalletHistoryById - getting all transaction records of the wallet in time order.
+func (r *WalletRepo) GetWalletHistoryById(ctx context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
+	defer r.rlock(ctx)()
+
+	if _, ok := r.wallets[walletId]; !ok {
+		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById: %w", entity.ErrWalletNotFound)
+	}
+
+	// Adding movements of the pockets if it is specified
+	ids := map[string]bool{walletId: true}
+	if filter.IncludePockets {
+		for id, wallet := range r.wallets {
+			if wallet.ParentID == walletId {
+				ids[id] = true
+			}
+		}
+	}
+
+	// Transactions are appended under the lock, so they are already in time order
+	transactions := make([]entity.Transaction, 0)
+	for _, transaction := range r.transactions {
+		if !ids[transaction.From] && !ids[transaction.To] {
+			continue
+		}
+		if filter.ExternalReference != "" && transaction.ExternalReference != filter.ExternalReference {
+			continue
+		}
+		if !filter.From.IsZero() && transaction.Time.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !transaction.Time.Before(filter.To) {
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// GetWalletById - getting wallet info by walletId.
+func (r *WalletRepo) GetWalletById(ctx context.Context, walletId string) (*entity.Wallet, error) {
+	defer r.rlock(ctx)()
+
+	wallet, ok := r.wallets[walletId]
+	if !ok {
+		return nil, fmt.Errorf("WalletRepo - GetWalletById: %w", entity.ErrWalletNotFound)
+	}
+
+	found := *wallet
+	return &found, nil
+}
+
+// GetPromoGrants - wallets in memory don't get promo grants.
+func (r *WalletRepo) GetPromoGrants(ctx context.Context, walletId string) ([]entity.PromoGrant, error) {
+	return make([]entity.PromoGrant, 0), nil
+}
+
+// SetCreditLimit - changing the credit limit of the wallet, it can't be lower than the current debt.
+func (r *WalletRepo) SetCreditLimit(ctx context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	defer r.lock(ctx)()
+
+	wallet, ok := r.wallets[walletId]
+	if !ok {
+		return nil, fmt.Errorf("WalletRepo - SetCreditLimit: %w", entity.ErrWalletNotFound)
+	}
+	if wallet.Balance < -creditLimit {
+		return nil, fmt.Errorf("WalletRepo - SetCreditLimit: %w", entity.ErrCreditLimitTooLow)
+	}
+	wallet.CreditLimit = creditLimit
+	wallet.Version++
+
+	changed := *wallet
+	return &changed, nil
+}
+
+// SetWalletStatus - changing the status of the wallet together with its pockets.
+// Closing sweeps the pockets into the wallet, the resulting balance must be zero.
+func (r *WalletRepo) SetWalletStatus(ctx context.Context, walletId string, status string) (*entity.Wallet, error) {
+	defer r.lock(ctx)()
+
+	wallet, ok := r.wallets[walletId]
+	if !ok {
+		return nil, fmt.Errorf("WalletRepo - SetWalletStatus: %w", entity.ErrWalletNotFound)
+	}
+	if wallet.Status == entity.WalletStatusClosed {
+		return nil, fmt.Errorf("WalletRepo - SetWalletStatus: %w", entity.ErrWalletClosed)
+	}
+
+	pockets := r.openPockets(walletId)
+	if status == entity.WalletStatusClosed {
+		total := wallet.Balance
+		for _, pocket := range pockets {
+			total += math.Max(pocket.Balance, 0)
+		}
+		if math.Abs(total) > amountEpsilon {
+			return nil, fmt.Errorf("WalletRepo - SetWalletStatus: %w", entity.ErrWalletNotEmpty)
+		}
+
+		for _, pocket := range pockets {
+			if pocket.Balance <= 0 {
+				continue
+			}
+
+			r.transactions = append(r.transactions, entity.Transaction{
+				Time:   time.Now(),
+				From:   pocket.ID,
+				To:     walletId,
+				Amount: pocket.Balance,
+				Type:   entity.TransactionTypePocketTransfer,
+			})
+			wallet.Balance += pocket.Balance
+			wallet.Version++
+			pocket.Balance = 0
+			pocket.Version++
+		}
+	}
+
+	wallet.Status = status
+	wallet.Version++
+	for _, pocket := range pockets {
+		pocket.Status = status
+		pocket.Version++
+	}
+
+	changed := *wallet
+	return &changed, nil
+}
+
+// GetPockets - getting open pockets of the wallet.
+func (r *WalletRepo) GetPockets(ctx context.Context, walletId string) ([]entity.Wallet, error) {
+	defer r.rlock(ctx)()
+
+	pockets := make([]entity.Wallet, 0)
+	for _, pocket := range r.openPockets(walletId) {
+		pockets = append(pockets, *pocket)
+	}
+
+	return pockets, nil
+}
+
+// GetWalletVersion - getting the version of the wallet. In Atomic the wallets stay locked till fn returns.
+func (r *WalletRepo) GetWalletVersion(ctx context.Context, walletId string) (int64, error) {
+	defer r.rlock(ctx)()
+
+	wallet, ok := r.wallets[walletId]
+	if !ok {
+		return 0, fmt.Errorf("WalletRepo - GetWalletVersion: %w", entity.ErrWalletNotFound)
+	}
+
+	return wallet.Version, nil
+}
+
+// GetBalanceAt - getting the balance of the wallet at the moment, it is the current balance without later movements.
+func (r *WalletRepo) GetBalanceAt(ctx context.Context, walletId string, at time.Time) (float64, error) {
+	defer r.rlock(ctx)()
+
+	wallet, ok := r.wallets[walletId]
+	if !ok {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt: %w", entity.ErrWalletNotFound)
+	}
+
+	balance := wallet.Balance
+	for _, transaction := range r.transactions {
+		if transaction.Time.Before(at) {
+			continue
+		}
+		if transaction.To == walletId {
+			balance -= transaction.Amount
+		}
+		if transaction.From == walletId {
+			balance += transaction.Amount
+		}
+	}
+
+	return balance, nil
+}
+
+// ReadSnapshot - running fn with the wallets locked for reading, so the reads made with its context see one state.
+// fn must not change the wallets.
+func (r *WalletRepo) ReadSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) || inSnapshot(ctx) {
+		return fn(ctx)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return fn(context.WithValue(ctx, snapshotKey{}, true))
+}
+
+// lock - locking the wallets for a change. The calls made in Atomic already hold the lock.
+func (r *WalletRepo) lock(ctx context.Context) func() {
+	if inTransaction(ctx) {
+		return func() {}
+	}
+	r.mu.Lock()
+	return r.mu.Unlock
+}
+
+// rlock - locking the wallets for reading. The calls made in Atomic and ReadSnapshot already hold the lock.
+func (r *WalletRepo) rlock(ctx context.Context) func() {
+	if inTransaction(ctx) || inSnapshot(ctx) {
+		return func() {}
+	}
+	r.mu.RLock()
+	return r.mu.RUnlock
+}
+
+// snapshot - copying the wallets to restore them if the transaction fails. The lock must be held by the caller.
+func (r *WalletRepo) snapshot() func() {
+	wallets := make(map[string]entity.Wallet, len(r.wallets))
+	for id, wallet := range r.wallets {
+		wallets[id] = *wallet
+	}
+	transactions := len(r.transactions)
+
+	return func() {
+		for id := range r.wallets {
+			if _, ok := wallets[id]; !ok {
+				delete(r.wallets, id)
+			}
+		}
+		for id, wallet := range wallets {
+			*r.wallets[id] = wallet
+		}
+		r.transactions = r.transactions[:transactions]
+	}
+}
+
+// openPockets - pockets of the wallet which are not closed, in order of creation. The lock must be held by the caller.
+func (r *WalletRepo) openPockets(walletId string) []*entity.Wallet {
+	pockets := make([]*entity.Wallet, 0)
+	for _, wallet := range r.wallets {
+		if wallet.ParentID == walletId && wallet.Status != entity.WalletStatusClosed {
+			pockets = append(pockets, wallet)
+		}
+	}
+	sort.Slice(pockets, func(i, j int) bool {
+		if pockets[i].CreatedAt.Equal(pockets[j].CreatedAt) {
+			return pockets[i].ID < pockets[j].ID
+		}
+		return pockets[i].CreatedAt.Before(pockets[j].CreatedAt)
+	})
+
+	return pockets
+}
+
+// makeUid - unique id like the one of make_uid() in the db. The lock must be held by the caller.
+func (r *WalletRepo) makeUid() string {
+	for {
+		sum := md5.Sum([]byte(fmt.Sprintf("%s%f", time.Now(), rand.Float64())))
+		id := hex.EncodeToString(sum[:])
+		if _, ok := r.wallets[id]; !ok {
+			return id
+		}
+	}
+}
+
+// checkWalletActive - frozen and closed wallets can't take part in user transfers.
+func checkWalletActive(wallet *entity.Wallet) error {
+	switch wallet.Status {
+	case entity.WalletStatusFrozen:
+		return entity.ErrWalletFrozen
+	case entity.WalletStatusClosed:
+		return entity.ErrWalletClosed
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+func Test_SendFunds(t *testing.T) {
+	ctx := context.Background()
+	r := NewWalletRepo()
+
+	sender, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 100})
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile("^[0-9a-f]{32}$"), sender.ID)
+	receiver, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 100})
+	require.NoError(t, err)
+
+	// Failed transfers change nothing
+	err = r.SendFunds(ctx, &entity.Transaction{From: sender.ID, To: receiver.ID, Amount: 100.5})
+	require.ErrorIs(t, err, entity.ErrInsufficientFunds)
+	err = r.SendFunds(ctx, &entity.Transaction{From: sender.ID, To: "eb376add88bf8e70f80787266a0801d5", Amount: 10})
+	require.ErrorIs(t, err, entity.ErrReceiverNotFound)
+
+	require.NoError(t, r.SendFunds(ctx, &entity.Transaction{From: sender.ID, To: receiver.ID, Amount: 30, ExternalReference: "INV-1"}))
+	require.NoError(t, r.SendFunds(ctx, &entity.Transaction{From: receiver.ID, To: sender.ID, Amount: 5}))
+
+	wallet, err := r.GetWalletById(ctx, sender.ID)
+	require.NoError(t, err)
+	require.Equal(t, 75.0, wallet.Balance)
+
+	history, err := r.GetWalletHistoryById(ctx, sender.ID, entity.HistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, 30.0, history[0].Amount)
+	require.Equal(t, 5.0, history[1].Amount)
+	require.False(t, history[1].Time.Before(history[0].Time))
+
+	history, err = r.GetWalletHistoryById(ctx, receiver.ID, entity.HistoryFilter{ExternalReference: "INV-1"})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	balance, err := r.GetBalanceAt(ctx, sender.ID, history[0].Time)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, balance)
+
+	_, err = r.GetWalletById(ctx, "eb376add88bf8e70f80787266a0801d5")
+	require.ErrorIs(t, err, entity.ErrWalletNotFound)
+}
+
+func Test_SendFundsConcurrently(t *testing.T) {
+	ctx := context.Background()
+	r := NewWalletRepo()
+
+	wallets := make([]*entity.Wallet, 4)
+	for i := range wallets {
+		wallet, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 10})
+		require.NoError(t, err)
+		wallets[i] = wallet
+	}
+
+	// Transfers in a ring, some of them fail on the balance check
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = r.SendFunds(ctx, &entity.Transaction{
+				From:   wallets[i%len(wallets)].ID,
+				To:     wallets[(i+1)%len(wallets)].ID,
+				Amount: float64(i%7 + 1),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0.0
+	for _, wallet := range wallets {
+		found, err := r.GetWalletById(ctx, wallet.ID)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, found.Balance, 0.0)
+		total += found.Balance
+	}
+	require.Equal(t, 40.0, total)
+}
+
+func Test_AtomicRollback(t *testing.T) {
+	ctx := context.Background()
+	r := NewWalletRepo()
+	outbox := NewOutboxRepo(r)
+
+	sender, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 100})
+	require.NoError(t, err)
+	receiver, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 100})
+	require.NoError(t, err)
+
+	// The transfer and its event are undone when the transaction fails
+	err = outbox.Atomic(ctx, func(ctx context.Context) error {
+		if err := r.SendFunds(ctx, &entity.Transaction{From: sender.ID, To: receiver.ID, Amount: 30}); err != nil {
+			return err
+		}
+		if _, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 100}); err != nil {
+			return err
+		}
+		if err := outbox.AddEvents(ctx, entity.DomainEvent{Type: entity.DomainEventFundsTransferred}); err != nil {
+			return err
+		}
+		return entity.ErrVersionMismatch
+	})
+	require.ErrorIs(t, err, entity.ErrVersionMismatch)
+
+	wallet, err := r.GetWalletById(ctx, sender.ID)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, wallet.Balance)
+	require.Equal(t, int64(1), wallet.Version)
+	history, err := r.GetWalletHistoryById(ctx, sender.ID, entity.HistoryFilter{})
+	require.NoError(t, err)
+	require.Empty(t, history)
+	require.Len(t, r.wallets, 2)
+	events, err := outbox.LockUnpublishedEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func Test_AtomicSerializesVersionChecks(t *testing.T) {
+	ctx := context.Background()
+	r := NewWalletRepo()
+	outbox := NewOutboxRepo(r)
+
+	wallet, err := r.CreateNewWallet(ctx, &entity.Wallet{Balance: 100})
+	require.NoError(t, err)
+
+	// Only one of the changes expecting the same version succeeds
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	applied := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := outbox.Atomic(ctx, func(ctx context.Context) error {
+				version, err := r.GetWalletVersion(ctx, wallet.ID)
+				if err != nil {
+					return err
+				}
+				if version != wallet.Version {
+					return entity.ErrVersionMismatch
+				}
+				_, err = r.SetCreditLimit(ctx, wallet.ID, 50)
+				return err
+			})
+			if err == nil {
+				mu.Lock()
+				applied++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, applied)
+}
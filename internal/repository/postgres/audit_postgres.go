@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// AuditRepo -.
+type AuditRepo struct {
+	*postgres.Postgres
+}
+
+// NewAuditRepo -.
+func NewAuditRepo(pg *postgres.Postgres) *AuditRepo {
+	return &AuditRepo{pg}
+}
+
+// AddAuditEntry - appending the entry to the audit log in the transaction of the context if there is one.
+func (r *AuditRepo) AddAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(entry).
+			Returning("id").
+			Insert()
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("AuditRepo - AddAuditEntry - r.RunInTransaction: %w", err)
+	}
+	return nil
+}
+
+// GetAuditEntries - getting the latest entries matching the filter, the newest first.
+func (r *AuditRepo) GetAuditEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	entries := make([]entity.AuditEntry, 0)
+	query := r.DB.Model(&entries)
+	if filter.WalletID != "" {
+		query = query.Where("wallet_id = ?", filter.WalletID)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Operation != "" {
+		query = query.Where("operation = ?", filter.Operation)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("time >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("time < ?", filter.To)
+	}
+	err := query.
+		Order("id DESC").
+		Limit(filter.Limit).
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo - GetAuditEntries - r.DB: %w", err)
+	}
+	return entries, nil
+}
+
+// DeleteAuditEntries - deleting the entries made before the time.
+func (r *AuditRepo) DeleteAuditEntries(ctx context.Context, before time.Time) (int, error) {
+	res, err := r.DB.Model((*entity.AuditEntry)(nil)).
+		Where("time < ?", before).
+		Delete()
+
+	if err != nil {
+		return 0, fmt.Errorf("AuditRepo - DeleteAuditEntries - r.DB: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
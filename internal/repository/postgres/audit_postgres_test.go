@@ -0,0 +1,44 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+)
+
+func Test_AuditedSendFunds(t *testing.T) {
+	pg := repotest.Postgres(t)
+	ctx := context.Background()
+
+	audit := NewAuditRepo(pg)
+	wallets := usecase.NewAuditedWallet(
+		usecase.New(NewWalletRepo(pg, []string{entity.BucketPromo, entity.BucketMain}), NewOutboxRepo(pg), 100, 0),
+		audit,
+	)
+
+	sender, err := wallets.CreateNewWalletWithDefaultBalance(ctx, entity.WalletTypeStandard)
+	require.NoError(t, err)
+	receiver, err := wallets.CreateNewWalletWithDefaultBalance(ctx, entity.WalletTypeStandard)
+	require.NoError(t, err)
+
+	_, err = wallets.SendFunds(ctx, sender.ID, entity.TransactionRequest{To: receiver.ID, Amount: 30})
+	require.NoError(t, err)
+
+	// The sender is read in the transaction of the transfer, so the state after it is already changed
+	entries, err := audit.GetAuditEntries(ctx, entity.AuditFilter{
+		WalletID:  sender.ID,
+		Operation: entity.AuditOperationSendFunds,
+		Limit:     1,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 100.0, entries[0].Before.Balance)
+	require.Equal(t, 70.0, entries[0].After.Balance)
+}
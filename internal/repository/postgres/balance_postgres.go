@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// BalanceRepo -.
+type BalanceRepo struct {
+	*postgres.Postgres
+}
+
+// NewBalanceRepo -.
+func NewBalanceRepo(pg *postgres.Postgres) *BalanceRepo {
+	return &BalanceRepo{pg}
+}
+
+// TakeSnapshots - storing end-of-day balances of the wallets created before the end of the day (UTC).
+// The end-of-day balance is the current balance without movements made after the day. Wallets which already
+// have a snapshot for the day are skipped.
+func (r *BalanceRepo) TakeSnapshots(ctx context.Context, day time.Time) (int, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO balance_snapshots (wallet_id, day, balance)
+		SELECT w.id, ?0, w.balance - eod.movements
+		FROM wallets AS w
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(SUM(CASE WHEN t.to_wallet_id = w.id THEN t.amount ELSE -t.amount END), 0) AS movements
+			FROM transactions AS t
+			WHERE (t.from_wallet_id = w.id OR t.to_wallet_id = w.id) AND t.time >= ?1
+		) AS eod
+		WHERE w.created_at < ?1
+		ON CONFLICT (wallet_id, day) DO NOTHING`,
+		day, day.AddDate(0, 0, 1))
+
+	if err != nil {
+		return 0, fmt.Errorf("BalanceRepo - TakeSnapshots - r.DB: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
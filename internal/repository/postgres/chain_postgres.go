@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// ChainRepo -.
+type ChainRepo struct {
+	*postgres.Postgres
+}
+
+// NewChainRepo -.
+func NewChainRepo(pg *postgres.Postgres) *ChainRepo {
+	return &ChainRepo{pg}
+}
+
+// ChainTransactions - linking up to limit not chained transactions to the chain.
+func (r *ChainRepo) ChainTransactions(ctx context.Context, limit int) (int, error) {
+	chained := 0
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		chain, err := lockChain(tx)
+		if err != nil {
+			return err
+		}
+		chained, err = extendChain(tx, chain, limit)
+
+		return err
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("ChainRepo - ChainTransactions - r.RunInTransaction: %w", err)
+	}
+	return chained, nil
+}
+
+// GetChain - getting the last chained transaction.
+func (r *ChainRepo) GetChain(ctx context.Context) (*entity.TransactionChain, error) {
+	chain := new(entity.TransactionChain)
+	err := r.DB.Model(chain).
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("ChainRepo - GetChain - r.DB: %w", err)
+	}
+	return chain, nil
+}
+
+// GetChainedTransactions - getting the chained transactions following the position in the chain order.
+func (r *ChainRepo) GetChainedTransactions(ctx context.Context, afterPosition int64, limit int) ([]entity.Transaction, error) {
+	transactions := make([]entity.Transaction, 0, limit)
+	err := r.DB.Model(&transactions).
+		Where("chain_position > ?", afterPosition).
+		Order("chain_position").
+		Limit(limit).
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("ChainRepo - GetChainedTransactions - r.DB: %w", err)
+	}
+	return transactions, nil
+}
+
+// CountUnchainedTransactions - counting the transactions waiting for the chain job.
+func (r *ChainRepo) CountUnchainedTransactions(ctx context.Context) (int, error) {
+	count, err := r.DB.Model((*entity.Transaction)(nil)).
+		Where("chain_position IS NULL").
+		Count()
+
+	if err != nil {
+		return 0, fmt.Errorf("ChainRepo - CountUnchainedTransactions - r.DB: %w", err)
+	}
+	return count, nil
+}
+
+// SaveChainHead - storing the signed chain head.
+func (r *ChainRepo) SaveChainHead(ctx context.Context, head *entity.ChainHead) error {
+	_, err := r.DB.Model(head).
+		Returning("id").
+		Insert()
+
+	if err != nil {
+		return fmt.Errorf("ChainRepo - SaveChainHead - r.DB: %w", err)
+	}
+	return nil
+}
+
+// GetChainHeads - getting all signed chain heads in the order they were published.
+func (r *ChainRepo) GetChainHeads(ctx context.Context) ([]entity.ChainHead, error) {
+	heads := make([]entity.ChainHead, 0)
+	err := r.DB.Model(&heads).
+		Order("id").
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("ChainRepo - GetChainHeads - r.DB: %w", err)
+	}
+	return heads, nil
+}
+
+// lockChain - locking the last chained transaction until the end of the db transaction, so only one chain job
+// extends the chain at a time.
+func lockChain(tx *pg.Tx) (*entity.TransactionChain, error) {
+	chain := new(entity.TransactionChain)
+	err := tx.Model(chain).
+		For("UPDATE").
+		Select()
+
+	return chain, err
+}
+
+// extendChain - linking up to limit not chained transactions to the locked chain in the id order. Transactions
+// committed after the later ones get the next positions, so the chain is walked by the position, not by the id.
+func extendChain(tx *pg.Tx, chain *entity.TransactionChain, limit int) (int, error) {
+	pending := make([]entity.Transaction, 0)
+	err := tx.Model(&pending).
+		Where("chain_position IS NULL").
+		Order("id").
+		Limit(limit).
+		Select()
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+
+	for i := range pending {
+		pending[i].PrevHash = chain.Hash
+		pending[i].Hash = pending[i].ChainHash()
+		pending[i].ChainPosition = chain.Position + 1
+		_, err = tx.Model(&pending[i]).
+			Column("prev_hash", "hash", "chain_position").
+			WherePK().
+			Update()
+		if err != nil {
+			return 0, err
+		}
+
+		chain.TransactionID = pending[i].ID
+		chain.Hash = pending[i].Hash
+		chain.Position = pending[i].ChainPosition
+	}
+
+	_, err = tx.Model(chain).
+		Column("transaction_id", "hash", "position").
+		WherePK().
+		Update()
+	if err != nil {
+		return 0, err
+	}
+	return len(pending), nil
+}
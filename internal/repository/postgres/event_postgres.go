@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// walletEventsChannel - notification channel of the committed wallet events.
+const walletEventsChannel = "wallet_events"
+
+// EventRepo -.
+type EventRepo struct {
+	*postgres.Postgres
+}
+
+// NewEventRepo -.
+func NewEventRepo(pg *postgres.Postgres) *EventRepo {
+	return &EventRepo{pg}
+}
+
+// GetWalletEvents - getting events of the wallet after the sequence in the commit order.
+func (r *EventRepo) GetWalletEvents(ctx context.Context, walletId string, afterSequence int64) ([]entity.WalletEvent, error) {
+	events := make([]entity.WalletEvent, 0)
+	err := r.DB.Model(&events).
+		Where("wallet_id = ?", walletId).
+		Where("sequence > ?", afterSequence).
+		Order("sequence ASC").
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("EventRepo - GetWalletEvents - r.DB: %w", err)
+	}
+	return events, nil
+}
+
+// ListenWalletEvents - passing events committed by any app instance to the handler until the context is done or
+// the listener is closed. Events which can't be decoded are passed to onError and skipped.
+// Events committed while the listener is disconnected are only available from the table.
+func (r *EventRepo) ListenWalletEvents(ctx context.Context, handler func(entity.WalletEvent), onError func(error)) error {
+	ln := r.DB.Listen(ctx, walletEventsChannel)
+	defer ln.Close()
+
+	notifications := ln.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification, ok := <-notifications:
+			if !ok {
+				return fmt.Errorf("EventRepo - ListenWalletEvents - listener is closed")
+			}
+
+			var event entity.WalletEvent
+			if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+				onError(fmt.Errorf("EventRepo - ListenWalletEvents - json.Unmarshal: %w", err))
+				continue
+			}
+			handler(event)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// InterestRepo -.
+type InterestRepo struct {
+	*postgres.Postgres
+}
+
+// NewInterestRepo -.
+func NewInterestRepo(pg *postgres.Postgres) *InterestRepo {
+	return &InterestRepo{pg}
+}
+
+// AccrueInterest - storing daily interest of savings wallets and overdraft interest of wallets with negative balance.
+// The end-of-day balance is the current balance without movements made after the day. Daily interest is rounded
+// half away from zero to 6 decimal places, overdraft interest is negative. Wallets which already have an accrual
+// for the day are skipped.
+func (r *InterestRepo) AccrueInterest(ctx context.Context, day time.Time, overdraftRate float64) (int, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO interest_accruals (wallet_id, day, balance, annual_rate, amount)
+		SELECT id, ?0, balance, rate, ROUND((balance * rate / ?2)::numeric, 6)
+		FROM (
+			SELECT w.id, eod.balance, CASE WHEN eod.balance > 0 THEN w.annual_rate ELSE ?4 END AS rate
+			FROM wallets AS w
+			CROSS JOIN LATERAL (
+				SELECT w.balance - COALESCE(SUM(CASE WHEN t.to_wallet_id = w.id THEN t.amount ELSE -t.amount END), 0) AS balance
+				FROM transactions AS t
+				WHERE (t.from_wallet_id = w.id OR t.to_wallet_id = w.id) AND t.time >= ?1
+			) AS eod
+			WHERE NOT w.is_system AND w.created_at < ?1
+				AND ((w.type = ?3 AND eod.balance > 0) OR eod.balance < 0)
+		) AS balances
+		WHERE rate > 0
+		ON CONFLICT (wallet_id, day) DO NOTHING`,
+		day, day.AddDate(0, 0, 1), entity.InterestDaysInYear, entity.WalletTypeSavings, overdraftRate)
+
+	if err != nil {
+		return 0, fmt.Errorf("InterestRepo - AccrueInterest - r.DB: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
+
+// GetCapitalizationPeriods - getting the months before the moment with not capitalized accruals, oldest first.
+func (r *InterestRepo) GetCapitalizationPeriods(ctx context.Context, before time.Time) ([]entity.InterestPeriod, error) {
+	periods := make([]entity.InterestPeriod, 0)
+	_, err := r.DB.QueryContext(ctx, &periods, `
+		SELECT wallet_id, date_trunc('month', day) AS month
+		FROM interest_accruals
+		WHERE capitalized_at IS NULL AND day < ?
+		GROUP BY 1, 2
+		ORDER BY 2, 1`, before)
+
+	if err != nil {
+		return nil, fmt.Errorf("InterestRepo - GetCapitalizationPeriods - r.DB: %w", err)
+	}
+	return periods, nil
+}
+
+// CapitalizeInterest - crediting the accruals of the wallet for the month from the system interest wallet and
+// marking them capitalized. The monthly amount is rounded half away from zero to cents. Returns the movement
+// of the interest, nil if nothing is moved. Like transfers, frozen and closed wallets are refused and their
+// accruals stay not capitalized.
+func (r *InterestRepo) CapitalizeInterest(ctx context.Context, walletId string, month time.Time) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := lockActiveWallet(tx, walletId); err != nil {
+			return err
+		}
+
+		accruals := make([]entity.InterestAccrual, 0)
+		err := tx.Model(&accruals).
+			Where("wallet_id = ?", walletId).
+			Where("day >= ?", month).
+			Where("day < ?", month.AddDate(0, 1, 0)).
+			Where("capitalized_at IS NULL").
+			For("UPDATE").
+			Select()
+		if err != nil || len(accruals) == 0 {
+			return err
+		}
+
+		total := 0.0
+		for _, accrual := range accruals {
+			total += accrual.Amount
+		}
+		total = math.Round(total*100) / 100
+
+		switch {
+		case total > 0:
+			transaction = &entity.Transaction{
+				From:        entity.SystemInterestWalletID,
+				To:          walletId,
+				Amount:      total,
+				Type:        entity.TransactionTypeInterest,
+				Description: fmt.Sprintf("Interest for %s", month.Format("2006-01")),
+			}
+			err = moveFunds(tx, transaction)
+		case total < 0:
+			transaction, err = chargeOverdraftInterest(tx, walletId, -total, fmt.Sprintf("Overdraft interest for %s", month.Format("2006-01")))
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&entity.InterestAccrual{}).
+			Set("capitalized_at = ?", time.Now()).
+			Where("wallet_id = ?", walletId).
+			Where("day >= ?", month).
+			Where("day < ?", month.AddDate(0, 1, 0)).
+			Where("capitalized_at IS NULL").
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("InterestRepo - CapitalizeInterest - r.DB: %w", err)
+	}
+	return transaction, nil
+}
+
+// GetInterestDebtors - getting the wallets with overdraft interest debt.
+func (r *InterestRepo) GetInterestDebtors(ctx context.Context) ([]string, error) {
+	debtors := make([]string, 0)
+	err := r.DB.Model((*entity.Wallet)(nil)).
+		Column("id").
+		Where("interest_debt > 0").
+		Order("id ASC").
+		Select(&debtors)
+
+	if err != nil {
+		return nil, fmt.Errorf("InterestRepo - GetInterestDebtors - r.DB: %w", err)
+	}
+	return debtors, nil
+}
+
+// ChargeInterestDebt - charging the overdraft interest debt of the wallet as far as the credit allows.
+// Returns the charge, nil if nothing is charged. Frozen and closed wallets are refused.
+func (r *InterestRepo) ChargeInterestDebt(ctx context.Context, walletId string) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := lockActiveWallet(tx, walletId); err != nil {
+			return err
+		}
+
+		var err error
+		transaction, err = chargeOverdraftInterest(tx, walletId, 0, "Overdraft interest debt")
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("InterestRepo - ChargeInterestDebt - r.DB: %w", err)
+	}
+	return transaction, nil
+}
+
+// chargeOverdraftInterest - moving overdraft interest and the debt left by previous charges to the system interest
+// wallet. The charge is limited by the available credit, so the credit limit is never exceeded, and the rest is
+// kept as the debt of the wallet. Returns the charge, nil if nothing is charged.
+func chargeOverdraftInterest(tx *pg.Tx, walletId string, amount float64, description string) (*entity.Transaction, error) {
+	wallet := new(entity.Wallet)
+	err := tx.Model(wallet).
+		Where("id = ?", walletId).
+		For("UPDATE").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+
+	amount += wallet.InterestDebt
+	charged := math.Max(math.Min(amount, wallet.Balance+wallet.CreditLimit), 0)
+	debt := math.Round((amount-charged)*100) / 100
+	if debt != wallet.InterestDebt {
+		_, err = tx.Model(wallet).
+			Set("interest_debt = ?", debt).
+			Set("version = version + 1").
+			WherePK().
+			Update()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if charged <= 0 {
+		return nil, nil
+	}
+
+	transaction := &entity.Transaction{
+		From:        walletId,
+		To:          entity.SystemInterestWalletID,
+		Amount:      charged,
+		Type:        entity.TransactionTypeOverdraftInterest,
+		Description: description,
+	}
+	return transaction, moveFunds(tx, transaction)
+}
+
+// GetInterestAccruals - getting all interest accruals of the wallet in time order.
+func (r *InterestRepo) GetInterestAccruals(ctx context.Context, walletId string) ([]entity.InterestAccrual, error) {
+	exists, err := r.DB.Model(&entity.Wallet{}).
+		Where("id = ?", walletId).
+		Exists()
+	if err != nil {
+		return nil, fmt.Errorf("InterestRepo - GetInterestAccruals - r.DB: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("InterestRepo - GetInterestAccruals - r.DB: %w", entity.ErrWalletNotFound)
+	}
+
+	accruals := make([]entity.InterestAccrual, 0)
+	err = r.DB.Model(&accruals).
+		Where("wallet_id = ?", walletId).
+		Order("day ASC").
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("InterestRepo - GetInterestAccruals - r.DB: %w", err)
+	}
+	return accruals, nil
+}
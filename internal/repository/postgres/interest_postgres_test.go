@@ -0,0 +1,91 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+)
+
+func Test_OverdraftInterestDebt(t *testing.T) {
+	pg := repotest.Postgres(t)
+	ctx := context.Background()
+
+	wallets := NewWalletRepo(pg, []string{entity.BucketPromo, entity.BucketMain})
+	interest := usecase.NewInterest(NewInterestRepo(pg), NewOutboxRepo(pg), 0, 0)
+
+	debtor, err := wallets.CreateNewWallet(ctx, &entity.Wallet{Balance: 100, Type: entity.WalletTypeStandard, Status: entity.WalletStatusActive})
+	require.NoError(t, err)
+	other, err := wallets.CreateNewWallet(ctx, &entity.Wallet{Balance: 100, Type: entity.WalletTypeStandard, Status: entity.WalletStatusActive})
+	require.NoError(t, err)
+	_, err = wallets.SetCreditLimit(ctx, debtor.ID, 100)
+	require.NoError(t, err)
+	require.NoError(t, wallets.SendFunds(ctx, &entity.Transaction{From: debtor.ID, To: other.ID, Amount: 195}))
+
+	// Only 5 of the 8 accrued are available in the credit limit
+	month := time.Now().UTC().AddDate(0, -1, 0)
+	_, err = pg.DB.Model(&entity.InterestAccrual{
+		WalletID:   debtor.ID,
+		Day:        time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC),
+		Balance:    -95,
+		AnnualRate: 0.2,
+		Amount:     -8,
+	}).Insert()
+	require.NoError(t, err)
+
+	require.NoError(t, interest.RunAccrualJob(ctx))
+	wallet, err := wallets.GetWalletById(ctx, debtor.ID)
+	require.NoError(t, err)
+	require.Equal(t, -100.0, wallet.Balance)
+	require.Equal(t, 3.0, wallet.InterestDebt)
+
+	// The debt is charged when the credit allows
+	require.NoError(t, wallets.SendFunds(ctx, &entity.Transaction{From: other.ID, To: debtor.ID, Amount: 50}))
+	require.NoError(t, interest.RunAccrualJob(ctx))
+	wallet, err = wallets.GetWalletById(ctx, debtor.ID)
+	require.NoError(t, err)
+	require.Equal(t, -53.0, wallet.Balance)
+	require.Equal(t, 0.0, wallet.InterestDebt)
+}
+
+func Test_CapitalizeInterestOfFrozenWallet(t *testing.T) {
+	pg := repotest.Postgres(t)
+	ctx := context.Background()
+
+	wallets := NewWalletRepo(pg, []string{entity.BucketPromo, entity.BucketMain})
+	interest := usecase.NewInterest(NewInterestRepo(pg), NewOutboxRepo(pg), 0, 0)
+
+	saver, err := wallets.CreateNewWallet(ctx, &entity.Wallet{Balance: 100, Type: entity.WalletTypeSavings, Status: entity.WalletStatusActive})
+	require.NoError(t, err)
+	month := time.Now().UTC().AddDate(0, -1, 0)
+	_, err = pg.DB.Model(&entity.InterestAccrual{
+		WalletID:   saver.ID,
+		Day:        time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC),
+		Balance:    100,
+		AnnualRate: 0.2,
+		Amount:     2,
+	}).Insert()
+	require.NoError(t, err)
+
+	// Interest isn't credited to a frozen wallet, it waits till the wallet is unfrozen
+	_, err = wallets.SetWalletStatus(ctx, saver.ID, entity.WalletStatusFrozen)
+	require.NoError(t, err)
+	require.NoError(t, interest.RunAccrualJob(ctx))
+	wallet, err := wallets.GetWalletById(ctx, saver.ID)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, wallet.Balance)
+
+	_, err = wallets.SetWalletStatus(ctx, saver.ID, entity.WalletStatusActive)
+	require.NoError(t, err)
+	require.NoError(t, interest.RunAccrualJob(ctx))
+	wallet, err = wallets.GetWalletById(ctx, saver.ID)
+	require.NoError(t, err)
+	require.Equal(t, 102.0, wallet.Balance)
+}
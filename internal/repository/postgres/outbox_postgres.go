@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// outboxRelayLock - advisory lock held by the relay, so only one app instance publishes the events and their order is kept.
+const outboxRelayLock = 7245100239
+
+// OutboxRepo -.
+type OutboxRepo struct {
+	*postgres.Postgres
+}
+
+// NewOutboxRepo -.
+func NewOutboxRepo(pg *postgres.Postgres) *OutboxRepo {
+	return &OutboxRepo{pg}
+}
+
+// AddEvents - storing the events in the outbox, in the transaction of the use case if there is one.
+func (r *OutboxRepo) AddEvents(ctx context.Context, events ...entity.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&events).
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("OutboxRepo - AddEvents - r.DB: %w", err)
+	}
+	return nil
+}
+
+// LockUnpublishedEvents - getting the oldest unpublished events, it must be called in the transaction of the use case.
+// The events are locked until the transaction ends. If another app instance is relaying the events, nothing is returned.
+func (r *OutboxRepo) LockUnpublishedEvents(ctx context.Context, limit int) ([]entity.DomainEvent, error) {
+	events := make([]entity.DomainEvent, 0)
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		var locked bool
+		_, err := tx.QueryOne(pg.Scan(&locked), "SELECT pg_try_advisory_xact_lock(?)", outboxRelayLock)
+		if err != nil || !locked {
+			return err
+		}
+
+		return tx.Model(&events).
+			Where("published_at IS NULL").
+			Order("id ASC").
+			Limit(limit).
+			For("UPDATE").
+			Select()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepo - LockUnpublishedEvents - r.DB: %w", err)
+	}
+	return events, nil
+}
+
+// MarkPublished - marking the events as published, they are not relayed again.
+func (r *OutboxRepo) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&entity.DomainEvent{}).
+			Set("published_at = ?", at).
+			Where("id IN (?)", pg.In(ids)).
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("OutboxRepo - MarkPublished - r.DB: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// PaymentRepo -.
+type PaymentRepo struct {
+	*postgres.Postgres
+	spendPriority []string
+}
+
+// NewPaymentRepo -.
+func NewPaymentRepo(pg *postgres.Postgres, spendPriority []string) *PaymentRepo {
+	return &PaymentRepo{pg, spendPriority}
+}
+
+// CreatePayment - storing a pending payment. Funds of a withdrawal are held on the gateway wallet until it is completed,
+// deposits to frozen and closed wallets are refused. Stored in the transaction of the context if there is one.
+func (r *PaymentRepo) CreatePayment(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if payment.Type == entity.PaymentTypeWithdrawal {
+			err := transfer(tx, &entity.Transaction{
+				From:              payment.WalletID,
+				To:                entity.SystemGatewayWalletID,
+				Amount:            payment.Amount,
+				Type:              entity.TransactionTypeWithdrawal,
+				ExternalReference: payment.ID,
+			}, r.spendPriority)
+			if err != nil {
+				return err
+			}
+		} else if _, err := lockActiveWallet(tx, payment.WalletID); err != nil {
+			return err
+		}
+
+		_, err := tx.Model(payment).
+			Returning("*").
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("PaymentRepo - CreatePayment - r.DB: %w", err)
+	}
+	return payment, nil
+}
+
+// UpdateGatewayReference - storing the ID of the payment assigned by the gateway, in the transaction of the context
+// if there is one.
+func (r *PaymentRepo) UpdateGatewayReference(ctx context.Context, paymentId string, reference string) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&entity.Payment{}).
+			Set("gateway_reference = ?", reference).
+			Where("id = ?", paymentId).
+			Where("gateway_reference IS NULL").
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("PaymentRepo - UpdateGatewayReference - r.DB: %w", err)
+	}
+	return nil
+}
+
+// CompletePayment - moving a pending payment to the final status. A succeeded deposit credits the wallet,
+// a failed withdrawal returns the held funds. Frozen and closed wallets aren't credited, the payment stays pending.
+// Repeated notifications with the same status are ignored, false is returned for them.
+func (r *PaymentRepo) CompletePayment(ctx context.Context, paymentId string, status string, reference string) (*entity.Payment, bool, error) {
+	payment := new(entity.Payment)
+	completed := false
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := tx.Model(payment).
+			Where("id = ?", paymentId).
+			For("UPDATE").
+			Select()
+		if errors.Is(err, pg.ErrNoRows) {
+			return entity.ErrPaymentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if payment.Status == status {
+			return nil
+		}
+		if payment.Status != entity.PaymentStatusPending {
+			return entity.ErrPaymentAlreadyCompleted
+		}
+
+		var credit *entity.Transaction
+		switch {
+		case payment.Type == entity.PaymentTypeDeposit && status == entity.PaymentStatusSucceeded:
+			credit = &entity.Transaction{
+				From:              entity.SystemGatewayWalletID,
+				To:                payment.WalletID,
+				Amount:            payment.Amount,
+				Type:              entity.TransactionTypeDeposit,
+				ExternalReference: payment.ID,
+			}
+		case payment.Type == entity.PaymentTypeWithdrawal && status == entity.PaymentStatusFailed:
+			credit = &entity.Transaction{
+				From:              entity.SystemGatewayWalletID,
+				To:                payment.WalletID,
+				Amount:            payment.Amount,
+				Type:              entity.TransactionTypeWithdrawalReversal,
+				ExternalReference: payment.ID,
+			}
+		}
+		if credit != nil {
+			// A frozen or closed wallet isn't credited, the payment stays pending and the gateway repeats the notification
+			if _, err := lockActiveWallet(tx, payment.WalletID); err != nil {
+				return err
+			}
+			if err := moveFunds(tx, credit); err != nil {
+				return err
+			}
+		}
+
+		payment.Status = status
+		payment.UpdatedAt = time.Now()
+		if reference != "" {
+			payment.GatewayReference = reference
+		}
+		_, err = tx.Model(payment).
+			Column("status", "gateway_reference", "updated_at").
+			WherePK().
+			Update()
+
+		completed = err == nil
+		return err
+	})
+
+	if err != nil {
+		return nil, false, fmt.Errorf("PaymentRepo - CompletePayment - r.DB: %w", err)
+	}
+	return payment, completed, nil
+}
+
+// GetPaymentById - getting payment info by paymentId, in the transaction of the context if there is one.
+func (r *PaymentRepo) GetPaymentById(ctx context.Context, paymentId string) (*entity.Payment, error) {
+	payment := new(entity.Payment)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(payment).
+			Where("id = ?", paymentId).
+			Select()
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, fmt.Errorf("PaymentRepo - GetPaymentById - r.DB: %w", entity.ErrPaymentNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PaymentRepo - GetPaymentById - r.DB: %w", err)
+	}
+	return payment, nil
+}
@@ -0,0 +1,46 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+)
+
+func Test_PaymentOfFrozenWallet(t *testing.T) {
+	pg := repotest.Postgres(t)
+	ctx := context.Background()
+
+	wallets := NewWalletRepo(pg, []string{entity.BucketPromo, entity.BucketMain})
+	payments := NewPaymentRepo(pg, []string{entity.BucketPromo, entity.BucketMain})
+
+	wallet, err := wallets.CreateNewWallet(ctx, &entity.Wallet{Balance: 100, Type: entity.WalletTypeStandard, Status: entity.WalletStatusActive})
+	require.NoError(t, err)
+	_, err = payments.CreatePayment(ctx, &entity.Payment{ID: wallet.ID + "-1", WalletID: wallet.ID, Type: entity.PaymentTypeDeposit, Amount: 50, Status: entity.PaymentStatusPending})
+	require.NoError(t, err)
+	_, err = wallets.SetWalletStatus(ctx, wallet.ID, entity.WalletStatusFrozen)
+	require.NoError(t, err)
+
+	// New deposits are refused, the started one is credited only after the wallet is unfrozen
+	_, err = payments.CreatePayment(ctx, &entity.Payment{ID: wallet.ID + "-2", WalletID: wallet.ID, Type: entity.PaymentTypeDeposit, Amount: 50, Status: entity.PaymentStatusPending})
+	require.ErrorIs(t, err, entity.ErrWalletFrozen)
+	_, _, err = payments.CompletePayment(ctx, wallet.ID+"-1", entity.PaymentStatusSucceeded, "ref")
+	require.ErrorIs(t, err, entity.ErrWalletFrozen)
+	payment, err := payments.GetPaymentById(ctx, wallet.ID+"-1")
+	require.NoError(t, err)
+	require.Equal(t, entity.PaymentStatusPending, payment.Status)
+
+	_, err = wallets.SetWalletStatus(ctx, wallet.ID, entity.WalletStatusActive)
+	require.NoError(t, err)
+	_, completed, err := payments.CompletePayment(ctx, wallet.ID+"-1", entity.PaymentStatusSucceeded, "ref")
+	require.NoError(t, err)
+	require.True(t, completed)
+	wallet, err = wallets.GetWalletById(ctx, wallet.ID)
+	require.NoError(t, err)
+	require.Equal(t, 150.0, wallet.Balance)
+}
@@ -0,0 +1,131 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// PocketRepo -.
+type PocketRepo struct {
+	*postgres.Postgres
+	spendPriority []string
+}
+
+// NewPocketRepo -.
+func NewPocketRepo(pg *postgres.Postgres, spendPriority []string) *PocketRepo {
+	return &PocketRepo{pg, spendPriority}
+}
+
+// CreatePocket - creating a pocket under an active top-level wallet.
+func (r *PocketRepo) CreatePocket(ctx context.Context, pocket *entity.Wallet) (*entity.Wallet, error) {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		parent, err := lockActiveWallet(tx, pocket.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent.ParentID != "" {
+			return entity.ErrNestedPocket
+		}
+
+		_, err = tx.Model(pocket).
+			Returning("*").
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("PocketRepo - CreatePocket - r.DB: %w", err)
+	}
+	return pocket, nil
+}
+
+// MovePocketFunds - moving funds between the wallet and its pocket, the wallet spends its balance buckets as in SendFunds.
+func (r *PocketRepo) MovePocketFunds(ctx context.Context, walletId string, pocketId string, transaction *entity.Transaction) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		parent, pocket, err := lockPocket(tx, walletId, pocketId)
+		if err != nil {
+			return err
+		}
+
+		if transaction.From == parent.ID {
+			err = spendPromo(tx, parent, transaction.Amount, r.spendPriority)
+		} else if pocket.Balance < transaction.Amount-amountEpsilon {
+			err = entity.ErrInsufficientFunds
+		}
+		if err != nil {
+			return err
+		}
+
+		return moveFunds(tx, transaction)
+	})
+
+	if err != nil {
+		return fmt.Errorf("PocketRepo - MovePocketFunds - r.DB: %w", err)
+	}
+	return nil
+}
+
+// ClosePocket - moving the rest of the pocket to the wallet and closing the pocket.
+// Returns the movement of the rest, nil if the pocket is empty.
+func (r *PocketRepo) ClosePocket(ctx context.Context, walletId string, pocketId string) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, pocket, err := lockPocket(tx, walletId, pocketId)
+		if err != nil {
+			return err
+		}
+
+		if pocket.Balance > 0 {
+			transaction = &entity.Transaction{
+				From:   pocketId,
+				To:     walletId,
+				Amount: pocket.Balance,
+				Type:   entity.TransactionTypePocketTransfer,
+			}
+			if err := moveFunds(tx, transaction); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Model(&entity.Wallet{}).
+			Set("status = ?", entity.WalletStatusClosed).
+			Set("version = version + 1").
+			Where("id = ?", pocketId).
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("PocketRepo - ClosePocket - r.DB: %w", err)
+	}
+	return transaction, nil
+}
+
+// lockPocket - locking the wallet and then its pocket, both of them must be active.
+func lockPocket(tx *pg.Tx, walletId string, pocketId string) (*entity.Wallet, *entity.Wallet, error) {
+	parent, err := lockActiveWallet(tx, walletId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pocket := new(entity.Wallet)
+	err = tx.Model(pocket).
+		Where("id = ?", pocketId).
+		Where("parent_id = ?", walletId).
+		For("UPDATE").
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil, entity.ErrPocketNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parent, pocket, checkWalletActive(pocket)
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// PromoRepo -.
+type PromoRepo struct {
+	*postgres.Postgres
+}
+
+// NewPromoRepo -.
+func NewPromoRepo(pg *postgres.Postgres) *PromoRepo {
+	return &PromoRepo{pg}
+}
+
+// GrantPromo - crediting the wallet from the system promo wallet and recording the grant.
+func (r *PromoRepo) GrantPromo(ctx context.Context, grant *entity.PromoGrant) (*entity.PromoGrant, error) {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := moveFunds(tx, &entity.Transaction{
+			From:   entity.SystemPromoWalletID,
+			To:     grant.WalletID,
+			Amount: grant.Amount,
+			Type:   entity.TransactionTypePromoGrant,
+		})
+		if errors.Is(err, entity.ErrReceiverNotFound) {
+			return entity.ErrWalletNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(grant).
+			Returning("*").
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("PromoRepo - GrantPromo - r.DB: %w", err)
+	}
+	return grant, nil
+}
+
+// GetExpiredPromoGrants - getting expired grants with unspent remains.
+func (r *PromoRepo) GetExpiredPromoGrants(ctx context.Context, now time.Time) ([]entity.PromoGrant, error) {
+	grants := make([]entity.PromoGrant, 0)
+	err := r.DB.Model(&grants).
+		Column("id", "wallet_id").
+		Where("remaining > 0").
+		Where("expired_at IS NULL").
+		Where("expires_at <= ?", now).
+		Order("id ASC").
+		Select()
+
+	if err != nil {
+		return nil, fmt.Errorf("PromoRepo - GetExpiredPromoGrants - r.DB: %w", err)
+	}
+	return grants, nil
+}
+
+// ExpirePromoGrant - moving the unspent remain of the grant back to the system promo wallet. The wallet is locked
+// before the grant in the same order as in SendFunds to avoid deadlocks. Returns nil if the grant is already
+// spent or expired.
+func (r *PromoRepo) ExpirePromoGrant(ctx context.Context, candidate entity.PromoGrant, now time.Time) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := tx.Model(&entity.Wallet{}).
+			Column("id").
+			Where("id = ?", candidate.WalletID).
+			For("UPDATE").
+			Select()
+		if err != nil {
+			return err
+		}
+		grant := new(entity.PromoGrant)
+		err = tx.Model(grant).
+			Where("id = ?", candidate.ID).
+			Where("remaining > 0").
+			Where("expired_at IS NULL").
+			For("UPDATE").
+			Select()
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		expiry := &entity.Transaction{
+			From:   grant.WalletID,
+			To:     entity.SystemPromoWalletID,
+			Amount: grant.Remaining,
+			Type:   entity.TransactionTypePromoExpiry,
+		}
+		if err := moveFunds(tx, expiry); err != nil {
+			return err
+		}
+
+		grant.Remaining = 0
+		grant.ExpiredAt = &now
+		_, err = tx.Model(grant).
+			Column("remaining", "expired_at").
+			WherePK().
+			Update()
+		if err != nil {
+			return err
+		}
+
+		transaction = expiry
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("PromoRepo - ExpirePromoGrant - r.DB: %w", err)
+	}
+	return transaction, nil
+}
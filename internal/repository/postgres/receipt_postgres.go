@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// ReceiptRepo -.
+type ReceiptRepo struct {
+	*postgres.Postgres
+}
+
+// NewReceiptRepo -.
+func NewReceiptRepo(pg *postgres.Postgres) *ReceiptRepo {
+	return &ReceiptRepo{pg}
+}
+
+// GetTransactionById - getting the transaction with the id.
+func (r *ReceiptRepo) GetTransactionById(ctx context.Context, transactionId int64) (*entity.Transaction, error) {
+	transaction := new(entity.Transaction)
+	err := r.DB.Model(transaction).
+		Where("id = ?", transactionId).
+		Select()
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, fmt.Errorf("ReceiptRepo - GetTransactionById - r.DB: %w", entity.ErrTransactionNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ReceiptRepo - GetTransactionById - r.DB: %w", err)
+	}
+	return transaction, nil
+}
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// ReconciliationRepo -.
+type ReconciliationRepo struct {
+	*postgres.Postgres
+}
+
+// NewReconciliationRepo -.
+func NewReconciliationRepo(pg *postgres.Postgres) *ReconciliationRepo {
+	return &ReconciliationRepo{pg}
+}
+
+// SetInitialBalances - setting the initial balance of the wallets created before the initial balances were stored.
+func (r *ReconciliationRepo) SetInitialBalances(ctx context.Context, defaultBalance float64) (int, error) {
+	res, err := r.DB.Model((*entity.Wallet)(nil)).
+		Set("initial_balance = ?", defaultBalance).
+		Where("initial_balance IS NULL").
+		Update()
+
+	if err != nil {
+		return 0, fmt.Errorf("ReconciliationRepo - SetInitialBalances - r.DB: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
+
+// GetWalletLedgers - getting the balances and the sums of recorded movements of the wallets following the wallet
+// with the id in the id order, including system wallets. Each wallet is read together with its movements.
+func (r *ReconciliationRepo) GetWalletLedgers(ctx context.Context, afterId string, limit int) ([]entity.WalletLedger, error) {
+	ledgers := make([]entity.WalletLedger, 0, limit)
+	_, err := r.DB.QueryContext(ctx, &ledgers, `
+		SELECT w.id AS wallet_id, w.balance, w.initial_balance,
+			COALESCE(incoming.amount, 0) AS incoming, COALESCE(outgoing.amount, 0) AS outgoing
+		FROM wallets AS w
+		CROSS JOIN LATERAL (SELECT SUM(t.amount) AS amount FROM transactions AS t WHERE t.to_wallet_id = w.id) AS incoming
+		CROSS JOIN LATERAL (SELECT SUM(t.amount) AS amount FROM transactions AS t WHERE t.from_wallet_id = w.id) AS outgoing
+		WHERE w.id > ?0
+		ORDER BY w.id
+		LIMIT ?1`,
+		afterId, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("ReconciliationRepo - GetWalletLedgers - r.DB: %w", err)
+	}
+	return ledgers, nil
+}
+
+// GetTotals - getting the sums of the balances and the initial balances of all wallets at one moment.
+// Transfers only move funds between the wallets, so the sums are equal if no money appeared or disappeared.
+func (r *ReconciliationRepo) GetTotals(ctx context.Context) (float64, float64, error) {
+	var totals struct {
+		Balance        float64
+		InitialBalance float64
+	}
+	_, err := r.DB.QueryOneContext(ctx, &totals, `
+		SELECT COALESCE(SUM(balance), 0) AS balance, COALESCE(SUM(initial_balance), 0) AS initial_balance
+		FROM wallets`)
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("ReconciliationRepo - GetTotals - r.DB: %w", err)
+	}
+	return totals.Balance, totals.InitialBalance, nil
+}
+
+// SaveReconciliation - storing the result of the reconciliation.
+func (r *ReconciliationRepo) SaveReconciliation(ctx context.Context, reconciliation *entity.Reconciliation) error {
+	_, err := r.DB.Model(reconciliation).
+		Returning("id").
+		Insert()
+
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepo - SaveReconciliation - r.DB: %w", err)
+	}
+	return nil
+}
+
+// GetLastReconciliation - getting the result of the latest reconciliation.
+func (r *ReconciliationRepo) GetLastReconciliation(ctx context.Context) (*entity.Reconciliation, error) {
+	reconciliation := new(entity.Reconciliation)
+	err := r.DB.Model(reconciliation).
+		Order("id DESC").
+		Limit(1).
+		Select()
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, fmt.Errorf("ReconciliationRepo - GetLastReconciliation - r.DB: %w", entity.ErrReconciliationNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ReconciliationRepo - GetLastReconciliation - r.DB: %w", err)
+	}
+	return reconciliation, nil
+}
@@ -0,0 +1,233 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// VoucherRepo -.
+type VoucherRepo struct {
+	*postgres.Postgres
+	spendPriority []string
+}
+
+// NewVoucherRepo -.
+func NewVoucherRepo(pg *postgres.Postgres, spendPriority []string) *VoucherRepo {
+	return &VoucherRepo{pg, spendPriority}
+}
+
+// CreateVouchers - moving the total value of the batch from the source wallet to the voucher wallet and storing the vouchers.
+func (r *VoucherRepo) CreateVouchers(ctx context.Context, vouchers []entity.Voucher) error {
+	total := 0.0
+	for _, voucher := range vouchers {
+		total += voucher.Amount * float64(voucher.MaxRedemptions)
+	}
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := transfer(tx, &entity.Transaction{
+			From:              vouchers[0].SourceWalletID,
+			To:                entity.SystemVoucherWalletID,
+			Amount:            total,
+			Type:              entity.TransactionTypeVoucherIssue,
+			ExternalReference: vouchers[0].BatchID,
+		}, r.spendPriority)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&vouchers).
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("VoucherRepo - CreateVouchers - r.DB: %w", err)
+	}
+	return nil
+}
+
+// RedeemVoucher - marking the voucher as used by the wallet and crediting the wallet from the voucher wallet.
+// Frozen and closed wallets are refused.
+func (r *VoucherRepo) RedeemVoucher(ctx context.Context, walletId string, codeHash string, now time.Time) (*entity.Transaction, error) {
+	transaction := &entity.Transaction{
+		From: entity.SystemVoucherWalletID,
+		To:   walletId,
+		Type: entity.TransactionTypeVoucherRedeem,
+	}
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		// The wallet is checked first, so codes can't be probed without an active wallet
+		if _, err := lockActiveWallet(tx, walletId); err != nil {
+			return err
+		}
+
+		voucher := new(entity.Voucher)
+		err := tx.Model(voucher).
+			Where("code_hash = ?", codeHash).
+			For("UPDATE").
+			Select()
+		if errors.Is(err, pg.ErrNoRows) {
+			return entity.ErrVoucherNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !voucher.ExpiresAt.After(now) || voucher.RefundedAt != nil {
+			return entity.ErrVoucherExpired
+		}
+		if voucher.Redemptions >= voucher.MaxRedemptions {
+			return entity.ErrVoucherUsed
+		}
+		// Each wallet can redeem a multi-use voucher only once
+		res, err := tx.Model(&entity.VoucherRedemption{
+			VoucherID: voucher.ID,
+			WalletID:  walletId,
+			Time:      now,
+		}).
+			OnConflict("DO NOTHING").
+			Insert()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return entity.ErrVoucherUsed
+		}
+
+		voucher.Redemptions++
+		_, err = tx.Model(voucher).
+			Column("redemptions").
+			WherePK().
+			Update()
+		if err != nil {
+			return err
+		}
+
+		transaction.Amount = voucher.Amount
+		transaction.ExternalReference = voucher.BatchID
+		return moveFunds(tx, transaction)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("VoucherRepo - RedeemVoucher - r.DB: %w", err)
+	}
+	return transaction, nil
+}
+
+// LockRedemptions - taking the advisory lock of the redemptions of the wallet, it is released with the transaction.
+// It doesn't lock the wallet row, so transfers of the wallet aren't blocked by attempts.
+func (r *VoucherRepo) LockRedemptions(ctx context.Context, walletId string) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "voucher_redemptions:"+walletId)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("VoucherRepo - LockRedemptions - r.DB: %w", err)
+	}
+	return nil
+}
+
+// CountFailedRedemptions - counting failed redemption attempts of the wallet since the moment.
+func (r *VoucherRepo) CountFailedRedemptions(ctx context.Context, walletId string, since time.Time) (int, error) {
+	var count int
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		var err error
+		count, err = tx.Model(&entity.VoucherAttempt{}).
+			Where("wallet_id = ?", walletId).
+			Where("time > ?", since).
+			Count()
+		return err
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("VoucherRepo - CountFailedRedemptions - r.DB: %w", err)
+	}
+	return count, nil
+}
+
+// RecordFailedRedemption - storing a failed redemption attempt of the wallet.
+func (r *VoucherRepo) RecordFailedRedemption(ctx context.Context, walletId string, at time.Time) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&entity.VoucherAttempt{
+			WalletID: walletId,
+			Time:     at,
+		}).
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("VoucherRepo - RecordFailedRedemption - r.DB: %w", err)
+	}
+	return nil
+}
+
+// RefundExpiredVouchers - returning the value of unused redemptions of expired vouchers to the source wallets.
+// Returns the refunds, expired vouchers without unused redemptions are only marked refunded.
+func (r *VoucherRepo) RefundExpiredVouchers(ctx context.Context, now time.Time) ([]entity.Transaction, error) {
+	refunds := make([]entity.Transaction, 0)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		vouchers := make([]entity.Voucher, 0)
+		err := tx.Model(&vouchers).
+			Where("expires_at <= ?", now).
+			Where("refunded_at IS NULL").
+			Order("id ASC").
+			For("UPDATE SKIP LOCKED").
+			Select()
+		if err != nil {
+			return err
+		}
+
+		for i := range vouchers {
+			voucher := &vouchers[i]
+			unused := voucher.MaxRedemptions - voucher.Redemptions
+			if unused > 0 {
+				refund := entity.Transaction{
+					From:              entity.SystemVoucherWalletID,
+					To:                voucher.SourceWalletID,
+					Amount:            voucher.Amount * float64(unused),
+					Type:              entity.TransactionTypeVoucherRefund,
+					ExternalReference: voucher.BatchID,
+				}
+				if err := moveFunds(tx, &refund); err != nil {
+					return err
+				}
+				refunds = append(refunds, refund)
+			}
+
+			voucher.RefundedAt = &now
+			_, err := tx.Model(voucher).
+				Column("refunded_at").
+				WherePK().
+				Update()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("VoucherRepo - RefundExpiredVouchers - r.DB: %w", err)
+	}
+	return refunds, nil
+}
+
+// DeleteFailedRedemptions - deleting failed redemption attempts which are older than the moment.
+func (r *VoucherRepo) DeleteFailedRedemptions(ctx context.Context, before time.Time) error {
+	_, err := r.DB.Model(&entity.VoucherAttempt{}).
+		Where("time <= ?", before).
+		Delete()
+
+	if err != nil {
+		return fmt.Errorf("VoucherRepo - DeleteFailedRedemptions - r.DB: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+)
+
+func Test_RedeemVoucherToInactiveWallet(t *testing.T) {
+	pg := repotest.Postgres(t)
+	ctx := context.Background()
+
+	wallets := NewWalletRepo(pg, []string{entity.BucketPromo, entity.BucketMain})
+	vouchers := NewVoucherRepo(pg, []string{entity.BucketPromo, entity.BucketMain})
+
+	frozen, err := wallets.CreateNewWallet(ctx, &entity.Wallet{Balance: 100, Type: entity.WalletTypeStandard, Status: entity.WalletStatusActive})
+	require.NoError(t, err)
+	_, err = wallets.SetWalletStatus(ctx, frozen.ID, entity.WalletStatusFrozen)
+	require.NoError(t, err)
+	closed, err := wallets.CreateNewWallet(ctx, &entity.Wallet{Balance: 0, Type: entity.WalletTypeStandard, Status: entity.WalletStatusActive})
+	require.NoError(t, err)
+	_, err = wallets.SetWalletStatus(ctx, closed.ID, entity.WalletStatusClosed)
+	require.NoError(t, err)
+
+	// The wallet is refused before the code is looked up
+	_, err = vouchers.RedeemVoucher(ctx, frozen.ID, "unknown", time.Now())
+	require.ErrorIs(t, err, entity.ErrWalletFrozen)
+	_, err = vouchers.RedeemVoucher(ctx, closed.ID, "unknown", time.Now())
+	require.ErrorIs(t, err, entity.ErrWalletClosed)
+	_, err = vouchers.RedeemVoucher(ctx, entity.SystemVoucherWalletID, "unknown", time.Now())
+	require.ErrorIs(t, err, entity.ErrWalletNotFound)
+}
@@ -2,26 +2,42 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
 
 	"github.com/egor-denisov/wallet-infotecs/internal/entity"
 	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
 )
 
+// amountEpsilon - tolerance for float rounding errors in balance arithmetic.
+const amountEpsilon = 1e-9
+
 // WalletRepo -.
 type WalletRepo struct {
 	*postgres.Postgres
+	spendPriority []string
 }
 
 // NewWalletRepo -.
-func NewWalletRepo(pg *postgres.Postgres) *WalletRepo {
-	return &WalletRepo{pg}
+func NewWalletRepo(pg *postgres.Postgres, spendPriority []string) *WalletRepo {
+	return &WalletRepo{pg, spendPriority}
 }
 
 // CreateNewWallet - creating new wallet entry  in the db.
 func (r *WalletRepo) CreateNewWallet(ctx context.Context, wallet *entity.Wallet) (*entity.Wallet, error) {
-	_, err := r.DB.Model(wallet).
-		Insert()
+	// Movements of the wallet are reconciled with the balance it is created with
+	wallet.InitialBalance = wallet.Balance
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(wallet).
+			Insert()
+		return err
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("WalletRepo - CreateNewWallet - r.DB: %w", err)
@@ -29,87 +45,578 @@ func (r *WalletRepo) CreateNewWallet(ctx context.Context, wallet *entity.Wallet)
 	return wallet, nil
 }
 
-// SendFunds - decreasing the balance of the sender and an increasing the receiver. Adding an entry to a transaction table.
+// SendFunds - spending the balance buckets of the sender in priority order and an increasing the receiver. Adding an entry to a transaction table.
 func (r *WalletRepo) SendFunds(ctx context.Context, transaction *entity.Transaction) error {
-	// Using the db transaction
-	tx, err := r.DB.Begin()
-    if err != nil {
-        return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
-    }
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return transfer(tx, transaction, r.spendPriority)
+	})
+
+	if err != nil {
+		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+	}
+	return nil
+}
 
-	defer tx.Close()
+// transfer - moving funds from a user wallet, its promo grants are spent according to the spend priority.
+func transfer(tx *pg.Tx, transaction *entity.Transaction, spendPriority []string) error {
+	// Locking both wallets in the id order, so opposite transfers between them don't deadlock
+	wallets := make([]entity.Wallet, 0, 2)
+	err := tx.Model(&wallets).
+		Where("id IN (?)", pg.In([]string{transaction.From, transaction.To})).
+		Order("id ASC").
+		For("UPDATE").
+		Select()
+	if err != nil {
+		return err
+	}
+	var sender, receiver *entity.Wallet
+	for i := range wallets {
+		switch wallets[i].ID {
+		case transaction.From:
+			sender = &wallets[i]
+		case transaction.To:
+			receiver = &wallets[i]
+		}
+	}
+
+	// System wallets can't be a source of user transfers. If walletId is not found then return 404
+	if sender == nil || sender.IsSystem {
+		return entity.ErrWalletNotFound
+	}
+	if err := checkWalletActive(sender); err != nil {
+		return err
+	}
+	// Pockets exchange funds only with their parent wallet
+	if sender.ParentID != "" {
+		return entity.ErrPocketTransfer
+	}
+	// The sender is locked, so a concurrent transfer with the reference is either committed or waits for this one
+	if transaction.UniqueReference {
+		sent, err := tx.Model((*entity.Transaction)(nil)).
+			Where("from_wallet_id = ?", transaction.From).
+			Where("external_reference = ?", transaction.ExternalReference).
+			Where("type = ?", entity.TransactionTypeTransfer).
+			Exists()
+		if err != nil {
+			return err
+		}
+		if sent {
+			return entity.ErrDuplicateReference
+		}
+	}
+
+	if receiver == nil {
+		return entity.ErrReceiverNotFound
+	}
+	if err := checkWalletActive(receiver); err != nil {
+		return err
+	}
+	if receiver.ParentID != "" {
+		return entity.ErrPocketTransfer
+	}
+	// Checking the balance before the db constraint does, its violation is not a known error
+	if sender.Balance-transaction.Amount < -sender.CreditLimit-amountEpsilon {
+		return entity.ErrInsufficientFunds
+	}
+
+	if err := spendPromo(tx, sender, transaction.Amount, spendPriority); err != nil {
+		return err
+	}
+
+	return moveFunds(tx, transaction)
+}
+
+// checkWalletActive - frozen and closed wallets can't take part in user transfers.
+func checkWalletActive(wallet *entity.Wallet) error {
+	switch wallet.Status {
+	case entity.WalletStatusFrozen:
+		return entity.ErrWalletFrozen
+	case entity.WalletStatusClosed:
+		return entity.ErrWalletClosed
+	}
+	return nil
+}
+
+// lockActiveWallet - locking the user wallet till the end of the transaction and getting it.
+// Like in transfers, system wallets are not found, frozen and closed wallets are refused.
+func lockActiveWallet(tx *pg.Tx, walletId string) (*entity.Wallet, error) {
+	wallet := new(entity.Wallet)
+	err := tx.Model(wallet).
+		Where("id = ?", walletId).
+		Where("NOT is_system").
+		For("UPDATE").
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, entity.ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, checkWalletActive(wallet)
+}
+
+// promoRemains - getting the unspent remains of the promo grants which are not swept yet.
+func promoRemains(tx *pg.Tx, walletId string) (float64, error) {
+	var remains float64
+	err := tx.Model(&entity.PromoGrant{}).
+		ColumnExpr("COALESCE(SUM(remaining), 0)").
+		Where("wallet_id = ?", walletId).
+		Where("expired_at IS NULL").
+		Select(&remains)
+
+	return remains, err
+}
+
+// spendPromo - decreasing remains of the promo grants according to the spend priority.
+// The rest of the amount is taken from the main bucket.
+func spendPromo(tx *pg.Tx, wallet *entity.Wallet, amount float64, spendPriority []string) error {
+	grants := make([]entity.PromoGrant, 0)
+	err := tx.Model(&grants).
+		Where("wallet_id = ?", wallet.ID).
+		Where("remaining > 0").
+		Where("expired_at IS NULL").
+		Order("expires_at ASC", "id ASC").
+		For("UPDATE").
+		Select()
+
+	if err != nil || len(grants) == 0 {
+		return err
+	}
+	// Grants which are expired but not swept yet are still excluded from the main bucket
+	main := wallet.Balance
+	for _, grant := range grants {
+		main -= grant.Remaining
+	}
+
+	now := time.Now()
+	left := amount
+	for _, bucket := range spendPriority {
+		switch bucket {
+		case entity.BucketMain:
+			// The credit line is a part of the main bucket
+			left -= math.Min(left, math.Max(main+wallet.CreditLimit, 0))
+		case entity.BucketPromo:
+			for i := range grants {
+				if left <= 0 {
+					break
+				}
+				if !grants[i].ExpiresAt.After(now) {
+					continue
+				}
+
+				spent := math.Min(left, grants[i].Remaining)
+				grants[i].Remaining -= spent
+				left -= spent
+
+				_, err := tx.Model(&grants[i]).
+					Column("remaining").
+					WherePK().
+					Update()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	// Expired promo can't be spent, so the balance check alone isn't enough
+	if left > amountEpsilon {
+		return entity.ErrInsufficientFunds
+	}
+	return nil
+}
+
+// moveFunds - decreasing the balance of the sender and an increasing the receiver. Adding an entry to a transaction table
+// and events of the user wallets, which are delivered to subscribers after the commit.
+func moveFunds(tx *pg.Tx, transaction *entity.Transaction) error {
 	// Decreasing the balance of the sender
-	res, err := r.DB.Model(&entity.Wallet{}).
+	sender := new(entity.Wallet)
+	res, err := tx.Model(sender).
 		Set("balance = balance - ?", transaction.Amount).
+		Set("version = version + 1").
+		Set("event_sequence = event_sequence + 1").
 		Where("id = ?", transaction.From).
+		Returning("balance, is_system, event_sequence").
 		Update()
-	// If error then rollback the transaction
 	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+		return err
 	}
-	// If walletId is not found then return 404
 	if res.RowsAffected() == 0 {
-		tx.Rollback()
 		return entity.ErrWalletNotFound
 	}
 	// Increasing the balance of the receiver
-	res, err = r.DB.Model(&entity.Wallet{}).
+	receiver := new(entity.Wallet)
+	res, err = tx.Model(receiver).
 		Set("balance = balance + ?", transaction.Amount).
+		Set("version = version + 1").
+		Set("event_sequence = event_sequence + 1").
 		Where("id = ?", transaction.To).
+		Returning("balance, is_system, event_sequence").
 		Update()
-	// If error or walletId is not found then rollback the transaction
-	if err != nil || res.RowsAffected() == 0 {
-		tx.Rollback()
-		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+	if err != nil {
+		return err
 	}
-	// Adding an entry to a transaction table
-	_, err = r.DB.Model(transaction).
+	if res.RowsAffected() == 0 {
+		return entity.ErrReceiverNotFound
+	}
+	// Adding an entry to a transaction table with the balances after it, the chain job links it to the chain later
+	transaction.FromBalanceAfter = sender.Balance
+	transaction.ToBalanceAfter = receiver.Balance
+	_, err = tx.Model(transaction).
+		Returning("*").
 		Insert()
-	
 	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+		return err
 	}
-	// Make commit
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+
+	// Transfers between user wallets are incoming and outgoing, movements to system wallets only change the balance.
+	// The sequences are taken under the locks of the wallet rows, so they follow the commit order of the events
+	outgoing, incoming := entity.WalletEventBalanceChanged, entity.WalletEventBalanceChanged
+	if transaction.Type == entity.TransactionTypeTransfer {
+		outgoing, incoming = entity.WalletEventOutgoingTransfer, entity.WalletEventIncomingTransfer
 	}
-	return nil
+	if !sender.IsSystem {
+		err = addWalletEvent(tx, &entity.WalletEvent{
+			WalletID:        transaction.From,
+			Sequence:        sender.EventSequence,
+			Type:            outgoing,
+			Time:            transaction.Time,
+			Amount:          -transaction.Amount,
+			Balance:         sender.Balance,
+			Counterparty:    transaction.To,
+			TransactionType: transaction.Type,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if !receiver.IsSystem {
+		err = addWalletEvent(tx, &entity.WalletEvent{
+			WalletID:        transaction.To,
+			Sequence:        receiver.EventSequence,
+			Type:            incoming,
+			Time:            transaction.Time,
+			Amount:          transaction.Amount,
+			Balance:         receiver.Balance,
+			Counterparty:    transaction.From,
+			TransactionType: transaction.Type,
+		})
+	}
+
+	return err
+}
+
+// addWalletEvent - storing the event, queueing its webhook deliveries and notifying the listeners of all app instances,
+// the notification is sent on commit.
+func addWalletEvent(tx *pg.Tx, event *entity.WalletEvent) error {
+	_, err := tx.Model(event).
+		Returning("id").
+		Insert()
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := addWebhookDeliveries(tx, event, payload); err != nil {
+		return err
+	}
+	_, err = tx.Exec("SELECT pg_notify(?, ?)", walletEventsChannel, string(payload))
+
+	return err
 }
 
-// GetWalletHistoryById - getting all transaction records from the user with the walletId.
-func (r *WalletRepo) GetWalletHistoryById(ctx context.Context, walletId string) ([]entity.Transaction, error) {
+// GetWalletHistoryById - getting all transaction records from the user with the walletId,
+// in the transaction of the context if there is one.
+func (r *WalletRepo) GetWalletHistoryById(ctx context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
 	transactions := make([]entity.Transaction, 0)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return selectHistory(tx, walletId, filter, &transactions)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - r.DB: %w", err)
+	}
+	return transactions, nil
+}
+
+// selectHistory - selecting the transactions of the wallet matching the filter.
+func selectHistory(tx *pg.Tx, walletId string, filter entity.HistoryFilter, transactions *[]entity.Transaction) error {
 	// If walletId is not found or error, return error
-	count, err := r.DB.Model(&entity.Wallet{}).
+	count, err := tx.Model(&entity.Wallet{}).
 		Where("id = ?", walletId).
 		SelectAndCount()
-	
-	if err != nil || count == 0 {
-		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - r.DB: %w", err)
-	}
 
-	err = r.DB.Model(&transactions).
-		Where("from_wallet_id = ?", walletId).
-		WhereOr("to_wallet_id = ?", walletId).
-		Select()
-		
 	if err != nil {
-		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - r.DB: %w", err)
+		return err
 	}
-	return transactions, nil
+	if count == 0 {
+		return entity.ErrWalletNotFound
+	}
+
+	query := tx.Model(transactions).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.Where("from_wallet_id = ?", walletId).
+				WhereOr("to_wallet_id = ?", walletId)
+			// Adding movements of the pockets if it is specified
+			if filter.IncludePockets {
+				pockets := tx.Model((*entity.Wallet)(nil)).
+					Column("id").
+					Where("parent_id = ?", walletId)
+
+				q = q.WhereOr("from_wallet_id IN (?)", pockets).
+					WhereOr("to_wallet_id IN (?)", pockets)
+			}
+			return q, nil
+		})
+	// Searching by external reference if it is specified
+	if filter.ExternalReference != "" {
+		query = query.Where("external_reference = ?", filter.ExternalReference)
+	}
+	// Limiting the period if it is specified
+	if !filter.From.IsZero() {
+		query = query.Where("time >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("time < ?", filter.To)
+	}
+
+	return query.Order("time ASC").
+		Select()
 }
 
-// GetWalletById - getting wallet info by walletId.
+// GetWalletById - getting wallet info by walletId, in the transaction of the context if there is one.
 func (r *WalletRepo) GetWalletById(ctx context.Context, walletId string) (*entity.Wallet, error) {
 	wallet := new(entity.Wallet)
-	err := r.DB.Model(wallet).
-		Where("id = ?", walletId).
-		Select()
-		
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(wallet).
+			Where("id = ?", walletId).
+			Select()
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, fmt.Errorf("WalletRepo - GetWalletById - r.DB: %w", entity.ErrWalletNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("WalletRepo - GetWalletById - r.DB: %w", err)
 	}
 	return wallet, nil
-}
\ No newline at end of file
+}
+
+// SetCreditLimit - changing the credit limit of the wallet, it can't be lower than the current debt.
+func (r *WalletRepo) SetCreditLimit(ctx context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	wallet := new(entity.Wallet)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := tx.Model(wallet).
+			Where("id = ?", walletId).
+			Where("NOT is_system").
+			For("UPDATE").
+			Select()
+		if errors.Is(err, pg.ErrNoRows) {
+			return entity.ErrWalletNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// The credit line covers only the main bucket, the promo remains are swept on their expiry
+		remains, err := promoRemains(tx, walletId)
+		if err != nil {
+			return err
+		}
+		if wallet.Balance-remains < -creditLimit {
+			return entity.ErrCreditLimitTooLow
+		}
+
+		wallet.CreditLimit = creditLimit
+		wallet.Version++
+		_, err = tx.Model(wallet).
+			Set("credit_limit = ?", creditLimit).
+			Set("version = version + 1").
+			Where("id = ?", walletId).
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - SetCreditLimit - r.DB: %w", err)
+	}
+	return wallet, nil
+}
+
+// GetPromoGrants - getting unspent promo grants of the wallet ordered by expiration,
+// in the transaction of the context if there is one.
+func (r *WalletRepo) GetPromoGrants(ctx context.Context, walletId string) ([]entity.PromoGrant, error) {
+	grants := make([]entity.PromoGrant, 0)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(&grants).
+			Where("wallet_id = ?", walletId).
+			Where("remaining > 0").
+			Where("expired_at IS NULL").
+			Order("expires_at ASC", "id ASC").
+			Select()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetPromoGrants - r.DB: %w", err)
+	}
+	return grants, nil
+}
+
+// SetWalletStatus - changing the status of the wallet together with its pockets.
+// Closing sweeps the pockets into the wallet, the resulting balance must be zero.
+func (r *WalletRepo) SetWalletStatus(ctx context.Context, walletId string, status string) (*entity.Wallet, error) {
+	wallet := new(entity.Wallet)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := tx.Model(wallet).
+			Where("id = ?", walletId).
+			Where("NOT is_system").
+			For("UPDATE").
+			Select()
+		if errors.Is(err, pg.ErrNoRows) {
+			return entity.ErrWalletNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if wallet.Status == entity.WalletStatusClosed {
+			return entity.ErrWalletClosed
+		}
+		// Pockets are locked after the parent in the same order as in pocket transfers
+		pockets := make([]entity.Wallet, 0)
+		err = tx.Model(&pockets).
+			Where("parent_id = ?", walletId).
+			Where("status <> ?", entity.WalletStatusClosed).
+			Order("id ASC").
+			For("UPDATE").
+			Select()
+		if err != nil {
+			return err
+		}
+
+		if status == entity.WalletStatusClosed {
+			for _, pocket := range pockets {
+				if pocket.Balance <= 0 {
+					continue
+				}
+
+				err := moveFunds(tx, &entity.Transaction{
+					From:   pocket.ID,
+					To:     walletId,
+					Amount: pocket.Balance,
+					Type:   entity.TransactionTypePocketTransfer,
+				})
+				if err != nil {
+					return err
+				}
+				wallet.Balance += pocket.Balance
+				wallet.Version++
+			}
+			if math.Abs(wallet.Balance) > amountEpsilon {
+				return entity.ErrWalletNotEmpty
+			}
+		}
+
+		wallet.Status = status
+		wallet.Version++
+		_, err = tx.Model(&entity.Wallet{}).
+			Set("status = ?", status).
+			Set("version = version + 1").
+			WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+				return q.Where("id = ?", walletId).
+					WhereOr("parent_id = ?", walletId), nil
+			}).
+			Where("status <> ?", entity.WalletStatusClosed).
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - SetWalletStatus - r.DB: %w", err)
+	}
+	return wallet, nil
+}
+
+// GetPockets - getting open pockets of the wallet, in the transaction of the context if there is one.
+func (r *WalletRepo) GetPockets(ctx context.Context, walletId string) ([]entity.Wallet, error) {
+	pockets := make([]entity.Wallet, 0)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(&pockets).
+			Where("parent_id = ?", walletId).
+			Where("status <> ?", entity.WalletStatusClosed).
+			Order("created_at ASC", "id ASC").
+			Select()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetPockets - r.DB: %w", err)
+	}
+	return pockets, nil
+}
+
+// GetWalletVersion - getting the version of the wallet, the wallet is locked till the end of the transaction.
+func (r *WalletRepo) GetWalletVersion(ctx context.Context, walletId string) (int64, error) {
+	wallet := new(entity.Wallet)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(wallet).
+			Column("version").
+			Where("id = ?", walletId).
+			Where("NOT is_system").
+			For("UPDATE").
+			Select()
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return 0, fmt.Errorf("WalletRepo - GetWalletVersion - r.DB: %w", entity.ErrWalletNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - GetWalletVersion - r.DB: %w", err)
+	}
+	return wallet.Version, nil
+}
+
+// GetBalanceAt - getting the balance of the wallet at the moment. It is the balance after the last transaction made
+// before the moment and after the nearest end-of-day snapshot, or the balance of the snapshot if there are no such
+// transactions. Without a snapshot it is the current balance without the later movements. Read in the transaction
+// of the context if there is one.
+func (r *WalletRepo) GetBalanceAt(ctx context.Context, walletId string, at time.Time) (float64, error) {
+	var balance float64
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.QueryOneContext(ctx, pg.Scan(&balance), `
+		SELECT COALESCE(
+			(
+				SELECT CASE WHEN t.from_wallet_id = w.id THEN t.from_balance_after ELSE t.to_balance_after END
+				FROM transactions AS t
+				WHERE (t.from_wallet_id = w.id OR t.to_wallet_id = w.id) AND t.time < ?1 AND t.time >= s.day_end
+				ORDER BY t.time DESC, t.id DESC
+				LIMIT 1
+			),
+			s.balance,
+			w.balance - (
+				SELECT COALESCE(SUM(CASE WHEN t.to_wallet_id = w.id THEN t.amount ELSE -t.amount END), 0)
+				FROM transactions AS t
+				WHERE (t.from_wallet_id = w.id OR t.to_wallet_id = w.id) AND t.time >= ?1
+			)
+		)
+		FROM wallets AS w
+		LEFT JOIN LATERAL (
+			SELECT (day + 1)::timestamp AT TIME ZONE 'UTC' AS day_end, balance
+			FROM balance_snapshots
+			WHERE wallet_id = w.id AND (day + 1)::timestamp AT TIME ZONE 'UTC' <= ?1
+			ORDER BY day DESC
+			LIMIT 1
+		) AS s ON true
+		WHERE w.id = ?0`,
+			walletId, at)
+		return err
+	})
+
+	if errors.Is(err, pg.ErrNoRows) {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt - r.DB: %w", entity.ErrWalletNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt - r.DB: %w", err)
+	}
+	return balance, nil
+}
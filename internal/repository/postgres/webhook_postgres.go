@@ -0,0 +1,214 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// WebhookRepo -.
+type WebhookRepo struct {
+	*postgres.Postgres
+}
+
+// NewWebhookRepo -.
+func NewWebhookRepo(pg *postgres.Postgres) *WebhookRepo {
+	return &WebhookRepo{pg}
+}
+
+// CreateWebhook - storing a subscription of a partner system to the events of the wallet.
+func (r *WebhookRepo) CreateWebhook(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		exists, err := tx.Model(&entity.Wallet{}).
+			Where("id = ?", webhook.WalletID).
+			Where("NOT is_system").
+			Exists()
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entity.ErrWalletNotFound
+		}
+
+		_, err = tx.Model(webhook).
+			Returning("*").
+			Insert()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - CreateWebhook - r.DB: %w", err)
+	}
+	return webhook, nil
+}
+
+// GetWebhooks - getting subscriptions of the wallet without their secrets.
+func (r *WebhookRepo) GetWebhooks(ctx context.Context, walletId string) ([]entity.Webhook, error) {
+	webhooks := make([]entity.Webhook, 0)
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return tx.Model(&webhooks).
+			ExcludeColumn("secret").
+			Where("wallet_id = ?", walletId).
+			Order("id ASC").
+			Select()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - GetWebhooks - r.DB: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook - deleting the subscription with all its deliveries.
+func (r *WebhookRepo) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		res, err := tx.Model(&entity.Webhook{}).
+			Where("id = ?", webhookId).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return entity.ErrWebhookNotFound
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("WebhookRepo - DeleteWebhook - r.DB: %w", err)
+	}
+	return nil
+}
+
+// GetDeliveries - getting deliveries of the subscription, the latest first. Empty status means any status.
+func (r *WebhookRepo) GetDeliveries(ctx context.Context, webhookId int64, status string) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		exists, err := tx.Model(&entity.Webhook{}).
+			Where("id = ?", webhookId).
+			Exists()
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entity.ErrWebhookNotFound
+		}
+
+		query := tx.Model(&deliveries).
+			Where("webhook_id = ?", webhookId)
+		if status != "" {
+			query = query.Where("status = ?", status)
+		}
+		return query.Order("id DESC").Select()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - GetDeliveries - r.DB: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ClaimDeliveries - getting pending deliveries which are due together with their subscriptions.
+// The claimed deliveries are postponed by the lease, so other app instances don't send them at the same time.
+// If the instance stops before recording the result, the delivery is sent again after the lease.
+func (r *WebhookRepo) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := tx.Model(&deliveries).
+			Relation("Webhook").
+			Where("webhook_delivery.status = ?", entity.WebhookDeliveryPending).
+			Where("webhook_delivery.next_attempt_at <= ?", now).
+			Order("webhook_delivery.next_attempt_at ASC").
+			Limit(limit).
+			For("UPDATE OF webhook_delivery SKIP LOCKED").
+			Select()
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		_, err = tx.Model(&entity.WebhookDelivery{}).
+			Set("next_attempt_at = ?", now.Add(lease)).
+			Where("id IN (?)", pg.In(ids)).
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - ClaimDeliveries - r.DB: %w", err)
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery - storing the result of a delivery attempt.
+func (r *WebhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(delivery).
+			Column("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+			WherePK().
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("WebhookRepo - UpdateDelivery - r.DB: %w", err)
+	}
+	return nil
+}
+
+// ReplayDelivery - returning a failed delivery to the queue with a fresh number of attempts.
+func (r *WebhookRepo) ReplayDelivery(ctx context.Context, webhookId int64, deliveryId int64, now time.Time) (*entity.WebhookDelivery, error) {
+	delivery := new(entity.WebhookDelivery)
+
+	err := r.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		err := tx.Model(delivery).
+			Where("id = ?", deliveryId).
+			Where("webhook_id = ?", webhookId).
+			For("UPDATE").
+			Select()
+		if errors.Is(err, pg.ErrNoRows) {
+			return entity.ErrDeliveryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if delivery.Status != entity.WebhookDeliveryFailed {
+			return entity.ErrDeliveryNotReplayable
+		}
+
+		delivery.Status = entity.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = now
+		_, err = tx.Model(delivery).
+			Column("status", "attempts", "next_attempt_at").
+			WherePK().
+			Update()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - ReplayDelivery - r.DB: %w", err)
+	}
+	return delivery, nil
+}
+
+// addWebhookDeliveries - queueing the event for the subscriptions of its wallet.
+// The deliveries are written in the transaction of the operation, so an event is never lost or sent for a rolled back operation.
+func addWebhookDeliveries(tx *pg.Tx, event *entity.WalletEvent, payload []byte) error {
+	_, err := tx.Exec(`INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT id, ?, ?, ? FROM webhooks WHERE wallet_id = ? AND ? = ANY(event_types)`,
+		event.ID, event.Type, string(payload), event.WalletID, event.Type)
+
+	return err
+}
@@ -0,0 +1,354 @@
+// Package repotest contains conformance tests shared by all implementations of the repositories.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// unknownWalletId - id which is never generated by the repositories.
+const unknownWalletId = "not-existing-wallet"
+
+// WalletRepo - running the conformance tests against the repository created by newRepo with the outbox
+// stored together with it. The repository may be shared by the tests, each of them uses its own wallets.
+func WalletRepo(t *testing.T, newRepo func(t *testing.T) (usecase.WalletRepo, usecase.OutboxRepo)) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r usecase.WalletRepo, o usecase.OutboxRepo)
+	}{
+		{"Create and get", testCreateAndGet},
+		{"Not found", testNotFound},
+		{"Transfer", testTransfer},
+		{"Insufficient funds", testInsufficientFunds},
+		{"Missing receiver", testMissingReceiver},
+		{"History", testHistory},
+		{"Unique reference", testUniqueReference},
+		{"Read snapshot", testReadSnapshot},
+		{"Read in transaction", testReadInTransaction},
+		{"Concurrent transfers", testConcurrentTransfers},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, o := newRepo(t)
+			test.test(t, r, o)
+		})
+	}
+}
+
+// Postgres - connecting to the database from the TEST_POSTGRES_URL env and migrating it,
+// the test is skipped if the env is not set.
+func Postgres(t *testing.T) *postgres.Postgres {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+
+	pg, err := postgres.New(url)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pg.DB.Close()
+	})
+
+	_, file, _, _ := runtime.Caller(0)
+	migrations, err := postgres.LoadMigrations(os.DirFS(filepath.Join(filepath.Dir(file), "../../../migrations")))
+	require.NoError(t, err)
+	_, err = pg.MigrateUp(context.Background(), migrations)
+	require.NoError(t, err)
+
+	return pg
+}
+
+func testCreateAndGet(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	ctx := context.Background()
+
+	created := createWallet(t, r, 100)
+	require.NotEmpty(t, created.ID)
+	require.Equal(t, 100.0, created.Balance)
+
+	wallet, err := r.GetWalletById(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, wallet.ID)
+	require.Equal(t, 100.0, wallet.Balance)
+	require.Equal(t, entity.WalletTypeStandard, wallet.Type)
+	require.Equal(t, entity.WalletStatusActive, wallet.Status)
+
+	// Ids are unique
+	other := createWallet(t, r, 100)
+	require.NotEqual(t, created.ID, other.ID)
+}
+
+func testNotFound(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	ctx := context.Background()
+
+	_, err := r.GetWalletById(ctx, unknownWalletId)
+	require.ErrorIs(t, err, entity.ErrWalletNotFound)
+
+	_, err = r.GetWalletHistoryById(ctx, unknownWalletId, entity.HistoryFilter{})
+	require.ErrorIs(t, err, entity.ErrWalletNotFound)
+
+	err = r.SendFunds(ctx, newTransaction(unknownWalletId, createWallet(t, r, 100).ID, 10))
+	require.ErrorIs(t, err, entity.ErrWalletNotFound)
+}
+
+func testTransfer(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	sender := createWallet(t, r, 100)
+	receiver := createWallet(t, r, 100)
+
+	transaction := newTransaction(sender.ID, receiver.ID, 30)
+	require.NoError(t, r.SendFunds(context.Background(), transaction))
+	require.False(t, transaction.Time.IsZero())
+
+	requireBalance(t, r, sender.ID, 70)
+	requireBalance(t, r, receiver.ID, 130)
+
+	// The whole balance can be sent
+	require.NoError(t, r.SendFunds(context.Background(), newTransaction(sender.ID, receiver.ID, 70)))
+	requireBalance(t, r, sender.ID, 0)
+	requireBalance(t, r, receiver.ID, 200)
+}
+
+func testInsufficientFunds(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	sender := createWallet(t, r, 100)
+	receiver := createWallet(t, r, 100)
+
+	err := r.SendFunds(context.Background(), newTransaction(sender.ID, receiver.ID, 100.01))
+	require.ErrorIs(t, err, entity.ErrInsufficientFunds)
+
+	requireBalance(t, r, sender.ID, 100)
+	requireBalance(t, r, receiver.ID, 100)
+	requireHistory(t, r, sender.ID, 0)
+}
+
+func testMissingReceiver(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	sender := createWallet(t, r, 100)
+
+	err := r.SendFunds(context.Background(), newTransaction(sender.ID, unknownWalletId, 10))
+	require.ErrorIs(t, err, entity.ErrReceiverNotFound)
+
+	requireBalance(t, r, sender.ID, 100)
+	requireHistory(t, r, sender.ID, 0)
+}
+
+func testHistory(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	ctx := context.Background()
+	first := createWallet(t, r, 100)
+	second := createWallet(t, r, 100)
+	third := createWallet(t, r, 100)
+
+	outgoing := newTransaction(first.ID, second.ID, 30)
+	outgoing.ExternalReference = "INV-2024-0042"
+	require.NoError(t, r.SendFunds(ctx, outgoing))
+	require.NoError(t, r.SendFunds(ctx, newTransaction(second.ID, first.ID, 10)))
+	require.NoError(t, r.SendFunds(ctx, newTransaction(third.ID, second.ID, 5)))
+
+	// Incoming and outgoing transfers in time order
+	history := requireHistory(t, r, first.ID, 2)
+	require.Equal(t, first.ID, history[0].From)
+	require.Equal(t, second.ID, history[0].To)
+	require.Equal(t, 30.0, history[0].Amount)
+	require.Equal(t, "INV-2024-0042", history[0].ExternalReference)
+	require.Equal(t, second.ID, history[1].From)
+	require.Equal(t, first.ID, history[1].To)
+	require.Equal(t, 10.0, history[1].Amount)
+	require.False(t, history[1].Time.Before(history[0].Time))
+
+	requireHistory(t, r, second.ID, 3)
+	requireHistory(t, r, third.ID, 1)
+
+	// Filtering by the external reference and the period
+	filtered, err := r.GetWalletHistoryById(ctx, second.ID, entity.HistoryFilter{ExternalReference: "INV-2024-0042"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+
+	filtered, err = r.GetWalletHistoryById(ctx, second.ID, entity.HistoryFilter{From: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, filtered, 0)
+}
+
+func testUniqueReference(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	ctx := context.Background()
+	sender := createWallet(t, r, 100)
+	receiver := createWallet(t, r, 100)
+
+	first := newTransaction(sender.ID, receiver.ID, 30)
+	first.ExternalReference = "E2E-1"
+	require.NoError(t, r.SendFunds(ctx, first))
+
+	// The reference may be repeated by ordinary transfers, but not by unique ones
+	repeated := newTransaction(sender.ID, receiver.ID, 10)
+	repeated.ExternalReference = "E2E-1"
+	require.NoError(t, r.SendFunds(ctx, repeated))
+
+	duplicate := newTransaction(sender.ID, receiver.ID, 10)
+	duplicate.ExternalReference = "E2E-1"
+	duplicate.UniqueReference = true
+	require.ErrorIs(t, r.SendFunds(ctx, duplicate), entity.ErrDuplicateReference)
+
+	// The reference is unique per sender
+	reverse := newTransaction(receiver.ID, sender.ID, 10)
+	reverse.ExternalReference = "E2E-1"
+	reverse.UniqueReference = true
+	require.NoError(t, r.SendFunds(ctx, reverse))
+
+	requireBalance(t, r, sender.ID, 70)
+	requireHistory(t, r, sender.ID, 3)
+}
+
+func testReadSnapshot(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	ctx := context.Background()
+	sender := createWallet(t, r, 100)
+	receiver := createWallet(t, r, 100)
+	require.NoError(t, r.SendFunds(ctx, newTransaction(sender.ID, receiver.ID, 30)))
+	history := requireHistory(t, r, sender.ID, 1)
+
+	// The reads made in the snapshot join it
+	err := r.ReadSnapshot(ctx, func(ctx context.Context) error {
+		balance, err := r.GetBalanceAt(ctx, sender.ID, history[0].Time)
+		require.NoError(t, err)
+		require.Equal(t, 100.0, balance)
+
+		transactions, err := r.GetWalletHistoryById(ctx, sender.ID, entity.HistoryFilter{From: history[0].Time})
+		require.NoError(t, err)
+		require.Len(t, transactions, 1)
+
+		_, err = r.GetWalletHistoryById(ctx, "eb376add88bf8e70f80787266a0801d5", entity.HistoryFilter{})
+		require.ErrorIs(t, err, entity.ErrWalletNotFound)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func testReadInTransaction(t *testing.T, r usecase.WalletRepo, o usecase.OutboxRepo) {
+	ctx := context.Background()
+	sender := createWallet(t, r, 100)
+	receiver := createWallet(t, r, 100)
+
+	// The reads made in the transaction see its own changes
+	err := o.Atomic(ctx, func(ctx context.Context) error {
+		if err := r.SendFunds(ctx, newTransaction(sender.ID, receiver.ID, 30)); err != nil {
+			return err
+		}
+
+		wallet, err := r.GetWalletById(ctx, sender.ID)
+		require.NoError(t, err)
+		require.Equal(t, 70.0, wallet.Balance)
+
+		history, err := r.GetWalletHistoryById(ctx, sender.ID, entity.HistoryFilter{})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		return nil
+	})
+	require.NoError(t, err)
+
+	requireBalance(t, r, sender.ID, 70)
+}
+
+func testConcurrentTransfers(t *testing.T, r usecase.WalletRepo, _ usecase.OutboxRepo) {
+	ctx := context.Background()
+
+	wallets := make([]*entity.Wallet, 4)
+	for i := range wallets {
+		wallets[i] = createWallet(t, r, 10)
+	}
+
+	// Transfers in a ring in both directions, some of them fail on the balance check. Stores with optimistic
+	// concurrency may also refuse a change with a version conflict once their retries are exhausted, but deadlocks
+	// and other unknown errors must never reach the caller
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		failures  []error
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			from, to := i%len(wallets), (i+1)%len(wallets)
+			if i%2 == 1 {
+				from, to = to, from
+			}
+			err := r.SendFunds(ctx, newTransaction(wallets[from].ID, wallets[to].ID, float64(i%7+1)))
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, err)
+				return
+			}
+			succeeded++
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range failures {
+		if !errors.Is(err, entity.ErrInsufficientFunds) && !errors.Is(err, entity.ErrVersionConflict) {
+			t.Errorf("unexpected transfer error: %v", err)
+		}
+	}
+
+	// Money is neither lost nor created and each successful transfer is in the history of both wallets
+	total := 0.0
+	transfers := 0
+	for _, wallet := range wallets {
+		found, err := r.GetWalletById(ctx, wallet.ID)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, found.Balance, 0.0)
+		total += found.Balance
+
+		history, err := r.GetWalletHistoryById(ctx, wallet.ID, entity.HistoryFilter{})
+		require.NoError(t, err)
+		transfers += len(history)
+	}
+	require.InDelta(t, 40.0, total, 1e-9)
+	require.Positive(t, succeeded)
+	require.Equal(t, 2*succeeded, transfers)
+}
+
+func createWallet(t *testing.T, r usecase.WalletRepo, balance float64) *entity.Wallet {
+	wallet, err := r.CreateNewWallet(context.Background(), &entity.Wallet{
+		Balance: balance,
+		Type:    entity.WalletTypeStandard,
+		Status:  entity.WalletStatusActive,
+	})
+	require.NoError(t, err)
+
+	return wallet
+}
+
+func newTransaction(from string, to string, amount float64) *entity.Transaction {
+	return &entity.Transaction{
+		From:   from,
+		To:     to,
+		Amount: amount,
+		Type:   entity.TransactionTypeTransfer,
+	}
+}
+
+func requireBalance(t *testing.T, r usecase.WalletRepo, walletId string, balance float64) {
+	wallet, err := r.GetWalletById(context.Background(), walletId)
+	require.NoError(t, err)
+	require.InDelta(t, balance, wallet.Balance, 1e-9)
+}
+
+func requireHistory(t *testing.T, r usecase.WalletRepo, walletId string, length int) []entity.Transaction {
+	history, err := r.GetWalletHistoryById(context.Background(), walletId, entity.HistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, history, length)
+
+	return history
+}
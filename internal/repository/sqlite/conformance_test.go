@@ -0,0 +1,26 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/repository/repotest"
+	"github.com/egor-denisov/wallet-infotecs/internal/usecase"
+)
+
+func Test_WalletRepoConformance(t *testing.T) {
+	repotest.WalletRepo(t, func(t *testing.T) (usecase.WalletRepo, usecase.OutboxRepo) {
+		db, err := New(context.Background(), filepath.Join(t.TempDir(), "wallet.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			db.DB.Close()
+		})
+
+		return NewWalletRepo(db), NewOutboxRepo(db)
+	})
+}
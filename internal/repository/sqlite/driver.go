@@ -0,0 +1,8 @@
+//go:build sqlite
+
+package sqlite
+
+// The pure-Go driver is large, so it is only built into the binary with the sqlite tag:
+//
+//	go build -tags sqlite ./cmd/app
+import _ "modernc.org/sqlite"
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS domain_events;
+
+DROP TABLE IF EXISTS transactions;
+
+DROP TABLE IF EXISTS wallets;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS wallets
+(
+    id TEXT PRIMARY KEY,
+    balance REAL NOT NULL DEFAULT 0,
+    type TEXT NOT NULL DEFAULT 'standard',
+    annual_rate REAL NOT NULL DEFAULT 0 CHECK (annual_rate >= 0),
+    credit_limit REAL NOT NULL DEFAULT 0 CHECK (credit_limit >= 0),
+    status TEXT NOT NULL DEFAULT 'active',
+    is_system INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    CHECK (is_system OR balance >= -credit_limit)
+);
+
+CREATE TABLE IF NOT EXISTS transactions
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    time INTEGER NOT NULL,
+    from_wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    to_wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    amount REAL NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    external_reference TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL DEFAULT 'transfer'
+);
+
+CREATE INDEX IF NOT EXISTS transactions_from_wallet_id_time_idx ON transactions (from_wallet_id, time);
+
+CREATE INDEX IF NOT EXISTS transactions_to_wallet_id_time_idx ON transactions (to_wallet_id, time);
+
+CREATE INDEX IF NOT EXISTS transactions_external_reference_idx ON transactions (external_reference);
+
+CREATE TABLE IF NOT EXISTS domain_events
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    occurred_at INTEGER NOT NULL,
+    payload TEXT NOT NULL,
+    published_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS domain_events_unpublished_idx ON domain_events (id) WHERE published_at IS NULL;
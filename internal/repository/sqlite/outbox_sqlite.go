@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// OutboxRepo -.
+type OutboxRepo struct {
+	*SQLite
+}
+
+// NewOutboxRepo -.
+func NewOutboxRepo(s *SQLite) *OutboxRepo {
+	return &OutboxRepo{s}
+}
+
+// AddEvents - storing the events in the outbox, in the transaction of the use case if there is one.
+func (r *OutboxRepo) AddEvents(ctx context.Context, events ...entity.DomainEvent) error {
+	err := r.runInTransaction(ctx, func(tx *sql.Tx) error {
+		for _, event := range events {
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO domain_events (type, aggregate_id, occurred_at, payload) VALUES (?, ?, ?, ?)",
+				event.Type, event.AggregateID, event.OccurredAt.UnixNano(), string(event.Payload))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("OutboxRepo - AddEvents - r.DB: %w", err)
+	}
+	return nil
+}
+
+// LockUnpublishedEvents - getting the oldest unpublished events. The database has a single writer,
+// so the events can't be relayed concurrently while the transaction of the use case lasts.
+func (r *OutboxRepo) LockUnpublishedEvents(ctx context.Context, limit int) ([]entity.DomainEvent, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx,
+		"SELECT id, type, aggregate_id, occurred_at, payload FROM domain_events WHERE published_at IS NULL ORDER BY id ASC LIMIT ?",
+		limit)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepo - LockUnpublishedEvents - r.DB: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]entity.DomainEvent, 0)
+	for rows.Next() {
+		var (
+			event      entity.DomainEvent
+			occurredAt int64
+			payload    string
+		)
+		if err := rows.Scan(&event.ID, &event.Type, &event.AggregateID, &occurredAt, &payload); err != nil {
+			return nil, fmt.Errorf("OutboxRepo - LockUnpublishedEvents - rows.Scan: %w", err)
+		}
+		event.OccurredAt = fromUnix(occurredAt)
+		event.Payload = []byte(payload)
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("OutboxRepo - LockUnpublishedEvents - rows.Err: %w", err)
+	}
+	return events, nil
+}
+
+// MarkPublished - marking the events as published, they are not relayed again.
+func (r *OutboxRepo) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, at.UnixNano())
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	_, err := r.conn(ctx).ExecContext(ctx,
+		"UPDATE domain_events SET published_at = ? WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+")",
+		args...)
+
+	if err != nil {
+		return fmt.Errorf("OutboxRepo - MarkPublished - r.DB: %w", err)
+	}
+	return nil
+}
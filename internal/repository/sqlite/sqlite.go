@@ -0,0 +1,173 @@
+// Package sqlite implements the wallet repositories on an embedded SQLite database file,
+// for single-node deployments which run without Postgres.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/pkg/postgres"
+)
+
+// driverName - name of the database/sql driver registered by modernc.org/sqlite.
+const driverName = "sqlite"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// ErrNoDriver - the binary was built without the sqlite tag.
+var ErrNoDriver = errors.New("sqlite driver is not built in, build the app with -tags sqlite")
+
+// txKey - context key of the transaction started by Atomic.
+type txKey struct{}
+
+// conn - methods shared by the database and its transactions.
+type conn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SQLite -.
+type SQLite struct {
+	DB *sql.DB
+}
+
+// New - opening the database file and applying the embedded migrations. SQLite has a single writer,
+// so the app uses one connection and its transactions are serialized instead of failing on the busy database.
+func New(ctx context.Context, path string) (*SQLite, error) {
+	if !driverRegistered() {
+		return nil, ErrNoDriver
+	}
+
+	db, err := sql.Open(driverName, fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	s := &SQLite{DB: db}
+	if err := s.migrate(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Atomic - running fn in one transaction. Repositories join it through the context,
+// so a use case can combine several repository calls. Nested calls join the outer transaction.
+func (s *SQLite) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	return s.runInTransaction(ctx, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// ReadSnapshot - running fn in one transaction. The only connection serializes the transactions,
+// so the reads made with its context see one state of the database.
+func (s *SQLite) ReadSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.Atomic(ctx, fn)
+}
+
+// runInTransaction - running fn in the transaction of Atomic from the context or in a new one.
+func (s *SQLite) runInTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// conn - the transaction of Atomic from the context or the database. With the only connection
+// held by the transaction, reads inside it must use the transaction too.
+func (s *SQLite) conn(ctx context.Context) conn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return s.DB
+}
+
+// migrate - applying the embedded migrations which are not applied yet, edited applied migrations are refused.
+// The files are laid out like the postgres migrations.
+func (s *SQLite) migrate(ctx context.Context) error {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return err
+	}
+	migrations, err := postgres.LoadMigrations(files)
+	if err != nil {
+		return err
+	}
+
+	return s.runInTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS schema_migrations
+			(
+				version INTEGER PRIMARY KEY,
+				name TEXT NOT NULL,
+				checksum TEXT NOT NULL,
+				applied_at INTEGER NOT NULL
+			)`)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			var checksum string
+			err := tx.QueryRowContext(ctx, "SELECT checksum FROM schema_migrations WHERE version = ?", migration.Version).
+				Scan(&checksum)
+			if err == nil {
+				if checksum != migration.Checksum {
+					return fmt.Errorf("%w: %d_%s", postgres.ErrMigrationChanged, migration.Version, migration.Name)
+				}
+				continue
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)",
+				migration.Version, migration.Name, migration.Checksum, time.Now().UnixNano())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// driverRegistered - checking that the driver is built into the binary.
+func driverRegistered() bool {
+	for _, driver := range sql.Drivers() {
+		if driver == driverName {
+			return true
+		}
+	}
+	return false
+}
+
+// fromUnix - times are stored as unix nanoseconds, so they are compared and ordered as numbers.
+func fromUnix(nanos int64) time.Time {
+	return time.Unix(0, nanos)
+}
@@ -0,0 +1,16 @@
+//go:build !sqlite
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewWithoutDriver(t *testing.T) {
+	_, err := New(context.Background(), filepath.Join(t.TempDir(), "wallet.db"))
+	require.ErrorIs(t, err, ErrNoDriver)
+}
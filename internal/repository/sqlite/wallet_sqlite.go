@@ -0,0 +1,311 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/md5"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// amountEpsilon - tolerance for float rounding errors in balance arithmetic.
+const amountEpsilon = 1e-9
+
+// walletColumns - columns of the wallet in the order of getWallet.
+const walletColumns = "id, balance, type, annual_rate, credit_limit, status, created_at, version"
+
+// WalletRepo -.
+type WalletRepo struct {
+	*SQLite
+}
+
+// NewWalletRepo -.
+func NewWalletRepo(s *SQLite) *WalletRepo {
+	return &WalletRepo{s}
+}
+
+// CreateNewWallet - creating new wallet entry in the db.
+func (r *WalletRepo) CreateNewWallet(ctx context.Context, wallet *entity.Wallet) (*entity.Wallet, error) {
+	wallet.ID = makeUid()
+	wallet.CreatedAt = time.Now()
+	if wallet.Type == "" {
+		wallet.Type = entity.WalletTypeStandard
+	}
+	if wallet.Status == "" {
+		wallet.Status = entity.WalletStatusActive
+	}
+	wallet.Version = 1
+
+	_, err := r.conn(ctx).ExecContext(ctx,
+		"INSERT INTO wallets (id, balance, type, annual_rate, credit_limit, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		wallet.ID, wallet.Balance, wallet.Type, wallet.AnnualRate, wallet.CreditLimit, wallet.Status, wallet.CreatedAt.UnixNano())
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - CreateNewWallet - r.DB: %w", err)
+	}
+	return wallet, nil
+}
+
+// SendFunds - decreasing the balance of the sender and an increasing the receiver. Adding an entry to a transaction table.
+func (r *WalletRepo) SendFunds(ctx context.Context, transaction *entity.Transaction) error {
+	err := r.runInTransaction(ctx, func(tx *sql.Tx) error {
+		sender, err := getWallet(ctx, tx, transaction.From)
+		if err != nil {
+			return err
+		}
+		if err := checkWalletActive(sender); err != nil {
+			return err
+		}
+		// The connection is the only one, so no other transfer runs between the check and the insert
+		if transaction.UniqueReference {
+			var sent bool
+			err := tx.QueryRowContext(ctx,
+				"SELECT EXISTS (SELECT 1 FROM transactions WHERE from_wallet_id = ? AND external_reference = ? AND type = ?)",
+				transaction.From, transaction.ExternalReference, entity.TransactionTypeTransfer).Scan(&sent)
+			if err != nil {
+				return err
+			}
+			if sent {
+				return entity.ErrDuplicateReference
+			}
+		}
+		receiver, err := getWallet(ctx, tx, transaction.To)
+		if errors.Is(err, entity.ErrWalletNotFound) {
+			return entity.ErrReceiverNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkWalletActive(receiver); err != nil {
+			return err
+		}
+		// Checking the balance before the db constraint does, its violation is not a known error
+		if sender.Balance-transaction.Amount < -sender.CreditLimit-amountEpsilon {
+			return entity.ErrInsufficientFunds
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE wallets SET balance = balance - ?, version = version + 1 WHERE id = ?", transaction.Amount, transaction.From)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE wallets SET balance = balance + ?, version = version + 1 WHERE id = ?", transaction.Amount, transaction.To)
+		if err != nil {
+			return err
+		}
+
+		transaction.Time = time.Now()
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO transactions (time, from_wallet_id, to_wallet_id, amount, description, external_reference, type) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			transaction.Time.UnixNano(), transaction.From, transaction.To, transaction.Amount,
+			transaction.Description, transaction.ExternalReference, transaction.Type)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("WalletRepo - SendFunds - r.DB: %w", err)
+	}
+	return nil
+}
+
+// GetWalletHistoryById - getting all transaction records from the user with the walletId.
+// Wallets in sqlite have no pockets, so IncludePockets changes nothing.
+func (r *WalletRepo) GetWalletHistoryById(ctx context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
+	db := r.conn(ctx)
+	if _, err := getWallet(ctx, db, walletId); err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - r.DB: %w", err)
+	}
+
+	where := []string{"(from_wallet_id = ? OR to_wallet_id = ?)"}
+	args := []interface{}{walletId, walletId}
+	// Searching by external reference if it is specified
+	if filter.ExternalReference != "" {
+		where = append(where, "external_reference = ?")
+		args = append(args, filter.ExternalReference)
+	}
+	// Limiting the period if it is specified
+	if !filter.From.IsZero() {
+		where = append(where, "time >= ?")
+		args = append(args, filter.From.UnixNano())
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "time < ?")
+		args = append(args, filter.To.UnixNano())
+	}
+
+	rows, err := db.QueryContext(ctx,
+		"SELECT time, from_wallet_id, to_wallet_id, amount, description, external_reference, type FROM transactions WHERE "+
+			strings.Join(where, " AND ")+" ORDER BY time ASC, id ASC",
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - r.DB: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := make([]entity.Transaction, 0)
+	for rows.Next() {
+		var (
+			transaction entity.Transaction
+			at          int64
+		)
+		err := rows.Scan(&at, &transaction.From, &transaction.To, &transaction.Amount,
+			&transaction.Description, &transaction.ExternalReference, &transaction.Type)
+		if err != nil {
+			return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - rows.Scan: %w", err)
+		}
+		transaction.Time = fromUnix(at)
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletHistoryById - rows.Err: %w", err)
+	}
+	return transactions, nil
+}
+
+// GetWalletById - getting wallet info by walletId.
+func (r *WalletRepo) GetWalletById(ctx context.Context, walletId string) (*entity.Wallet, error) {
+	wallet, err := getWallet(ctx, r.conn(ctx), walletId)
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - GetWalletById - r.DB: %w", err)
+	}
+	return wallet, nil
+}
+
+// GetPromoGrants - wallets in sqlite don't get promo grants.
+func (r *WalletRepo) GetPromoGrants(ctx context.Context, walletId string) ([]entity.PromoGrant, error) {
+	return make([]entity.PromoGrant, 0), nil
+}
+
+// SetCreditLimit - changing the credit limit of the wallet, it can't be lower than the current debt.
+func (r *WalletRepo) SetCreditLimit(ctx context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	var wallet *entity.Wallet
+	err := r.runInTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		wallet, err = getWallet(ctx, tx, walletId)
+		if err != nil {
+			return err
+		}
+		if wallet.Balance < -creditLimit {
+			return entity.ErrCreditLimitTooLow
+		}
+
+		wallet.CreditLimit = creditLimit
+		wallet.Version++
+		_, err = tx.ExecContext(ctx, "UPDATE wallets SET credit_limit = ?, version = version + 1 WHERE id = ?", creditLimit, walletId)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - SetCreditLimit - r.DB: %w", err)
+	}
+	return wallet, nil
+}
+
+// SetWalletStatus - changing the status of the wallet, the balance must be zero to close it.
+func (r *WalletRepo) SetWalletStatus(ctx context.Context, walletId string, status string) (*entity.Wallet, error) {
+	var wallet *entity.Wallet
+	err := r.runInTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		wallet, err = getWallet(ctx, tx, walletId)
+		if err != nil {
+			return err
+		}
+		if wallet.Status == entity.WalletStatusClosed {
+			return entity.ErrWalletClosed
+		}
+		if status == entity.WalletStatusClosed && math.Abs(wallet.Balance) > amountEpsilon {
+			return entity.ErrWalletNotEmpty
+		}
+
+		wallet.Status = status
+		wallet.Version++
+		_, err = tx.ExecContext(ctx, "UPDATE wallets SET status = ?, version = version + 1 WHERE id = ?", status, walletId)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("WalletRepo - SetWalletStatus - r.DB: %w", err)
+	}
+	return wallet, nil
+}
+
+// GetPockets - wallets in sqlite have no pockets.
+func (r *WalletRepo) GetPockets(ctx context.Context, walletId string) ([]entity.Wallet, error) {
+	return make([]entity.Wallet, 0), nil
+}
+
+// GetWalletVersion - getting the version of the wallet. The database has a single writer,
+// so the wallet can't change till the end of the transaction.
+func (r *WalletRepo) GetWalletVersion(ctx context.Context, walletId string) (int64, error) {
+	wallet, err := getWallet(ctx, r.conn(ctx), walletId)
+
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - GetWalletVersion - r.DB: %w", err)
+	}
+	return wallet.Version, nil
+}
+
+// GetBalanceAt - getting the balance of the wallet at the moment, it is the current balance without later movements.
+func (r *WalletRepo) GetBalanceAt(ctx context.Context, walletId string, at time.Time) (float64, error) {
+	var balance float64
+	err := r.conn(ctx).QueryRowContext(ctx, `
+		SELECT w.balance - COALESCE(SUM(CASE WHEN t.to_wallet_id = w.id THEN t.amount ELSE -t.amount END), 0)
+		FROM wallets AS w
+		LEFT JOIN transactions AS t ON (t.from_wallet_id = w.id OR t.to_wallet_id = w.id) AND t.time >= ?
+		WHERE w.id = ? AND NOT w.is_system
+		GROUP BY w.id, w.balance`,
+		at.UnixNano(), walletId).
+		Scan(&balance)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt - r.DB: %w", entity.ErrWalletNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("WalletRepo - GetBalanceAt - r.DB: %w", err)
+	}
+	return balance, nil
+}
+
+// getWallet - getting a user wallet, system wallets are not visible to the users.
+func getWallet(ctx context.Context, db conn, walletId string) (*entity.Wallet, error) {
+	var (
+		wallet    entity.Wallet
+		createdAt int64
+	)
+	err := db.QueryRowContext(ctx, "SELECT "+walletColumns+" FROM wallets WHERE id = ? AND NOT is_system", walletId).
+		Scan(&wallet.ID, &wallet.Balance, &wallet.Type, &wallet.AnnualRate, &wallet.CreditLimit, &wallet.Status, &createdAt, &wallet.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	wallet.CreatedAt = fromUnix(createdAt)
+
+	return &wallet, nil
+}
+
+// checkWalletActive - frozen and closed wallets can't take part in user transfers.
+func checkWalletActive(wallet *entity.Wallet) error {
+	switch wallet.Status {
+	case entity.WalletStatusFrozen:
+		return entity.ErrWalletFrozen
+	case entity.WalletStatusClosed:
+		return entity.ErrWalletClosed
+	}
+	return nil
+}
+
+// makeUid - unique id like the one of make_uid() in postgres.
+func makeUid() string {
+	sum := md5.Sum([]byte(fmt.Sprintf("%s%f", time.Now(), rand.Float64())))
+	return hex.EncodeToString(sum[:])
+}
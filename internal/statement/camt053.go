@@ -0,0 +1,198 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const camtNamespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// ISO 20022 field limits
+const (
+	camtMax34Text  = 34
+	camtMax35Text  = 35
+	camtMax140Text = 140
+)
+
+type camtDocument struct {
+	XMLName xml.Name      `xml:"Document"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Stmt    camtStatement `xml:"BkToCstmrStmt"`
+}
+
+type camtStatement struct {
+	GrpHdr struct {
+		MsgId   string `xml:"MsgId"`
+		CreDtTm string `xml:"CreDtTm"`
+	} `xml:"GrpHdr"`
+	Stmt struct {
+		Id      string `xml:"Id"`
+		CreDtTm string `xml:"CreDtTm"`
+		FrToDt  struct {
+			FrDtTm string `xml:"FrDtTm"`
+			ToDtTm string `xml:"ToDtTm"`
+		} `xml:"FrToDt"`
+		Acct struct {
+			Id  camtAccountID `xml:"Id"`
+			Ccy string        `xml:"Ccy"`
+		} `xml:"Acct"`
+		Bal  []camtBalance `xml:"Bal"`
+		Ntry []camtEntry   `xml:"Ntry"`
+	} `xml:"Stmt"`
+}
+
+type camtAccountID struct {
+	Othr struct {
+		Id string `xml:"Id"`
+	} `xml:"Othr"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Tp struct {
+		Cd string `xml:"CdOrPrtry>Cd"`
+	} `xml:"Tp"`
+	Amt       camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	DtTm      string     `xml:"Dt>DtTm"`
+}
+
+type camtEntry struct {
+	Amt         camtAmount `xml:"Amt"`
+	CdtDbtInd   string     `xml:"CdtDbtInd"`
+	Sts         string     `xml:"Sts"`
+	BookgDtTm   string     `xml:"BookgDt>DtTm"`
+	ValDtTm     string     `xml:"ValDt>DtTm"`
+	AcctSvcrRef string     `xml:"AcctSvcrRef"`
+	BkTxCd      struct {
+		Cd   string `xml:"Prtry>Cd"`
+		Issr string `xml:"Prtry>Issr"`
+	} `xml:"BkTxCd"`
+	TxDtls camtTransactionDetails `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTransactionDetails struct {
+	Refs      *camtReferences `xml:"Refs,omitempty"`
+	RltdPties camtParties     `xml:"RltdPties"`
+	RmtInf    *camtRemittance `xml:"RmtInf,omitempty"`
+}
+
+type camtReferences struct {
+	EndToEndId string `xml:"EndToEndId"`
+}
+
+type camtRemittance struct {
+	Ustrd []string `xml:"Ustrd"`
+}
+
+type camtParties struct {
+	DbtrAcct *camtAccount `xml:"DbtrAcct,omitempty"`
+	CdtrAcct *camtAccount `xml:"CdtrAcct,omitempty"`
+}
+
+type camtAccount struct {
+	Id camtAccountID `xml:"Id"`
+}
+
+// WriteCamt053 - writing the statement as an ISO 20022 camt.053.001.02 bank to customer statement.
+// Every movement is a booked entry with the proprietary transaction code equal to its type.
+func WriteCamt053(w io.Writer, s *entity.Statement) error {
+	doc := camtDocument{Xmlns: camtNamespace}
+	stmt := &doc.Stmt
+
+	stmt.GrpHdr.MsgId = statementID(s)
+	stmt.GrpHdr.CreDtTm = formatCamtTime(s.GeneratedAt)
+	stmt.Stmt.Id = statementID(s)
+	stmt.Stmt.CreDtTm = formatCamtTime(s.GeneratedAt)
+	stmt.Stmt.FrToDt.FrDtTm = formatCamtTime(s.From)
+	stmt.Stmt.FrToDt.ToDtTm = formatCamtTime(s.To)
+	stmt.Stmt.Acct.Id = camtAccountIDOf(s.WalletID)
+	stmt.Stmt.Acct.Ccy = s.Currency
+	stmt.Stmt.Bal = []camtBalance{
+		newCamtBalance("OPBD", s.OpeningBalance, s.Currency, s.From),
+		newCamtBalance("CLBD", s.ClosingBalance, s.Currency, s.To),
+	}
+
+	for _, line := range s.Lines {
+		entry := camtEntry{
+			Amt:         camtAmount{Ccy: s.Currency, Value: formatAbsAmount(line.Amount)},
+			CdtDbtInd:   creditDebit(line.Amount),
+			Sts:         "BOOK",
+			BookgDtTm:   formatCamtTime(line.Time),
+			ValDtTm:     formatCamtTime(line.Time),
+			AcctSvcrRef: entryID(s, line),
+		}
+		entry.BkTxCd.Cd = line.Type
+		entry.BkTxCd.Issr = servicerID
+
+		counterparty := &camtAccount{Id: camtAccountIDOf(line.Counterparty)}
+		if line.Amount < 0 {
+			entry.TxDtls.RltdPties.CdtrAcct = counterparty
+		} else {
+			entry.TxDtls.RltdPties.DbtrAcct = counterparty
+		}
+		// References longer than the end-to-end identifier are kept in the remittance information
+		var unstructured []string
+		if line.ExternalReference != "" && len(line.ExternalReference) <= camtMax35Text {
+			entry.TxDtls.Refs = &camtReferences{EndToEndId: line.ExternalReference}
+		} else if line.ExternalReference != "" {
+			unstructured = append(unstructured, line.ExternalReference)
+		}
+		if line.Description != "" {
+			unstructured = append(unstructured, Truncate(line.Description, camtMax140Text))
+		}
+		if len(unstructured) > 0 {
+			entry.TxDtls.RmtInf = &camtRemittance{Ustrd: unstructured}
+		}
+
+		stmt.Stmt.Ntry = append(stmt.Stmt.Ntry, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newCamtBalance(code string, amount float64, currency string, at time.Time) camtBalance {
+	balance := camtBalance{
+		Amt:       camtAmount{Ccy: currency, Value: formatAbsAmount(amount)},
+		CdtDbtInd: creditDebit(amount),
+		DtTm:      formatCamtTime(at),
+	}
+	balance.Tp.Cd = code
+
+	return balance
+}
+
+func camtAccountIDOf(walletId string) camtAccountID {
+	var id camtAccountID
+	id.Othr.Id = Truncate(walletId, camtMax34Text)
+
+	return id
+}
+
+// creditDebit - negative balances of wallets with a credit line are debit balances.
+func creditDebit(amount float64) string {
+	if amount < 0 {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+func formatCamtTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,46 @@
+// Package statement renders wallet statements into downloadable documents.
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+var csvHeader = []string{"time", "type", "counterparty", "description", "external_reference", "amount", "balance"}
+
+// WriteCSV - writing the statement as CSV, the opening and closing balances are the first and the last rows.
+func WriteCSV(w io.Writer, s *entity.Statement) error {
+	cw := csv.NewWriter(w)
+
+	rows := make([][]string, 0, len(s.Lines)+3)
+	rows = append(rows, csvHeader)
+	rows = append(rows, []string{formatTime(s.From), entity.StatementOpeningBalance, "", "", "", "", formatAmount(s.OpeningBalance)})
+	for _, line := range s.Lines {
+		rows = append(rows, []string{
+			formatTime(line.Time),
+			line.Type,
+			line.Counterparty,
+			line.Description,
+			line.ExternalReference,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+		})
+	}
+	rows = append(rows, []string{formatTime(s.To), entity.StatementClosingBalance, "", "", "", "", formatAmount(s.ClosingBalance)})
+
+	return cw.WriteAll(rows)
+}
+
+// formatTime - all statement times are in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatAmount - amounts are rounded to cents.
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,41 @@
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// Identifier of the service in the bank formats
+const servicerID = "EWALLET"
+
+// statementID - stable identifier of the statement for the wallet and period, at most 35 characters.
+func statementID(s *entity.Statement) string {
+	return fmt.Sprintf("%s-%s-%s", Truncate(s.WalletID, 16), s.From.UTC().Format("20060102"), s.To.UTC().Format("20060102"))
+}
+
+// entryID - stable identifier of the movement, the same movement gets the same identifier in every export
+// so that accounting software can skip already imported entries.
+func entryID(s *entity.Statement, line entity.StatementLine) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s|%s", s.WalletID, line.Time.UTC().Format(time.RFC3339Nano), line.Type, line.Counterparty, formatAmount(line.Amount))))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Truncate - cutting the string to the limit of the field.
+func Truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
+
+// formatAbsAmount - the bank formats carry the direction separately from the amount.
+func formatAbsAmount(amount float64) string {
+	return strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+}
@@ -0,0 +1,90 @@
+package statement
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// testStatement - a statement of a wallet with a credit line which goes negative during the period.
+func testStatement() *entity.Statement {
+	return &entity.Statement{
+		WalletID:       "5b53700ed469fa6a09ea72bb78f36fd9",
+		From:           time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Currency:       "XXX",
+		GeneratedAt:    time.Date(2024, 3, 1, 0, 5, 0, 0, time.UTC),
+		OpeningBalance: 100.0,
+		ClosingBalance: -9.8,
+		Lines: []entity.StatementLine{
+			{
+				Time:              time.Date(2024, 2, 4, 17, 25, 35, 448000000, time.UTC),
+				Type:              entity.TransactionTypeTransfer,
+				Counterparty:      "eb376add88bf8e70f80787266a0801d5",
+				Description:       "Оплата по счету №42 & <доставка>",
+				ExternalReference: "INV-2024-0042",
+				Amount:            -130.0,
+				Balance:           -30.0,
+			},
+			{
+				Time:              time.Date(2024, 2, 10, 9, 0, 0, 0, time.UTC),
+				Type:              entity.TransactionTypeDeposit,
+				Counterparty:      entity.SystemGatewayWalletID,
+				ExternalReference: "gateway-reference-which-is-longer-than-35",
+				Amount:            20.5,
+				Balance:           -9.5,
+			},
+			{
+				Time:         time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+				Type:         entity.TransactionTypeOverdraftInterest,
+				Counterparty: entity.SystemInterestWalletID,
+				Amount:       -0.3,
+				Balance:      -9.8,
+			},
+		},
+	}
+}
+
+func TestWriteCamt053(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCamt053(&buf, testStatement()))
+
+	assertGolden(t, "statement.camt053.xml", buf.Bytes())
+}
+
+func TestWriteOFX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteOFX(&buf, testStatement()))
+
+	assertGolden(t, "statement.ofx", buf.Bytes())
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testStatement()))
+
+	assertGolden(t, "statement.csv", buf.Bytes())
+}
+
+// assertGolden - comparing the output with the golden file, run the tests with -update to rewrite the files.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
@@ -0,0 +1,127 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const ofxHeader = `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+// OFX field limits
+const (
+	ofxMaxAccountID = 22
+	ofxMaxName      = 32
+	ofxMaxMemo      = 255
+)
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	Signon  struct {
+		Status   ofxStatus `xml:"SONRS>STATUS"`
+		Server   string    `xml:"SONRS>DTSERVER"`
+		Language string    `xml:"SONRS>LANGUAGE"`
+	} `xml:"SIGNONMSGSRSV1"`
+	Bank struct {
+		TrnUID string       `xml:"STMTTRNRS>TRNUID"`
+		Status ofxStatus    `xml:"STMTTRNRS>STATUS"`
+		Stmt   ofxStatement `xml:"STMTTRNRS>STMTRS"`
+	} `xml:"BANKMSGSRSV1"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxStatement struct {
+	CurDef  string `xml:"CURDEF"`
+	Account struct {
+		BankID string `xml:"BANKID"`
+		AcctID string `xml:"ACCTID"`
+		Type   string `xml:"ACCTTYPE"`
+	} `xml:"BANKACCTFROM"`
+	TranList struct {
+		Start        string           `xml:"DTSTART"`
+		End          string           `xml:"DTEND"`
+		Transactions []ofxTransaction `xml:"STMTTRN"`
+	} `xml:"BANKTRANLIST"`
+	LedgerBal struct {
+		Amount string `xml:"BALAMT"`
+		AsOf   string `xml:"DTASOF"`
+	} `xml:"LEDGERBAL"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FitID  string `xml:"FITID"`
+	Name   string `xml:"NAME"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+// WriteOFX - writing the statement as an OFX 2.2 bank statement response.
+// OFX limits account identifiers to 22 characters, so longer wallet IDs are shortened.
+func WriteOFX(w io.Writer, s *entity.Statement) error {
+	var doc ofxDocument
+	doc.Signon.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	doc.Signon.Server = formatOFXTime(s.GeneratedAt)
+	doc.Signon.Language = "RUS"
+	doc.Bank.TrnUID = statementID(s)
+	doc.Bank.Status = ofxStatus{Code: 0, Severity: "INFO"}
+
+	stmt := &doc.Bank.Stmt
+	stmt.CurDef = s.Currency
+	stmt.Account.BankID = servicerID
+	stmt.Account.AcctID = Truncate(s.WalletID, ofxMaxAccountID)
+	stmt.Account.Type = "CHECKING"
+	stmt.TranList.Start = formatOFXTime(s.From)
+	stmt.TranList.End = formatOFXTime(s.To)
+	stmt.LedgerBal.Amount = formatAmount(s.ClosingBalance)
+	stmt.LedgerBal.AsOf = formatOFXTime(s.To)
+
+	for _, line := range s.Lines {
+		stmt.TranList.Transactions = append(stmt.TranList.Transactions, ofxTransaction{
+			Type:   ofxTransactionType(line),
+			Posted: formatOFXTime(line.Time),
+			Amount: formatAmount(line.Amount),
+			FitID:  entryID(s, line),
+			Name:   Truncate(line.Counterparty, ofxMaxName),
+			Memo:   Truncate(memo(line), ofxMaxMemo),
+		})
+	}
+
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n"+ofxHeader); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ofxTransactionType - mapping movement types to OFX transaction types.
+func ofxTransactionType(line entity.StatementLine) string {
+	switch line.Type {
+	case entity.TransactionTypeInterest, entity.TransactionTypeOverdraftInterest:
+		return "INT"
+	case entity.TransactionTypeDeposit:
+		return "DEP"
+	case entity.TransactionTypeTransfer, entity.TransactionTypePocketTransfer:
+		return "XFER"
+	}
+	if line.Amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
@@ -0,0 +1,107 @@
+package statement
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// DejaVu Sans is used because the core PDF fonts have no cyrillic glyphs.
+//
+//go:embed fonts/DejaVuSansCondensed.ttf
+var fontRegular []byte
+
+const (
+	fontFamily = "DejaVu"
+	lineHeight = 5.0
+)
+
+// Column widths in millimeters of the A4 portrait page with 10mm margins
+var pdfColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Дата", 32, "L"},
+	{"Тип", 28, "L"},
+	{"Контрагент", 56, "L"},
+	{"Сумма", 37, "R"},
+	{"Остаток", 37, "R"},
+}
+
+// WritePDF - writing the statement as a PDF table, descriptions are printed under the movements.
+func WritePDF(w io.Writer, s *entity.Statement) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetCreationDate(s.GeneratedAt)
+	pdf.SetTitle(fmt.Sprintf("Выписка по кошельку %s", s.WalletID), true)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", fontRegular)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.CellFormat(0, lineHeight, fmt.Sprintf("Страница %d из {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// Statement header
+	pdf.SetFont(fontFamily, "", 14)
+	pdf.CellFormat(0, 8, "Выписка по кошельку", "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, lineHeight, s.WalletID, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, lineHeight, fmt.Sprintf("Период: %s - %s (UTC)", formatTime(s.From), formatTime(s.To)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, lineHeight, fmt.Sprintf("Входящий остаток: %s", formatAmount(s.OpeningBalance)), "", 1, "L", false, 0, "")
+	pdf.Ln(lineHeight)
+
+	// Movements table
+	pdf.SetFont(fontFamily, "", 8)
+	for _, column := range pdfColumns {
+		pdf.CellFormat(column.width, lineHeight+1, column.title, "B", 0, column.align, false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	for _, line := range s.Lines {
+		values := []string{
+			line.Time.UTC().Format(time.DateTime),
+			line.Type,
+			line.Counterparty,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+		}
+		for i, column := range pdfColumns {
+			pdf.CellFormat(column.width, lineHeight, values[i], "", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+
+		if line.Description != "" || line.ExternalReference != "" {
+			pdf.SetX(10 + pdfColumns[0].width)
+			pdf.MultiCell(0, lineHeight-1, memo(line), "", "L", false)
+		}
+	}
+	if len(s.Lines) == 0 {
+		pdf.CellFormat(0, lineHeight, "Операций за период нет", "", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(lineHeight)
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, lineHeight, fmt.Sprintf("Исходящий остаток: %s", formatAmount(s.ClosingBalance)), "T", 1, "L", false, 0, "")
+
+	return pdf.Output(w)
+}
+
+// memo - joining the description and the external reference of a movement.
+func memo(line entity.StatementLine) string {
+	switch {
+	case line.ExternalReference == "":
+		return line.Description
+	case line.Description == "":
+		return line.ExternalReference
+	}
+	return fmt.Sprintf("%s (%s)", line.Description, line.ExternalReference)
+}
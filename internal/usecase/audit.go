@@ -0,0 +1,618 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// auditKey - context key of the audited request.
+type auditKey struct{}
+
+// auditRequest - actor of the request and whether its operation is already in the audit log.
+// Operations of one request are made one after another, so the flag isn't guarded.
+type auditRequest struct {
+	actor    entity.AuditActor
+	recorded bool
+}
+
+// WithAudit - operations made with the context are recorded in the audit log on behalf of the actor.
+// Requests without an id get a generated one.
+func WithAudit(ctx context.Context, actor entity.AuditActor) context.Context {
+	if actor.RequestID == "" {
+		actor.RequestID = NewRequestID()
+	}
+	return context.WithValue(ctx, auditKey{}, &auditRequest{actor: actor})
+}
+
+// AuditActorOf - the actor of the request, operations without one are made by the service itself.
+func AuditActorOf(ctx context.Context) entity.AuditActor {
+	if request, ok := ctx.Value(auditKey{}).(*auditRequest); ok {
+		return request.actor
+	}
+	return entity.AuditActor{Actor: entity.AuditActorSystem}
+}
+
+// AuditRecorded - whether an operation of the request is already in the audit log.
+func AuditRecorded(ctx context.Context) bool {
+	request, ok := ctx.Value(auditKey{}).(*auditRequest)
+	return ok && request.recorded
+}
+
+// NewRequestID - random id of a request without one.
+func NewRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// AuditUseCase -.
+type AuditUseCase struct {
+	repo      AuditRepo
+	Retention time.Duration
+}
+
+// NewAudit -.
+func NewAudit(r AuditRepo, retention time.Duration) *AuditUseCase {
+	return &AuditUseCase{
+		repo:      r,
+		Retention: retention,
+	}
+}
+
+// Record - adding the entry made by the actor of the context to the audit log
+func (au *AuditUseCase) Record(ctx context.Context, entry entity.AuditEntry) error {
+	err := addAuditEntry(ctx, au.repo, entry)
+	if err != nil {
+		return fmt.Errorf("AuditUseCase - Record - addAuditEntry: %w", err)
+	}
+
+	return nil
+}
+
+// GetEntries - getting the latest entries of the audit log matching the filter
+func (au *AuditUseCase) GetEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.Limit == 0 {
+		filter.Limit = entity.DefaultAuditEntries
+	}
+	if filter.Limit < 0 || filter.Limit > entity.MaxAuditEntries {
+		return nil, entity.ErrWrongAuditLimit
+	}
+
+	entries, err := au.repo.GetAuditEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("AuditUseCase - GetEntries - au.repo.GetAuditEntries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// PurgeExpired - deleting the entries older than the retention period, entries are kept forever without it
+func (au *AuditUseCase) PurgeExpired(ctx context.Context) (int, error) {
+	if au.Retention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := au.repo.DeleteAuditEntries(ctx, time.Now().Add(-au.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("AuditUseCase - PurgeExpired - au.repo.DeleteAuditEntries: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// auditor - recording changes of the decorated use cases in the audit log together with the states of the wallets.
+// A successful change is recorded in its transaction, so the change is rolled back if it can't be recorded.
+// A failed change is recorded after the rollback. The wallets must be kept in the database of the audit log,
+// otherwise the change isn't made in the transaction of the entry.
+type auditor struct {
+	repo    AuditRepo
+	wallets Wallet
+}
+
+// AuditedWallet - recording every change of the wallets made with the use case in the audit log.
+type AuditedWallet struct {
+	Wallet
+	auditor
+}
+
+// NewAuditedWallet -.
+func NewAuditedWallet(w Wallet, r AuditRepo) *AuditedWallet {
+	return &AuditedWallet{
+		Wallet:  w,
+		auditor: auditor{repo: r, wallets: w},
+	}
+}
+
+// CreateNewWalletWithDefaultBalance - creating a wallet on behalf of the actor of the context
+func (a *AuditedWallet) CreateNewWalletWithDefaultBalance(ctx context.Context, walletType string) (*entity.Wallet, error) {
+	var wallet *entity.Wallet
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationCreateWallet,
+		Details:   map[string]interface{}{"type": walletType},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		var err error
+		wallet, err = a.Wallet.CreateNewWalletWithDefaultBalance(ctx, walletType)
+		if err != nil {
+			return err
+		}
+		entry.WalletID = wallet.ID
+		entry.After = wallet
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// SendFunds - sending funds on behalf of the actor of the context, the sender is recorded before and after it
+func (a *AuditedWallet) SendFunds(ctx context.Context, from string, request entity.TransactionRequest) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationSendFunds,
+		WalletID:  from,
+		Details: map[string]interface{}{
+			"to":                 request.To,
+			"amount":             request.Amount,
+			"description":        request.Description,
+			"external_reference": request.ExternalReference,
+		},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, from)
+
+		var err error
+		transaction, err = a.Wallet.SendFunds(ctx, from, request)
+		if err != nil {
+			return err
+		}
+		if transaction.ID != 0 {
+			entry.Details["transaction_id"] = transaction.ID
+		}
+		entry.After = a.getWallet(ctx, from)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// SetCreditLimit - changing the credit limit on behalf of the actor of the context
+func (a *AuditedWallet) SetCreditLimit(ctx context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	var wallet *entity.Wallet
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationSetCreditLimit,
+		WalletID:  walletId,
+		Details:   map[string]interface{}{"credit_limit": creditLimit},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, walletId)
+
+		var err error
+		wallet, err = a.Wallet.SetCreditLimit(ctx, walletId, creditLimit)
+		if err != nil {
+			return err
+		}
+		entry.After = wallet
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// SetWalletStatus - changing the status on behalf of the actor of the context
+func (a *AuditedWallet) SetWalletStatus(ctx context.Context, walletId string, status string) (*entity.Wallet, error) {
+	var wallet *entity.Wallet
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationSetWalletStatus,
+		WalletID:  walletId,
+		Details:   map[string]interface{}{"status": status},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, walletId)
+
+		var err error
+		wallet, err = a.Wallet.SetWalletStatus(ctx, walletId, status)
+		if err != nil {
+			return err
+		}
+		entry.After = wallet
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// AuditedPromo - recording the promo grants in the audit log.
+type AuditedPromo struct {
+	Promo
+	auditor
+}
+
+// NewAuditedPromo - the wallets are read with w before and after the grants.
+func NewAuditedPromo(p Promo, w Wallet, r AuditRepo) *AuditedPromo {
+	return &AuditedPromo{
+		Promo:   p,
+		auditor: auditor{repo: r, wallets: w},
+	}
+}
+
+// GrantPromo - granting the promo balance on behalf of the actor of the context
+func (a *AuditedPromo) GrantPromo(ctx context.Context, walletId string, amount float64) (*entity.PromoGrant, error) {
+	var grant *entity.PromoGrant
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationGrantPromo,
+		WalletID:  walletId,
+		Details:   map[string]interface{}{"amount": amount},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, walletId)
+
+		var err error
+		grant, err = a.Promo.GrantPromo(ctx, walletId, amount)
+		if err != nil {
+			return err
+		}
+		entry.Details["grant_id"] = grant.ID
+		entry.Details["expires_at"] = grant.ExpiresAt
+		entry.After = a.getWallet(ctx, walletId)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// AuditedVoucher - recording the issued voucher batches in the audit log.
+type AuditedVoucher struct {
+	Voucher
+	auditor
+}
+
+// NewAuditedVoucher - the source wallets are read with w before and after the issue.
+func NewAuditedVoucher(v Voucher, w Wallet, r AuditRepo) *AuditedVoucher {
+	return &AuditedVoucher{
+		Voucher: v,
+		auditor: auditor{repo: r, wallets: w},
+	}
+}
+
+// IssueVouchers - issuing the batch on behalf of the actor of the context, the codes aren't recorded
+func (a *AuditedVoucher) IssueVouchers(ctx context.Context, request entity.VoucherBatchRequest) (*entity.VoucherBatch, error) {
+	var batch *entity.VoucherBatch
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationIssueVouchers,
+		WalletID:  request.SourceWalletID,
+		Details: map[string]interface{}{
+			"amount":          request.Amount,
+			"count":           request.Count,
+			"max_redemptions": request.MaxRedemptions,
+			"expires_at":      request.ExpiresAt,
+		},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, request.SourceWalletID)
+
+		var err error
+		batch, err = a.Voucher.IssueVouchers(ctx, request)
+		if err != nil {
+			return err
+		}
+		entry.Details["batch_id"] = batch.ID
+		entry.After = a.getWallet(ctx, request.SourceWalletID)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// AuditedPocket - recording the movements of the funds between the wallets and their pockets in the audit log.
+type AuditedPocket struct {
+	Pocket
+	auditor
+}
+
+// NewAuditedPocket - the wallets are read with w together with their pockets.
+func NewAuditedPocket(p Pocket, w Wallet, r AuditRepo) *AuditedPocket {
+	return &AuditedPocket{
+		Pocket:  p,
+		auditor: auditor{repo: r, wallets: w},
+	}
+}
+
+// MovePocketFunds - moving the funds on behalf of the actor of the context
+func (a *AuditedPocket) MovePocketFunds(ctx context.Context, walletId string, pocketId string, request entity.PocketTransferRequest) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationMovePocketFunds,
+		WalletID:  walletId,
+		Details: map[string]interface{}{
+			"pocket_id": pocketId,
+			"amount":    request.Amount,
+			"direction": request.Direction,
+		},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, walletId)
+
+		var err error
+		transaction, err = a.Pocket.MovePocketFunds(ctx, walletId, pocketId, request)
+		if err != nil {
+			return err
+		}
+		if transaction.ID != 0 {
+			entry.Details["transaction_id"] = transaction.ID
+		}
+		entry.After = a.getWallet(ctx, walletId)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// ClosePocket - closing the pocket on behalf of the actor of the context
+func (a *AuditedPocket) ClosePocket(ctx context.Context, walletId string, pocketId string) error {
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationClosePocket,
+		WalletID:  walletId,
+		Details:   map[string]interface{}{"pocket_id": pocketId},
+	}
+
+	return a.audit(ctx, &entry, func(ctx context.Context) error {
+		entry.Before = a.lockWallet(ctx, walletId)
+
+		if err := a.Pocket.ClosePocket(ctx, walletId, pocketId); err != nil {
+			return err
+		}
+		entry.After = a.getWallet(ctx, walletId)
+
+		return nil
+	})
+}
+
+// AuditedPayment - recording the payments in the audit log. The gateway is called outside of a transaction,
+// so deposits and withdrawals are recorded after they are submitted. The wallet can't be locked till then,
+// so only its state after the payment is recorded.
+type AuditedPayment struct {
+	Payment
+	auditor
+}
+
+// NewAuditedPayment - the wallets are read with w before and after the payments.
+func NewAuditedPayment(p Payment, w Wallet, r AuditRepo) *AuditedPayment {
+	return &AuditedPayment{
+		Payment: p,
+		auditor: auditor{repo: r, wallets: w},
+	}
+}
+
+// Deposit - starting a top-up on behalf of the actor of the context
+func (a *AuditedPayment) Deposit(ctx context.Context, walletId string, amount float64) (*entity.Payment, error) {
+	return a.submit(ctx, entity.AuditOperationDeposit, walletId, amount, a.Payment.Deposit)
+}
+
+// Withdraw - starting a withdrawal on behalf of the actor of the context
+func (a *AuditedPayment) Withdraw(ctx context.Context, walletId string, amount float64) (*entity.Payment, error) {
+	return a.submit(ctx, entity.AuditOperationWithdraw, walletId, amount, a.Payment.Withdraw)
+}
+
+// HandleNotification - applying the result reported by the gateway. The wallet of the payment is known only
+// after it is applied, so only its state after the payment is recorded.
+func (a *AuditedPayment) HandleNotification(ctx context.Context, payload []byte, signature string) (*entity.Payment, error) {
+	var payment *entity.Payment
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationCompletePayment,
+		Details:   map[string]interface{}{},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		var err error
+		payment, err = a.Payment.HandleNotification(ctx, payload, signature)
+		if err != nil {
+			return err
+		}
+		entry.WalletID = payment.WalletID
+		entry.Details["payment_id"] = payment.ID
+		entry.Details["type"] = payment.Type
+		entry.Details["amount"] = payment.Amount
+		entry.Details["status"] = payment.Status
+		entry.After = a.getWallet(ctx, payment.WalletID)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (a *AuditedPayment) submit(ctx context.Context, operation string, walletId string, amount float64,
+	send func(context.Context, string, float64) (*entity.Payment, error)) (*entity.Payment, error) {
+	var payment *entity.Payment
+	entry := entity.AuditEntry{
+		Operation: operation,
+		WalletID:  walletId,
+		Details:   map[string]interface{}{"amount": amount},
+	}
+
+	err := a.auditAfter(ctx, &entry, func(ctx context.Context) error {
+		var err error
+		payment, err = send(ctx, walletId, amount)
+		if err != nil {
+			return err
+		}
+		entry.Details["payment_id"] = payment.ID
+		entry.Details["status"] = payment.Status
+		entry.After = a.getWallet(ctx, walletId)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// AuditedWebhook - recording the replays of the webhook deliveries in the audit log.
+type AuditedWebhook struct {
+	Webhook
+	auditor
+}
+
+// NewAuditedWebhook -.
+func NewAuditedWebhook(w Webhook, r AuditRepo) *AuditedWebhook {
+	return &AuditedWebhook{
+		Webhook: w,
+		auditor: auditor{repo: r},
+	}
+}
+
+// ReplayDelivery - scheduling the delivery again on behalf of the actor of the context
+func (a *AuditedWebhook) ReplayDelivery(ctx context.Context, webhookId int64, deliveryId int64) (*entity.WebhookDelivery, error) {
+	var delivery *entity.WebhookDelivery
+	entry := entity.AuditEntry{
+		Operation: entity.AuditOperationReplayWebhookDelivery,
+		Details: map[string]interface{}{
+			"webhook_id":  webhookId,
+			"delivery_id": deliveryId,
+		},
+	}
+
+	err := a.audit(ctx, &entry, func(ctx context.Context) error {
+		var err error
+		delivery, err = a.Webhook.ReplayDelivery(ctx, webhookId, deliveryId)
+		if err != nil {
+			return err
+		}
+		entry.Details["attempts"] = delivery.Attempts
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// audit - running the change and recording the entry in its transaction. The error of the change is returned
+// as is, so the callers still recognize it.
+func (a *auditor) audit(ctx context.Context, entry *entity.AuditEntry, change func(ctx context.Context) error) error {
+	var changeErr error
+	err := a.repo.Atomic(ctx, func(ctx context.Context) error {
+		changeErr = change(ctx)
+		if changeErr != nil {
+			return changeErr
+		}
+
+		return addAuditEntry(ctx, a.repo, *entry)
+	})
+	if changeErr != nil {
+		return a.auditFailure(ctx, entry, changeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("auditor - audit - a.repo.Atomic: %w", err)
+	}
+
+	return nil
+}
+
+// auditAfter - running the change which can't be made in a transaction and recording the entry after it
+func (a *auditor) auditAfter(ctx context.Context, entry *entity.AuditEntry, change func(ctx context.Context) error) error {
+	if changeErr := change(ctx); changeErr != nil {
+		return a.auditFailure(ctx, entry, changeErr)
+	}
+	if err := addAuditEntry(ctx, a.repo, *entry); err != nil {
+		return fmt.Errorf("auditor - auditAfter - addAuditEntry: %w", err)
+	}
+
+	return nil
+}
+
+// auditFailure - recording the failed change and returning its error
+func (a *auditor) auditFailure(ctx context.Context, entry *entity.AuditEntry, changeErr error) error {
+	entry.After = nil
+	entry.Error = changeErr.Error()
+	if err := addAuditEntry(ctx, a.repo, *entry); err != nil {
+		return fmt.Errorf("auditor - auditFailure - addAuditEntry: %w", err)
+	}
+
+	return changeErr
+}
+
+// lockWallet - the state of the wallet before the change, it is locked till the end of the transaction,
+// so the change is made to this state. Nil if it can't be read, the change fails then too.
+func (a *auditor) lockWallet(ctx context.Context, walletId string) *entity.Wallet {
+	wallet, err := a.wallets.LockWallet(ctx, walletId)
+	if err != nil {
+		return nil
+	}
+	return wallet
+}
+
+// getWallet - the state of the wallet for the audit log, nil if it can't be read.
+func (a *auditor) getWallet(ctx context.Context, walletId string) *entity.Wallet {
+	wallet, err := a.wallets.GetWalletById(ctx, walletId)
+	if err != nil {
+		return nil
+	}
+	return wallet
+}
+
+// addAuditEntry - storing the entry made by the actor of the context and marking the request as recorded
+func addAuditEntry(ctx context.Context, r AuditRepo, entry entity.AuditEntry) error {
+	actor := AuditActorOf(ctx)
+	entry.Time = time.Now().UTC()
+	entry.Actor = actor.Actor
+	entry.ClientIP = actor.ClientIP
+	entry.RequestID = actor.RequestID
+
+	err := r.AddAuditEntry(ctx, &entry)
+	if err != nil {
+		return fmt.Errorf("r.AddAuditEntry: %w", err)
+	}
+
+	if request, ok := ctx.Value(auditKey{}).(*auditRequest); ok {
+		request.recorded = true
+	}
+	return nil
+}
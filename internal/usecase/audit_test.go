@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestAuditedSetCreditLimit(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	actor := entity.AuditActor{Actor: entity.AuditActorAdmin, ClientIP: "192.168.0.10", RequestID: "request-1"}
+	ctx := WithAudit(context.Background(), actor)
+	before := &entity.Wallet{ID: "a", Balance: 100.0}
+	after := &entity.Wallet{ID: "a", Balance: 100.0, CreditLimit: 50.0}
+
+	wallet := mock_usecase.NewMockWallet(c)
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(atomic)
+	wallet.EXPECT().LockWallet(ctx, "a").Return(before, nil)
+	wallet.EXPECT().SetCreditLimit(ctx, "a", 50.0).Return(after, nil)
+	repo.EXPECT().AddAuditEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.AuditEntry) error {
+		require.Equal(t, entity.AuditActorAdmin, entry.Actor)
+		require.Equal(t, "192.168.0.10", entry.ClientIP)
+		require.Equal(t, "request-1", entry.RequestID)
+		require.Equal(t, entity.AuditOperationSetCreditLimit, entry.Operation)
+		require.Equal(t, "a", entry.WalletID)
+		require.Equal(t, 50.0, entry.Details["credit_limit"])
+		require.Equal(t, before, entry.Before)
+		require.Equal(t, after, entry.After)
+		require.Empty(t, entry.Error)
+		return nil
+	})
+
+	result, err := NewAuditedWallet(wallet, repo).SetCreditLimit(ctx, "a", 50.0)
+	require.NoError(t, err)
+	require.Equal(t, after, result)
+	require.True(t, AuditRecorded(ctx))
+}
+
+func TestAuditedSendFundsFailed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	// Changes without an actor are made by the service
+	ctx := context.Background()
+	request := entity.TransactionRequest{To: "b", Amount: 500.0}
+	before := &entity.Wallet{ID: "a", Balance: 100.0}
+
+	wallet := mock_usecase.NewMockWallet(c)
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(atomic)
+	wallet.EXPECT().LockWallet(ctx, "a").Return(before, nil)
+	wallet.EXPECT().SendFunds(ctx, "a", request).Return(nil, entity.ErrInsufficientFunds)
+	repo.EXPECT().AddAuditEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.AuditEntry) error {
+		require.Equal(t, entity.AuditActorSystem, entry.Actor)
+		require.Equal(t, entity.AuditOperationSendFunds, entry.Operation)
+		require.Equal(t, before, entry.Before)
+		require.Nil(t, entry.After)
+		require.Equal(t, entity.ErrInsufficientFunds.Error(), entry.Error)
+		return nil
+	})
+
+	_, err := NewAuditedWallet(wallet, repo).SendFunds(ctx, "a", request)
+	require.ErrorIs(t, err, entity.ErrInsufficientFunds)
+}
+
+func TestAuditedCreateWalletNotRecorded(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	ctx := WithAudit(context.Background(), entity.AuditActor{Actor: entity.AuditActorClient})
+
+	// The wallet isn't created if its creation can't be recorded
+	wallet := mock_usecase.NewMockWallet(c)
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(atomic)
+	wallet.EXPECT().CreateNewWalletWithDefaultBalance(ctx, "").Return(&entity.Wallet{ID: "a"}, nil)
+	repo.EXPECT().AddAuditEntry(ctx, gomock.Any()).Return(errors.New("something went wrong"))
+
+	_, err := NewAuditedWallet(wallet, repo).CreateNewWalletWithDefaultBalance(ctx, "")
+	require.Error(t, err)
+	require.False(t, AuditRecorded(ctx))
+}
+
+func TestAuditedGrantPromo(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	ctx := WithAudit(context.Background(), entity.AuditActor{Actor: entity.AuditActorAdmin})
+	before := &entity.Wallet{ID: "a", Balance: 100.0}
+	after := &entity.Wallet{ID: "a", Balance: 150.0}
+	grant := &entity.PromoGrant{ID: 7, WalletID: "a", Amount: 50.0, Remaining: 50.0}
+
+	promo := mock_usecase.NewMockPromo(c)
+	wallet := mock_usecase.NewMockWallet(c)
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(atomic)
+	gomock.InOrder(
+		wallet.EXPECT().LockWallet(ctx, "a").Return(before, nil),
+		promo.EXPECT().GrantPromo(ctx, "a", 50.0).Return(grant, nil),
+		wallet.EXPECT().GetWalletById(ctx, "a").Return(after, nil),
+	)
+	repo.EXPECT().AddAuditEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.AuditEntry) error {
+		require.Equal(t, entity.AuditOperationGrantPromo, entry.Operation)
+		require.Equal(t, "a", entry.WalletID)
+		require.Equal(t, int64(7), entry.Details["grant_id"])
+		require.Equal(t, before, entry.Before)
+		require.Equal(t, after, entry.After)
+		return nil
+	})
+
+	result, err := NewAuditedPromo(promo, wallet, repo).GrantPromo(ctx, "a", 50.0)
+	require.NoError(t, err)
+	require.Equal(t, grant, result)
+	require.True(t, AuditRecorded(ctx))
+}
+
+func TestAuditedDepositFailed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	ctx := WithAudit(context.Background(), entity.AuditActor{Actor: entity.AuditActorClient})
+
+	// The gateway isn't called in a transaction, so the failure is recorded after it without the states of the wallet
+	payment := mock_usecase.NewMockPayment(c)
+	wallet := mock_usecase.NewMockWallet(c)
+	repo := mock_usecase.NewMockAuditRepo(c)
+	payment.EXPECT().Deposit(ctx, "a", 50.0).Return(nil, entity.ErrWalletFrozen)
+	repo.EXPECT().AddAuditEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.AuditEntry) error {
+		require.Equal(t, entity.AuditOperationDeposit, entry.Operation)
+		require.Nil(t, entry.Before)
+		require.Nil(t, entry.After)
+		require.Equal(t, entity.ErrWalletFrozen.Error(), entry.Error)
+		return nil
+	})
+
+	_, err := NewAuditedPayment(payment, wallet, repo).Deposit(ctx, "a", 50.0)
+	require.ErrorIs(t, err, entity.ErrWalletFrozen)
+	require.True(t, AuditRecorded(ctx))
+}
+
+func TestAuditedReplayDelivery(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	ctx := WithAudit(context.Background(), entity.AuditActor{Actor: entity.AuditActorAdmin})
+
+	webhook := mock_usecase.NewMockWebhook(c)
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(atomic)
+	webhook.EXPECT().ReplayDelivery(ctx, int64(1), int64(7)).Return(&entity.WebhookDelivery{ID: 7, WebhookID: 1}, nil)
+	repo.EXPECT().AddAuditEntry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.AuditEntry) error {
+		require.Equal(t, entity.AuditOperationReplayWebhookDelivery, entry.Operation)
+		require.Equal(t, int64(1), entry.Details["webhook_id"])
+		require.Equal(t, int64(7), entry.Details["delivery_id"])
+		return nil
+	})
+
+	_, err := NewAuditedWebhook(webhook, repo).ReplayDelivery(ctx, 1, 7)
+	require.NoError(t, err)
+}
+
+func TestWithAudit(t *testing.T) {
+	// Requests without an id get a generated one
+	actor := AuditActorOf(WithAudit(context.Background(), entity.AuditActor{Actor: entity.AuditActorClient}))
+	require.Equal(t, entity.AuditActorClient, actor.Actor)
+	require.Len(t, actor.RequestID, 32)
+
+	require.Equal(t, entity.AuditActor{Actor: entity.AuditActorSystem}, AuditActorOf(context.Background()))
+	require.False(t, AuditRecorded(context.Background()))
+}
+
+func TestGetAuditEntries(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().GetAuditEntries(context.Background(), entity.AuditFilter{WalletID: "a", Limit: entity.DefaultAuditEntries}).Return([]entity.AuditEntry{{ID: 1}}, nil)
+
+	entries, err := NewAudit(repo, 0).GetEntries(context.Background(), entity.AuditFilter{WalletID: "a"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	_, err = NewAudit(repo, 0).GetEntries(context.Background(), entity.AuditFilter{Limit: entity.MaxAuditEntries + 1})
+	require.ErrorIs(t, err, entity.ErrWrongAuditLimit)
+}
+
+func TestPurgeExpired(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockAuditRepo(c)
+	repo.EXPECT().DeleteAuditEntries(context.Background(), gomock.Any()).Return(3, nil)
+
+	deleted, err := NewAudit(repo, time.Hour).PurgeExpired(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, deleted)
+
+	// Without the retention period the entries are kept
+	deleted, err = NewAudit(repo, 0).PurgeExpired(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// BalanceUseCase -.
+type BalanceUseCase struct {
+	repo        BalanceRepo
+	wallets     WalletRepo
+	CatchUpDays int
+}
+
+// NewBalance -.
+func NewBalance(r BalanceRepo, w WalletRepo, catchUpDays int) *BalanceUseCase {
+	return &BalanceUseCase{
+		repo:        r,
+		wallets:     w,
+		CatchUpDays: catchUpDays,
+	}
+}
+
+// GetBalanceAt - getting the balance of a wallet at the moment
+func (b *BalanceUseCase) GetBalanceAt(ctx context.Context, walletId string, at time.Time) (*entity.BalanceAt, error) {
+	balance, err := b.wallets.GetBalanceAt(ctx, walletId, at)
+	if err != nil {
+		return nil, fmt.Errorf("BalanceUseCase - GetBalanceAt - b.wallets.GetBalanceAt: %w", err)
+	}
+
+	return &entity.BalanceAt{
+		WalletID: walletId,
+		At:       at,
+		Balance:  balance,
+	}, nil
+}
+
+// TakeSnapshots - storing end-of-day balances of the wallets for the day (UTC).
+// Already stored snapshots are skipped, so the day can be safely re-run.
+func (b *BalanceUseCase) TakeSnapshots(ctx context.Context, day time.Time) (int, error) {
+	day = startOfDay(day)
+	if !day.Before(startOfDay(time.Now())) {
+		return 0, entity.ErrDayIsNotOver
+	}
+
+	taken, err := b.repo.TakeSnapshots(ctx, day)
+	if err != nil {
+		return 0, fmt.Errorf("BalanceUseCase - TakeSnapshots - b.repo.TakeSnapshots: %w", err)
+	}
+
+	return taken, nil
+}
+
+// RunSnapshotJob - storing end-of-day balances for the last finished days
+func (b *BalanceUseCase) RunSnapshotJob(ctx context.Context) error {
+	today := startOfDay(time.Now())
+
+	// Days missed while the service was down are caught up
+	for n := b.CatchUpDays; n >= 1; n-- {
+		if _, err := b.TakeSnapshots(ctx, today.AddDate(0, 0, -n)); err != nil {
+			return fmt.Errorf("BalanceUseCase - RunSnapshotJob - b.TakeSnapshots: %w", err)
+		}
+	}
+
+	return nil
+}
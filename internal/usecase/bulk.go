@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/statement"
+)
+
+// BulkUseCase -.
+type BulkUseCase struct {
+	wallet   Wallet
+	Currency string
+}
+
+// NewBulk -.
+func NewBulk(w Wallet, currency string) *BulkUseCase {
+	return &BulkUseCase{
+		wallet:   w,
+		Currency: currency,
+	}
+}
+
+// ImportPayments - executing the credit transfers of a payment file one by one as ordinary transfers.
+// A rejected instruction doesn't stop the others, instructions with an end-to-end identifier already sent
+// from the same wallet are rejected as duplicates so that a file can be imported again safely, also concurrently.
+func (b *BulkUseCase) ImportPayments(ctx context.Context, payment *entity.BulkPayment) (*entity.BulkStatusReport, error) {
+	report := &entity.BulkStatusReport{
+		OriginalMessageID: payment.MessageID,
+		Instructions:      make([]entity.BulkInstructionStatus, 0, len(payment.Instructions)),
+	}
+
+	settled := 0
+	for _, instruction := range payment.Instructions {
+		status := entity.BulkInstructionStatus{
+			PaymentInfoID: instruction.PaymentInfoID,
+			InstructionID: instruction.InstructionID,
+			EndToEndID:    instruction.EndToEndID,
+			Status:        entity.BulkInstructionSettled,
+		}
+
+		reason, err := b.execute(ctx, instruction)
+		if err != nil {
+			status.Status = entity.BulkInstructionRejected
+			status.Reason = reason
+			status.Details = err.Error()
+		} else {
+			settled++
+		}
+		report.Instructions = append(report.Instructions, status)
+	}
+
+	switch settled {
+	case len(payment.Instructions):
+		report.GroupStatus = entity.BulkGroupAccepted
+	case 0:
+		report.GroupStatus = entity.BulkGroupRejected
+	default:
+		report.GroupStatus = entity.BulkGroupPartiallyAccepted
+	}
+
+	return report, nil
+}
+
+// execute - validating and sending a single instruction, returning the reason code of the rejection
+func (b *BulkUseCase) execute(ctx context.Context, instruction entity.BulkInstruction) (string, error) {
+	if instruction.From == "" || instruction.To == "" {
+		return entity.BulkReasonWrongAccount, entity.ErrWalletNotFound
+	}
+	if instruction.Currency != b.Currency {
+		return entity.BulkReasonWrongCurrency, fmt.Errorf("currency %q is not supported", instruction.Currency)
+	}
+
+	reference := instruction.EndToEndID
+	if reference == entity.EndToEndNotProvided {
+		reference = ""
+	}
+
+	_, err := b.wallet.SendFunds(ctx, instruction.From, entity.TransactionRequest{
+		To:                instruction.To,
+		Amount:            instruction.Amount,
+		Description:       statement.Truncate(instruction.Description, entity.MaxDescriptionLength),
+		ExternalReference: reference,
+		UniqueReference:   true,
+	})
+
+	return bulkReason(err), err
+}
+
+// bulkReason - mapping errors of transfers to ISO 20022 reason codes
+func bulkReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, entity.ErrWalletNotFound), errors.Is(err, entity.ErrReceiverNotFound):
+		return entity.BulkReasonWrongAccount
+	case errors.Is(err, entity.ErrWalletClosed):
+		return entity.BulkReasonClosedAccount
+	case errors.Is(err, entity.ErrWalletFrozen):
+		return entity.BulkReasonBlockedAccount
+	case errors.Is(err, entity.ErrSenderIsReceiver), errors.Is(err, entity.ErrPocketTransfer):
+		return entity.BulkReasonTransferForbidden
+	case errors.Is(err, entity.ErrInsufficientFunds):
+		return entity.BulkReasonInsufficientFunds
+	case errors.Is(err, entity.ErrWrongAmount):
+		return entity.BulkReasonWrongAmount
+	case errors.Is(err, entity.ErrDuplicateReference):
+		return entity.BulkReasonDuplication
+	}
+	return entity.BulkReasonNarrative
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// ChainUseCase -.
+type ChainUseCase struct {
+	repo       ChainRepo
+	SigningKey ed25519.PrivateKey
+	BatchSize  int
+}
+
+// NewChain -.
+func NewChain(r ChainRepo, signingKey ed25519.PrivateKey, batchSize int) *ChainUseCase {
+	return &ChainUseCase{
+		repo:       r,
+		SigningKey: signingKey,
+		BatchSize:  batchSize,
+	}
+}
+
+// ChainTransactions - linking the recorded transactions to the chain in batches. Transfers don't wait for the chain,
+// so it is extended by the job.
+func (ch *ChainUseCase) ChainTransactions(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		chained, err := ch.repo.ChainTransactions(ctx, ch.BatchSize)
+		if err != nil {
+			return total, fmt.Errorf("ChainUseCase - ChainTransactions - ch.repo.ChainTransactions: %w", err)
+		}
+		total += chained
+
+		if chained < ch.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// PublishHead - signing the last chained transaction, auditors store the signed heads outside of the service
+// to detect the history rewritten after the head was signed.
+func (ch *ChainUseCase) PublishHead(ctx context.Context) (*entity.ChainHead, error) {
+	if ch.SigningKey == nil {
+		return nil, fmt.Errorf("ChainUseCase - PublishHead: %w", entity.ErrChainSigningKeyMissing)
+	}
+
+	chain, err := ch.repo.GetChain(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ChainUseCase - PublishHead - ch.repo.GetChain: %w", err)
+	}
+	if chain.TransactionID == 0 {
+		return nil, fmt.Errorf("ChainUseCase - PublishHead: %w", entity.ErrEmptyChain)
+	}
+
+	// The time is stored with the precision of the database, so it is signed in seconds
+	head := &entity.ChainHead{
+		TransactionID: chain.TransactionID,
+		Hash:          chain.Hash,
+		SignedAt:      time.Now().UTC().Truncate(time.Second),
+		PublicKey:     base64.StdEncoding.EncodeToString(ch.SigningKey.Public().(ed25519.PublicKey)),
+	}
+	head.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(ch.SigningKey, head.Message()))
+
+	err = ch.repo.SaveChainHead(ctx, head)
+	if err != nil {
+		return nil, fmt.Errorf("ChainUseCase - PublishHead - ch.repo.SaveChainHead: %w", err)
+	}
+
+	return head, nil
+}
+
+// GetHeads - getting the published chain heads
+func (ch *ChainUseCase) GetHeads(ctx context.Context) ([]entity.ChainHead, error) {
+	heads, err := ch.repo.GetChainHeads(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ChainUseCase - GetHeads - ch.repo.GetChainHeads: %w", err)
+	}
+
+	return heads, nil
+}
+
+// VerifyChain - walking the chain in batches and recomputing the hash of every transaction. Stops at the first broken
+// link: a changed or removed transaction, a removed tail of the chain or a signed head not matching the transactions.
+func (ch *ChainUseCase) VerifyChain(ctx context.Context) (*entity.ChainVerification, error) {
+	verification := &entity.ChainVerification{}
+
+	// The transactions chained during the walk are verified too, but the chain head is checked as it was at the start
+	chain, err := ch.repo.GetChain(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ChainUseCase - VerifyChain - ch.repo.GetChain: %w", err)
+	}
+	heads, err := ch.repo.GetChainHeads(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ChainUseCase - VerifyChain - ch.repo.GetChainHeads: %w", err)
+	}
+
+	// Signed heads must be signed by the configured key if there is one
+	signedHeads := make(map[int64][]entity.ChainHead, len(heads))
+	for _, head := range heads {
+		if !ch.verifySignature(head) {
+			verification.Break = &entity.ChainBreak{TransactionID: head.TransactionID, Reason: entity.ChainBreakSignature}
+			return verification, nil
+		}
+		signedHeads[head.TransactionID] = append(signedHeads[head.TransactionID], head)
+	}
+	verification.Heads = len(heads)
+
+	prevHash := ""
+	headFound := chain.TransactionID == 0
+	afterPosition := int64(0)
+	for {
+		transactions, err := ch.repo.GetChainedTransactions(ctx, afterPosition, ch.BatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("ChainUseCase - VerifyChain - ch.repo.GetChainedTransactions: %w", err)
+		}
+
+		for i := range transactions {
+			t := &transactions[i]
+			verification.Transactions++
+
+			// A transaction keeps its position in the chain, so its hash can't be removed
+			if t.Hash == "" {
+				verification.Break = &entity.ChainBreak{TransactionID: t.ID, Reason: entity.ChainBreakNotChained}
+				return verification, nil
+			}
+			if t.PrevHash != prevHash {
+				verification.Break = &entity.ChainBreak{TransactionID: t.ID, Reason: entity.ChainBreakPrevHash}
+				return verification, nil
+			}
+			if t.ChainHash() != t.Hash {
+				verification.Break = &entity.ChainBreak{TransactionID: t.ID, Reason: entity.ChainBreakHash}
+				return verification, nil
+			}
+			if t.ID == chain.TransactionID {
+				if t.Hash != chain.Hash {
+					verification.Break = &entity.ChainBreak{TransactionID: t.ID, Reason: entity.ChainBreakHead}
+					return verification, nil
+				}
+				headFound = true
+			}
+			for _, head := range signedHeads[t.ID] {
+				if head.Hash != t.Hash {
+					verification.Break = &entity.ChainBreak{TransactionID: t.ID, Reason: entity.ChainBreakSignedHead}
+					return verification, nil
+				}
+			}
+			delete(signedHeads, t.ID)
+
+			prevHash = t.Hash
+		}
+
+		if len(transactions) < ch.BatchSize {
+			break
+		}
+		afterPosition = transactions[len(transactions)-1].ChainPosition
+	}
+
+	// Transactions recorded after the last run of the chain job are not chained yet, the ones chained during the walk
+	// are already counted
+	verification.Unchained, err = ch.repo.CountUnchainedTransactions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ChainUseCase - VerifyChain - ch.repo.CountUnchainedTransactions: %w", err)
+	}
+	verification.Transactions += verification.Unchained
+
+	// Removed transactions at the end of the chain don't break the links, but the heads still point at them
+	if !headFound {
+		verification.Break = &entity.ChainBreak{TransactionID: chain.TransactionID, Reason: entity.ChainBreakHead}
+		return verification, nil
+	}
+	missing := int64(0)
+	for transactionId := range signedHeads {
+		if missing == 0 || transactionId < missing {
+			missing = transactionId
+		}
+	}
+	if missing != 0 {
+		verification.Break = &entity.ChainBreak{TransactionID: missing, Reason: entity.ChainBreakMissingEntry}
+	}
+
+	return verification, nil
+}
+
+// verifySignature - checking the signature of the head with its public key, which must be the configured one.
+func (ch *ChainUseCase) verifySignature(head entity.ChainHead) bool {
+	publicKey, err := base64.StdEncoding.DecodeString(head.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	if ch.SigningKey != nil && !bytes.Equal(publicKey, ch.SigningKey.Public().(ed25519.PublicKey)) {
+		return false
+	}
+	signature, err := base64.StdEncoding.DecodeString(head.Signature)
+	if err != nil {
+		return false
+	}
+
+	return ed25519.Verify(publicKey, head.Message(), signature)
+}
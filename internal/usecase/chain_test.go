@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+// chainOf - transactions linked in the chain by their hashes in the given order.
+func chainOf(transactions ...entity.Transaction) []entity.Transaction {
+	prevHash := ""
+	for i := range transactions {
+		transactions[i].PrevHash = prevHash
+		transactions[i].Hash = transactions[i].ChainHash()
+		transactions[i].ChainPosition = int64(i + 1)
+		prevHash = transactions[i].Hash
+	}
+	return transactions
+}
+
+// testChain - the transaction 4 was committed before the transaction 2, so it is chained first.
+func testChain() []entity.Transaction {
+	now := time.Date(2024, 2, 5, 3, 0, 0, 0, time.UTC)
+	return chainOf(
+		entity.Transaction{ID: 1, Time: now, From: "system-deposit", To: "a", Amount: 100.0, Type: entity.TransactionTypeDeposit, FromBalanceAfter: -100.0, ToBalanceAfter: 100.0},
+		entity.Transaction{ID: 4, Time: now.Add(time.Minute), From: "b", To: "a", Amount: 10.0, Description: "Оплата по счету №42", Type: entity.TransactionTypeTransfer, FromBalanceAfter: 120.0, ToBalanceAfter: 110.0},
+		entity.Transaction{ID: 2, Time: now.Add(time.Hour), From: "a", To: "b", Amount: 30.0, Type: entity.TransactionTypeTransfer, FromBalanceAfter: 80.0, ToBalanceAfter: 100.0},
+	)
+}
+
+func TestChainHash(t *testing.T) {
+	transactions := testChain()
+
+	// Any change of the content or of the previous hash changes the hash
+	changed := transactions[1]
+	changed.Amount = 31.0
+	require.NotEqual(t, transactions[1].Hash, changed.ChainHash())
+
+	relinked := transactions[1]
+	relinked.PrevHash = ""
+	require.NotEqual(t, transactions[1].Hash, relinked.ChainHash())
+
+	// The hash doesn't depend on the time zone of the time
+	moved := transactions[1]
+	moved.Time = moved.Time.In(time.FixedZone("MSK", 3*60*60))
+	require.Equal(t, transactions[1].Hash, moved.ChainHash())
+}
+
+func TestChainTransactions(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockChainRepo(c)
+	gomock.InOrder(
+		repo.EXPECT().ChainTransactions(context.Background(), 2).Return(2, nil),
+		repo.EXPECT().ChainTransactions(context.Background(), 2).Return(1, nil),
+	)
+
+	chained, err := NewChain(repo, nil, 2).ChainTransactions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, chained)
+}
+
+func TestPublishHead(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	repo := mock_usecase.NewMockChainRepo(c)
+	repo.EXPECT().GetChain(context.Background()).Return(&entity.TransactionChain{ID: true, TransactionID: 4, Hash: "abc"}, nil)
+	repo.EXPECT().SaveChainHead(context.Background(), gomock.Any()).Return(nil)
+
+	head, err := NewChain(repo, key, 10).PublishHead(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, int64(4), head.TransactionID)
+	require.Equal(t, "abc", head.Hash)
+	require.Equal(t, base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)), head.PublicKey)
+
+	signature, err := base64.StdEncoding.DecodeString(head.Signature)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), head.Message(), signature))
+}
+
+func TestPublishHeadWithoutKey(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	_, err := NewChain(mock_usecase.NewMockChainRepo(c), nil, 10).PublishHead(context.Background())
+	require.ErrorIs(t, err, entity.ErrChainSigningKeyMissing)
+}
+
+func TestVerifyChain(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	otherKey := ed25519.NewKeyFromSeed([]byte("another-chain-signing-key-seed!!"))
+	signed := func(key ed25519.PrivateKey, transaction entity.Transaction) entity.ChainHead {
+		head := entity.ChainHead{
+			TransactionID: transaction.ID,
+			Hash:          transaction.Hash,
+			SignedAt:      time.Date(2024, 2, 5, 4, 0, 0, 0, time.UTC),
+			PublicKey:     base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		}
+		head.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, head.Message()))
+		return head
+	}
+
+	tests := []struct {
+		name          string
+		transactions  func() []entity.Transaction
+		unchained     int
+		heads         func(transactions []entity.Transaction) []entity.ChainHead
+		chain         func(transactions []entity.Transaction) *entity.TransactionChain
+		expectedBreak *entity.ChainBreak
+		expectedHeads int
+	}{
+		{
+			name:         "Ok",
+			transactions: testChain,
+			heads: func(transactions []entity.Transaction) []entity.ChainHead {
+				return []entity.ChainHead{signed(key, transactions[1]), signed(key, transactions[2])}
+			},
+			expectedHeads: 2,
+		},
+		{
+			name: "Changed amount",
+			transactions: func() []entity.Transaction {
+				transactions := testChain()
+				transactions[1].Amount = 3.0
+				return transactions
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 4, Reason: entity.ChainBreakHash},
+		},
+		{
+			name: "Removed transaction",
+			transactions: func() []entity.Transaction {
+				transactions := testChain()
+				return append(transactions[:1], transactions[2:]...)
+			},
+			chain: func(transactions []entity.Transaction) *entity.TransactionChain {
+				return &entity.TransactionChain{ID: true, TransactionID: 2, Hash: transactions[1].Hash}
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 2, Reason: entity.ChainBreakPrevHash},
+		},
+		{
+			name: "Rehashed chain",
+			transactions: func() []entity.Transaction {
+				transactions := testChain()
+				transactions[1].Amount = 3.0
+				return chainOf(transactions...)
+			},
+			heads: func([]entity.Transaction) []entity.ChainHead {
+				return []entity.ChainHead{signed(key, testChain()[1])}
+			},
+			chain: func(transactions []entity.Transaction) *entity.TransactionChain {
+				return &entity.TransactionChain{ID: true, TransactionID: 2, Hash: transactions[2].Hash}
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 4, Reason: entity.ChainBreakSignedHead},
+		},
+		{
+			name: "Removed tail",
+			transactions: func() []entity.Transaction {
+				return testChain()[:2]
+			},
+			chain: func([]entity.Transaction) *entity.TransactionChain {
+				transactions := testChain()
+				return &entity.TransactionChain{ID: true, TransactionID: 2, Hash: transactions[2].Hash}
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 2, Reason: entity.ChainBreakHead},
+		},
+		{
+			name: "Removed signed tail",
+			transactions: func() []entity.Transaction {
+				return testChain()[:2]
+			},
+			heads: func([]entity.Transaction) []entity.ChainHead {
+				return []entity.ChainHead{signed(key, testChain()[2])}
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 2, Reason: entity.ChainBreakMissingEntry},
+		},
+		{
+			name:         "Head signed by another key",
+			transactions: testChain,
+			heads: func(transactions []entity.Transaction) []entity.ChainHead {
+				return []entity.ChainHead{signed(otherKey, transactions[2])}
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 2, Reason: entity.ChainBreakSignature},
+		},
+		{
+			name: "Latest transactions not chained yet",
+			transactions: func() []entity.Transaction {
+				return testChain()[:2]
+			},
+			unchained: 1,
+		},
+		{
+			name: "Hash removed in the middle",
+			transactions: func() []entity.Transaction {
+				transactions := testChain()
+				transactions[1].PrevHash, transactions[1].Hash = "", ""
+				return transactions
+			},
+			expectedBreak: &entity.ChainBreak{TransactionID: 4, Reason: entity.ChainBreakNotChained},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			transactions := test.transactions()
+			heads := []entity.ChainHead{}
+			if test.heads != nil {
+				heads = test.heads(transactions)
+			}
+			last := transactions[len(transactions)-1]
+			chain := &entity.TransactionChain{ID: true, TransactionID: last.ID, Hash: last.Hash, Position: last.ChainPosition}
+			if test.chain != nil {
+				chain = test.chain(transactions)
+			}
+
+			// The transactions are read in batches of two following the position of the previous batch
+			repo := mock_usecase.NewMockChainRepo(c)
+			repo.EXPECT().GetChain(context.Background()).Return(chain, nil)
+			repo.EXPECT().GetChainHeads(context.Background()).Return(heads, nil)
+			afterPosition := int64(0)
+			for i := 0; i <= len(transactions); i += 2 {
+				batch := transactions[i:min(i+2, len(transactions))]
+				repo.EXPECT().GetChainedTransactions(context.Background(), afterPosition, 2).Return(batch, nil).MaxTimes(1)
+				if len(batch) > 0 {
+					afterPosition = batch[len(batch)-1].ChainPosition
+				}
+			}
+			repo.EXPECT().CountUnchainedTransactions(context.Background()).Return(test.unchained, nil).MaxTimes(1)
+
+			verification, err := NewChain(repo, key, 2).VerifyChain(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, test.expectedBreak, verification.Break)
+			if test.expectedBreak == nil {
+				require.Equal(t, len(transactions)+test.unchained, verification.Transactions)
+				require.Equal(t, test.unchained, verification.Unchained)
+				require.Equal(t, test.expectedHeads, verification.Heads)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// Events buffered for a subscriber, a subscriber which falls behind is disconnected and resumes from the table
+const subscriberBuffer = 64
+
+// Delays before reconnecting a closed listener, the delay doubles while the listener keeps failing
+const (
+	listenerMinBackoff = time.Second
+	listenerMaxBackoff = time.Minute
+)
+
+// EventUseCase - in-process broker of the wallet events received from the database listener.
+type EventUseCase struct {
+	repo       EventRepo
+	secret     []byte
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan entity.WalletEvent]struct{}
+}
+
+// NewEvent -.
+func NewEvent(r EventRepo, secret string) *EventUseCase {
+	return &EventUseCase{
+		repo:        r,
+		secret:      []byte(secret),
+		minBackoff:  listenerMinBackoff,
+		maxBackoff:  listenerMaxBackoff,
+		subscribers: make(map[string]map[chan entity.WalletEvent]struct{}),
+	}
+}
+
+// Listen - delivering events committed by all app instances to the subscribers until the context is done.
+// A closed listener is reconnected with a backoff, its errors and skipped events are passed to onError.
+func (e *EventUseCase) Listen(ctx context.Context, onError func(error)) {
+	delay := e.minBackoff
+	for {
+		started := time.Now()
+		err := e.repo.ListenWalletEvents(ctx, e.Publish, onError)
+		if ctx.Err() != nil {
+			return
+		}
+		onError(fmt.Errorf("EventUseCase - Listen - e.repo.ListenWalletEvents: %w", err))
+
+		// The listener which worked for a while is reconnected without waiting long
+		if time.Since(started) > e.maxBackoff {
+			delay = e.minBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, e.maxBackoff)
+	}
+}
+
+// Publish - passing the event to the subscribers of its wallet without blocking
+func (e *EventUseCase) Publish(event entity.WalletEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for live := range e.subscribers[event.WalletID] {
+		select {
+		case live <- event:
+		default:
+			delete(e.subscribers[event.WalletID], live)
+			close(live)
+		}
+	}
+	if len(e.subscribers[event.WalletID]) == 0 {
+		delete(e.subscribers, event.WalletID)
+	}
+}
+
+// Subscribe - getting events of the wallet after the sequence of the last received event followed by new events.
+// The channel is closed when the context is done or when the subscriber falls behind.
+func (e *EventUseCase) Subscribe(ctx context.Context, walletId string, lastSequence int64) (<-chan entity.WalletEvent, error) {
+	// Subscribing before reading the table so that no event is lost in between
+	live := e.subscribe(walletId)
+
+	var missed []entity.WalletEvent
+	if lastSequence > 0 {
+		var err error
+		missed, err = e.repo.GetWalletEvents(ctx, walletId, lastSequence)
+		if err != nil {
+			e.unsubscribe(walletId, live)
+
+			return nil, fmt.Errorf("EventUseCase - Subscribe - e.repo.GetWalletEvents: %w", err)
+		}
+	}
+
+	events := make(chan entity.WalletEvent)
+	go func() {
+		defer close(events)
+		defer e.unsubscribe(walletId, live)
+
+		last := lastSequence
+		send := func(event entity.WalletEvent) bool {
+			// Events from the table may be delivered by the listener as well
+			if event.Sequence <= last {
+				return true
+			}
+			select {
+			case events <- event:
+				last = event.Sequence
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range missed {
+			if !send(event) {
+				return
+			}
+		}
+		for {
+			select {
+			case event, ok := <-live:
+				if !ok || !send(event) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// SubscriptionToken - issuing the token which allows to subscribe to the events of the wallet
+func (e *EventUseCase) SubscriptionToken(walletId string) *entity.EventSubscription {
+	return &entity.EventSubscription{
+		WalletID: walletId,
+		Token:    hex.EncodeToString(e.sign(walletId)),
+	}
+}
+
+// CheckSubscriptionToken - checking the token of the wallet, tokens are unavailable without the secret
+func (e *EventUseCase) CheckSubscriptionToken(walletId string, token string) bool {
+	provided, err := hex.DecodeString(token)
+	if err != nil || len(e.secret) == 0 {
+		return false
+	}
+
+	return hmac.Equal(provided, e.sign(walletId))
+}
+
+func (e *EventUseCase) sign(walletId string) []byte {
+	mac := hmac.New(sha256.New, e.secret)
+	mac.Write([]byte(walletId))
+
+	return mac.Sum(nil)
+}
+
+func (e *EventUseCase) subscribe(walletId string) chan entity.WalletEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	live := make(chan entity.WalletEvent, subscriberBuffer)
+	if e.subscribers[walletId] == nil {
+		e.subscribers[walletId] = make(map[chan entity.WalletEvent]struct{})
+	}
+	e.subscribers[walletId][live] = struct{}{}
+
+	return live
+}
+
+// unsubscribe - removing the subscriber unless it is already removed for falling behind
+func (e *EventUseCase) unsubscribe(walletId string, live chan entity.WalletEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.subscribers[walletId][live]; !ok {
+		return
+	}
+	delete(e.subscribers[walletId], live)
+	close(live)
+	if len(e.subscribers[walletId]) == 0 {
+		delete(e.subscribers, walletId)
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestSubscribe(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := mock_usecase.NewMockEventRepo(c)
+	repo.EXPECT().GetWalletEvents(ctx, walletId, int64(40)).Return([]entity.WalletEvent{
+		{ID: 103, Sequence: 41, WalletID: walletId, Type: entity.WalletEventIncomingTransfer},
+		{ID: 101, Sequence: 42, WalletID: walletId, Type: entity.WalletEventOutgoingTransfer},
+	}, nil)
+
+	e := NewEvent(repo, "secret")
+	events, err := e.Subscribe(ctx, walletId, 40)
+	require.NoError(t, err)
+
+	// The listener delivers an event which is already read from the table and events of other wallets.
+	// Ids are taken on insert, so an event committed later may have a lower id
+	e.Publish(entity.WalletEvent{ID: 101, Sequence: 42, WalletID: walletId, Type: entity.WalletEventOutgoingTransfer})
+	e.Publish(entity.WalletEvent{ID: 104, Sequence: 7, WalletID: "eb376add88bf8e70f80787266a0801d5", Type: entity.WalletEventIncomingTransfer})
+	e.Publish(entity.WalletEvent{ID: 102, Sequence: 43, WalletID: walletId, Type: entity.WalletEventBalanceChanged})
+
+	for _, sequence := range []int64{41, 42, 43} {
+		event := <-events
+		require.Equal(t, sequence, event.Sequence)
+	}
+
+	cancel()
+	for range events {
+	}
+	require.Empty(t, e.subscribers)
+}
+
+func TestListenReconnects(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The closed listener is reconnected until the context is done
+	repo := mock_usecase.NewMockEventRepo(c)
+	gomock.InOrder(
+		repo.EXPECT().ListenWalletEvents(ctx, gomock.Any(), gomock.Any()).Return(errors.New("listener is closed")).Times(2),
+		repo.EXPECT().ListenWalletEvents(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ func(entity.WalletEvent), _ func(error)) error {
+				cancel()
+				return nil
+			}),
+	)
+
+	e := NewEvent(repo, "secret")
+	e.minBackoff = time.Millisecond
+	e.maxBackoff = 2 * time.Millisecond
+
+	var errs []error
+	e.Listen(ctx, func(err error) {
+		errs = append(errs, err)
+	})
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], "EventUseCase - Listen - e.repo.ListenWalletEvents: listener is closed")
+}
+
+func TestSubscribeSlowSubscriber(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+
+	e := NewEvent(mock_usecase.NewMockEventRepo(c), "secret")
+	events, err := e.Subscribe(context.Background(), walletId, 0)
+	require.NoError(t, err)
+
+	// The subscriber doesn't read, so the buffer overflows and the subscription ends
+	for id := int64(1); id <= subscriberBuffer+2; id++ {
+		e.Publish(entity.WalletEvent{ID: id, Sequence: id, WalletID: walletId})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	require.Less(t, received, subscriberBuffer+2)
+	require.Empty(t, e.subscribers)
+}
+
+func TestSubscriptionToken(t *testing.T) {
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+
+	e := NewEvent(nil, "secret")
+	subscription := e.SubscriptionToken(walletId)
+	require.Equal(t, walletId, subscription.WalletID)
+	require.True(t, e.CheckSubscriptionToken(walletId, subscription.Token))
+	require.False(t, e.CheckSubscriptionToken("eb376add88bf8e70f80787266a0801d5", subscription.Token))
+	require.False(t, e.CheckSubscriptionToken(walletId, "not-a-token"))
+
+	// Tokens are unavailable without the secret
+	e = NewEvent(nil, "")
+	require.False(t, e.CheckSubscriptionToken(walletId, e.SubscriptionToken(walletId).Token))
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// InterestUseCase -.
+type InterestUseCase struct {
+	repo          InterestRepo
+	outbox        OutboxRepo
+	CatchUpDays   int
+	OverdraftRate float64
+}
+
+// NewInterest - capitalized interest is stored together with its domain events in the outbox.
+func NewInterest(r InterestRepo, o OutboxRepo, catchUpDays int, overdraftRate float64) *InterestUseCase {
+	return &InterestUseCase{
+		repo:          r,
+		outbox:        o,
+		CatchUpDays:   catchUpDays,
+		OverdraftRate: overdraftRate,
+	}
+}
+
+// AccrueInterest - accruing interest of savings wallets and overdraft interest for the day (UTC) on end-of-day balances.
+// Already accrued wallets are skipped, so the day can be safely re-run.
+func (i *InterestUseCase) AccrueInterest(ctx context.Context, day time.Time) (int, error) {
+	day = startOfDay(day)
+	if !day.Before(startOfDay(time.Now())) {
+		return 0, entity.ErrDayIsNotOver
+	}
+
+	accrued, err := i.repo.AccrueInterest(ctx, day, i.OverdraftRate)
+	if err != nil {
+		return 0, fmt.Errorf("InterestUseCase - AccrueInterest - i.repo.AccrueInterest: %w", err)
+	}
+
+	return accrued, nil
+}
+
+// RunAccrualJob - accruing interest for the last finished days and capitalizing accruals of finished months
+func (i *InterestUseCase) RunAccrualJob(ctx context.Context) error {
+	today := startOfDay(time.Now())
+
+	// Days missed while the service was down are caught up
+	for n := i.CatchUpDays; n >= 1; n-- {
+		if _, err := i.AccrueInterest(ctx, today.AddDate(0, 0, -n)); err != nil {
+			return fmt.Errorf("InterestUseCase - RunAccrualJob - i.AccrueInterest: %w", err)
+		}
+	}
+
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if err := i.capitalizeInterest(ctx, monthStart); err != nil {
+		return fmt.Errorf("InterestUseCase - RunAccrualJob - i.capitalizeInterest: %w", err)
+	}
+
+	return nil
+}
+
+// capitalizeInterest - capitalizing accruals of every month before the moment and charging overdraft interest debts
+// left by previous months, each wallet and month in its own transaction. Frozen and closed wallets are skipped,
+// a frozen wallet is capitalized by the first run after it is unfrozen.
+func (i *InterestUseCase) capitalizeInterest(ctx context.Context, before time.Time) error {
+	periods, err := i.repo.GetCapitalizationPeriods(ctx, before)
+	if err != nil {
+		return fmt.Errorf("InterestUseCase - capitalizeInterest - i.repo.GetCapitalizationPeriods: %w", err)
+	}
+
+	for _, period := range periods {
+		err := i.outbox.Atomic(ctx, func(ctx context.Context) error {
+			transaction, err := i.repo.CapitalizeInterest(ctx, period.WalletID, period.Month)
+			if err != nil {
+				return fmt.Errorf("InterestUseCase - capitalizeInterest - i.repo.CapitalizeInterest: %w", err)
+			}
+
+			return i.addCapitalizedEvent(ctx, period.WalletID, transaction)
+		})
+		if err != nil && !isInactiveWallet(err) {
+			return err
+		}
+	}
+
+	debtors, err := i.repo.GetInterestDebtors(ctx)
+	if err != nil {
+		return fmt.Errorf("InterestUseCase - capitalizeInterest - i.repo.GetInterestDebtors: %w", err)
+	}
+
+	for _, walletId := range debtors {
+		err := i.outbox.Atomic(ctx, func(ctx context.Context) error {
+			transaction, err := i.repo.ChargeInterestDebt(ctx, walletId)
+			if err != nil {
+				return fmt.Errorf("InterestUseCase - capitalizeInterest - i.repo.ChargeInterestDebt: %w", err)
+			}
+
+			return i.addCapitalizedEvent(ctx, walletId, transaction)
+		})
+		if err != nil && !isInactiveWallet(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addCapitalizedEvent - storing the InterestCapitalized event of the wallet if funds were moved
+func (i *InterestUseCase) addCapitalizedEvent(ctx context.Context, walletId string, transaction *entity.Transaction) error {
+	if transaction == nil {
+		return nil
+	}
+
+	return addEvent(ctx, i.outbox, entity.DomainEventInterestCapitalized, walletId, fundsMoved(transaction))
+}
+
+// isInactiveWallet - the wallet is frozen or closed.
+func isInactiveWallet(err error) bool {
+	return errors.Is(err, entity.ErrWalletFrozen) || errors.Is(err, entity.ErrWalletClosed)
+}
+
+// GetInterestAccruals - getting daily interest accruals of a wallet
+func (i *InterestUseCase) GetInterestAccruals(ctx context.Context, walletId string) ([]entity.InterestAccrual, error) {
+	accruals, err := i.repo.GetInterestAccruals(ctx, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("InterestUseCase - GetInterestAccruals - i.repo.GetInterestAccruals: %w", err)
+	}
+
+	return accruals, nil
+}
+
+// startOfDay - truncating the time to the start of its day in UTC
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
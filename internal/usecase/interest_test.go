@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestCapitalizeInterestDomainEvents(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	month := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	repo := mock_usecase.NewMockInterestRepo(c)
+	repo.EXPECT().GetCapitalizationPeriods(gomock.Any(), gomock.Any()).Return([]entity.InterestPeriod{
+		{WalletID: "a", Month: month},
+		{WalletID: "b", Month: month},
+	}, nil)
+	repo.EXPECT().CapitalizeInterest(gomock.Any(), "a", month).
+		Return(&entity.Transaction{From: entity.SystemInterestWalletID, To: "a", Amount: 1.5, Type: entity.TransactionTypeInterest}, nil)
+	// Nothing is moved for a month with zero interest
+	repo.EXPECT().CapitalizeInterest(gomock.Any(), "b", month).Return(nil, nil)
+	repo.EXPECT().GetInterestDebtors(gomock.Any()).Return([]string{"c"}, nil)
+	repo.EXPECT().ChargeInterestDebt(gomock.Any(), "c").
+		Return(&entity.Transaction{From: "c", To: entity.SystemInterestWalletID, Amount: 3, Type: entity.TransactionTypeInterest}, nil)
+
+	// Each wallet is capitalized with its event in its own transaction
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(atomic)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	require.NoError(t, NewInterest(repo, outbox, 0, 0).RunAccrualJob(context.Background()))
+	require.Len(t, stored, 2)
+	require.Equal(t, entity.DomainEventInterestCapitalized, stored[0].Type)
+	require.Equal(t, "a", stored[0].AggregateID)
+	require.Equal(t, entity.DomainEventInterestCapitalized, stored[1].Type)
+	require.Equal(t, "c", stored[1].AggregateID)
+}
+
+func TestCapitalizeInterestSkipsInactiveWallets(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	month := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	repo := mock_usecase.NewMockInterestRepo(c)
+	repo.EXPECT().GetCapitalizationPeriods(gomock.Any(), gomock.Any()).Return([]entity.InterestPeriod{
+		{WalletID: "frozen", Month: month},
+		{WalletID: "a", Month: month},
+	}, nil)
+	// The frozen wallet is refused like in transfers, the others are still capitalized
+	repo.EXPECT().CapitalizeInterest(gomock.Any(), "frozen", month).Return(nil, entity.ErrWalletFrozen)
+	repo.EXPECT().CapitalizeInterest(gomock.Any(), "a", month).Return(nil, nil)
+	repo.EXPECT().GetInterestDebtors(gomock.Any()).Return([]string{"closed"}, nil)
+	repo.EXPECT().ChargeInterestDebt(gomock.Any(), "closed").Return(nil, entity.ErrWalletClosed)
+
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(atomic)
+
+	require.NoError(t, NewInterest(repo, outbox, 0, 0).RunAccrualJob(context.Background()))
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/egor-denisov/wallet-infotecs/internal/entity"
 )
@@ -11,17 +12,263 @@ import (
 type (
 	// Wallet - usecase interfaces.
 	Wallet interface {
-		CreateNewWalletWithDefaultBalance(c context.Context) (*entity.Wallet, error)
-		SendFunds(c context.Context, from string, to string, amount float64) error
-		GetWalletHistoryById(c context.Context, walletId string) ([]entity.Transaction, error)
+		CreateNewWalletWithDefaultBalance(c context.Context, walletType string) (*entity.Wallet, error)
+		SendFunds(c context.Context, from string, request entity.TransactionRequest) (*entity.Transaction, error)
+		GetWalletHistoryById(c context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error)
 		GetWalletById(c context.Context, walletId string) (*entity.Wallet, error)
+		SetCreditLimit(c context.Context, walletId string, creditLimit float64) (*entity.Wallet, error)
+		SetWalletStatus(c context.Context, walletId string, status string) (*entity.Wallet, error)
+		// LockWallet - the wallet locked against other changes till the end of the transaction of the context.
+		LockWallet(c context.Context, walletId string) (*entity.Wallet, error)
 	}
 
 	// WalletRepo - repository interfaces.
 	WalletRepo interface {
 		CreateNewWallet(с context.Context, wallet *entity.Wallet) (*entity.Wallet, error)
 		SendFunds(ctx context.Context, transaction *entity.Transaction) error
-		GetWalletHistoryById(c context.Context, walletId string) ([]entity.Transaction, error)
+		GetWalletHistoryById(c context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error)
 		GetWalletById(c context.Context, walletId string) (*entity.Wallet, error)
+		GetPromoGrants(c context.Context, walletId string) ([]entity.PromoGrant, error)
+		SetCreditLimit(c context.Context, walletId string, creditLimit float64) (*entity.Wallet, error)
+		SetWalletStatus(c context.Context, walletId string, status string) (*entity.Wallet, error)
+		GetPockets(c context.Context, walletId string) ([]entity.Wallet, error)
+		GetBalanceAt(c context.Context, walletId string, at time.Time) (float64, error)
+		// ReadSnapshot - running fn with the repository reads made with its context seeing one state of the wallets.
+		ReadSnapshot(c context.Context, fn func(c context.Context) error) error
+		// GetWalletVersion - the version of the wallet, it is locked against other changes till the end of the transaction.
+		GetWalletVersion(c context.Context, walletId string) (int64, error)
+	}
+
+	// Outbox - usecase interfaces.
+	Outbox interface {
+		RelayEvents(c context.Context) (int, error)
+	}
+
+	// OutboxRepo - repository interfaces.
+	OutboxRepo interface {
+		// Atomic - running fn in one transaction with the repository calls made with its context.
+		Atomic(c context.Context, fn func(c context.Context) error) error
+		AddEvents(c context.Context, events ...entity.DomainEvent) error
+		LockUnpublishedEvents(c context.Context, limit int) ([]entity.DomainEvent, error)
+		MarkPublished(c context.Context, ids []int64, at time.Time) error
+	}
+
+	// EventPublisher - delivering domain events to other components.
+	// An event can be published more than once, so consumers deduplicate them by ID.
+	EventPublisher interface {
+		Publish(c context.Context, event entity.DomainEvent) error
+	}
+
+	// Statement - usecase interfaces.
+	Statement interface {
+		GetStatement(c context.Context, walletId string, from time.Time, to time.Time) (*entity.Statement, error)
+	}
+
+	// Pocket - usecase interfaces.
+	Pocket interface {
+		CreatePocket(c context.Context, walletId string, name string) (*entity.Wallet, error)
+		MovePocketFunds(c context.Context, walletId string, pocketId string, request entity.PocketTransferRequest) (*entity.Transaction, error)
+		ClosePocket(c context.Context, walletId string, pocketId string) error
+	}
+
+	// PocketRepo - repository interfaces.
+	PocketRepo interface {
+		CreatePocket(c context.Context, pocket *entity.Wallet) (*entity.Wallet, error)
+		MovePocketFunds(c context.Context, walletId string, pocketId string, transaction *entity.Transaction) error
+		ClosePocket(c context.Context, walletId string, pocketId string) (*entity.Transaction, error)
+	}
+
+	// Event - usecase interfaces.
+	Event interface {
+		Subscribe(c context.Context, walletId string, lastSequence int64) (<-chan entity.WalletEvent, error)
+		SubscriptionToken(walletId string) *entity.EventSubscription
+		CheckSubscriptionToken(walletId string, token string) bool
+	}
+
+	// EventRepo - repository interfaces.
+	EventRepo interface {
+		GetWalletEvents(c context.Context, walletId string, afterSequence int64) ([]entity.WalletEvent, error)
+		ListenWalletEvents(c context.Context, handler func(entity.WalletEvent), onError func(error)) error
+	}
+
+	// Webhook - usecase interfaces.
+	Webhook interface {
+		CreateWebhook(c context.Context, request entity.WebhookRequest) (*entity.Webhook, error)
+		GetWebhooks(c context.Context, walletId string) ([]entity.Webhook, error)
+		DeleteWebhook(c context.Context, webhookId int64) error
+		GetDeliveries(c context.Context, webhookId int64, status string) ([]entity.WebhookDelivery, error)
+		ReplayDelivery(c context.Context, webhookId int64, deliveryId int64) (*entity.WebhookDelivery, error)
+		DispatchDeliveries(c context.Context) (int, error)
+	}
+
+	// WebhookRepo - repository interfaces.
+	WebhookRepo interface {
+		CreateWebhook(c context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+		GetWebhooks(c context.Context, walletId string) ([]entity.Webhook, error)
+		DeleteWebhook(c context.Context, webhookId int64) error
+		GetDeliveries(c context.Context, webhookId int64, status string) ([]entity.WebhookDelivery, error)
+		ClaimDeliveries(c context.Context, now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error)
+		UpdateDelivery(c context.Context, delivery *entity.WebhookDelivery) error
+		ReplayDelivery(c context.Context, webhookId int64, deliveryId int64, now time.Time) (*entity.WebhookDelivery, error)
+	}
+
+	// WebhookSender - delivering webhook payloads to the partner systems.
+	// Returns the status code of the response, zero if there is no response.
+	WebhookSender interface {
+		Send(c context.Context, delivery entity.WebhookDelivery) (int, error)
+	}
+
+	// Bulk - usecase interfaces.
+	Bulk interface {
+		ImportPayments(c context.Context, payment *entity.BulkPayment) (*entity.BulkStatusReport, error)
+	}
+
+	// Promo - usecase interfaces.
+	Promo interface {
+		GrantPromo(c context.Context, walletId string, amount float64) (*entity.PromoGrant, error)
+		ExpirePromoGrants(c context.Context) (int, error)
+	}
+
+	// PromoRepo - repository interfaces.
+	PromoRepo interface {
+		GrantPromo(c context.Context, grant *entity.PromoGrant) (*entity.PromoGrant, error)
+		GetExpiredPromoGrants(c context.Context, now time.Time) ([]entity.PromoGrant, error)
+		ExpirePromoGrant(c context.Context, candidate entity.PromoGrant, now time.Time) (*entity.Transaction, error)
+	}
+
+	// Voucher - usecase interfaces.
+	Voucher interface {
+		IssueVouchers(c context.Context, request entity.VoucherBatchRequest) (*entity.VoucherBatch, error)
+		RedeemVoucher(c context.Context, walletId string, code string) (*entity.Transaction, error)
+		RefundExpiredVouchers(c context.Context) (int, error)
+	}
+
+	// VoucherRepo - repository interfaces.
+	VoucherRepo interface {
+		// Atomic - running fn in one transaction with the repository calls made with its context.
+		Atomic(c context.Context, fn func(c context.Context) error) error
+		// LockRedemptions - holding the lock of the redemptions of the wallet until the transaction of the context ends.
+		LockRedemptions(c context.Context, walletId string) error
+		CreateVouchers(c context.Context, vouchers []entity.Voucher) error
+		RedeemVoucher(c context.Context, walletId string, codeHash string, now time.Time) (*entity.Transaction, error)
+		CountFailedRedemptions(c context.Context, walletId string, since time.Time) (int, error)
+		RecordFailedRedemption(c context.Context, walletId string, at time.Time) error
+		DeleteFailedRedemptions(c context.Context, before time.Time) error
+		RefundExpiredVouchers(c context.Context, now time.Time) ([]entity.Transaction, error)
+	}
+
+	// Payment - usecase interfaces.
+	Payment interface {
+		Deposit(c context.Context, walletId string, amount float64) (*entity.Payment, error)
+		Withdraw(c context.Context, walletId string, amount float64) (*entity.Payment, error)
+		HandleNotification(c context.Context, payload []byte, signature string) (*entity.Payment, error)
+		GetPaymentById(c context.Context, paymentId string) (*entity.Payment, error)
+	}
+
+	// PaymentRepo - repository interfaces.
+	PaymentRepo interface {
+		CreatePayment(c context.Context, payment *entity.Payment) (*entity.Payment, error)
+		UpdateGatewayReference(c context.Context, paymentId string, reference string) error
+		CompletePayment(c context.Context, paymentId string, status string, reference string) (*entity.Payment, bool, error)
+		GetPaymentById(c context.Context, paymentId string) (*entity.Payment, error)
+	}
+
+	// PaymentGateway - external payment provider interfaces.
+	// Operations are confirmed asynchronously with a notification to the callback endpoint.
+	PaymentGateway interface {
+		Deposit(c context.Context, payment entity.Payment) (string, error)
+		Withdraw(c context.Context, payment entity.Payment) (string, error)
+		ParseNotification(payload []byte, signature string) (*entity.PaymentNotification, error)
+	}
+
+	// Interest - usecase interfaces.
+	Interest interface {
+		AccrueInterest(c context.Context, day time.Time) (int, error)
+		RunAccrualJob(c context.Context) error
+		GetInterestAccruals(c context.Context, walletId string) ([]entity.InterestAccrual, error)
+	}
+
+	// InterestRepo - repository interfaces.
+	InterestRepo interface {
+		AccrueInterest(c context.Context, day time.Time, overdraftRate float64) (int, error)
+		GetCapitalizationPeriods(c context.Context, before time.Time) ([]entity.InterestPeriod, error)
+		CapitalizeInterest(c context.Context, walletId string, month time.Time) (*entity.Transaction, error)
+		GetInterestDebtors(c context.Context) ([]string, error)
+		ChargeInterestDebt(c context.Context, walletId string) (*entity.Transaction, error)
+		GetInterestAccruals(c context.Context, walletId string) ([]entity.InterestAccrual, error)
+	}
+
+	// Balance - usecase interfaces.
+	Balance interface {
+		GetBalanceAt(c context.Context, walletId string, at time.Time) (*entity.BalanceAt, error)
+		TakeSnapshots(c context.Context, day time.Time) (int, error)
+		RunSnapshotJob(c context.Context) error
+	}
+
+	// BalanceRepo - repository interfaces.
+	BalanceRepo interface {
+		TakeSnapshots(c context.Context, day time.Time) (int, error)
+	}
+
+	// Reconciliation - usecase interfaces.
+	Reconciliation interface {
+		Reconcile(c context.Context) (*entity.Reconciliation, error)
+		GetLastReconciliation(c context.Context) (*entity.Reconciliation, error)
+	}
+
+	// ReconciliationRepo - repository interfaces.
+	ReconciliationRepo interface {
+		SetInitialBalances(c context.Context, defaultBalance float64) (int, error)
+		GetWalletLedgers(c context.Context, afterId string, limit int) ([]entity.WalletLedger, error)
+		GetTotals(c context.Context) (float64, float64, error)
+		SaveReconciliation(c context.Context, reconciliation *entity.Reconciliation) error
+		GetLastReconciliation(c context.Context) (*entity.Reconciliation, error)
+	}
+
+	// Chain - usecase interfaces.
+	Chain interface {
+		ChainTransactions(c context.Context) (int, error)
+		PublishHead(c context.Context) (*entity.ChainHead, error)
+		GetHeads(c context.Context) ([]entity.ChainHead, error)
+		VerifyChain(c context.Context) (*entity.ChainVerification, error)
+	}
+
+	// ChainRepo - repository interfaces.
+	ChainRepo interface {
+		ChainTransactions(c context.Context, limit int) (int, error)
+		GetChain(c context.Context) (*entity.TransactionChain, error)
+		GetChainedTransactions(c context.Context, afterPosition int64, limit int) ([]entity.Transaction, error)
+		CountUnchainedTransactions(c context.Context) (int, error)
+		SaveChainHead(c context.Context, head *entity.ChainHead) error
+		GetChainHeads(c context.Context) ([]entity.ChainHead, error)
+	}
+
+	// Receipt - usecase interfaces.
+	Receipt interface {
+		IssueReceipt(transaction *entity.Transaction) (*entity.Receipt, error)
+		GetReceipt(c context.Context, transactionId int64, walletId string) (*entity.Receipt, error)
+		VerifyReceipt(receipt entity.Receipt) (*entity.ReceiptVerification, error)
+		GetPublicKey() (*entity.ReceiptKey, error)
+	}
+
+	// ReceiptRepo - repository interfaces.
+	ReceiptRepo interface {
+		GetTransactionById(c context.Context, transactionId int64) (*entity.Transaction, error)
+	}
+
+	// Audit - usecase interfaces.
+	Audit interface {
+		Record(c context.Context, entry entity.AuditEntry) error
+		GetEntries(c context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+		PurgeExpired(c context.Context) (int, error)
+	}
+
+	// AuditRepo - repository interfaces.
+	AuditRepo interface {
+		// Atomic - running fn in one transaction with the repository calls made with its context.
+		Atomic(c context.Context, fn func(c context.Context) error) error
+		AddAuditEntry(c context.Context, entry *entity.AuditEntry) error
+		GetAuditEntries(c context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+		DeleteAuditEntries(c context.Context, before time.Time) (int, error)
 	}
 )
@@ -7,6 +7,7 @@ package mock_usecase
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/egor-denisov/wallet-infotecs/internal/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -36,18 +37,18 @@ func (m *MockWallet) EXPECT() *MockWalletMockRecorder {
 }
 
 // CreateNewWalletWithDefaultBalance mocks base method.
-func (m *MockWallet) CreateNewWalletWithDefaultBalance(c context.Context) (*entity.Wallet, error) {
+func (m *MockWallet) CreateNewWalletWithDefaultBalance(c context.Context, walletType string) (*entity.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNewWalletWithDefaultBalance", c)
+	ret := m.ctrl.Call(m, "CreateNewWalletWithDefaultBalance", c, walletType)
 	ret0, _ := ret[0].(*entity.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNewWalletWithDefaultBalance indicates an expected call of CreateNewWalletWithDefaultBalance.
-func (mr *MockWalletMockRecorder) CreateNewWalletWithDefaultBalance(c interface{}) *gomock.Call {
+func (mr *MockWalletMockRecorder) CreateNewWalletWithDefaultBalance(c, walletType interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewWalletWithDefaultBalance", reflect.TypeOf((*MockWallet)(nil).CreateNewWalletWithDefaultBalance), c)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewWalletWithDefaultBalance", reflect.TypeOf((*MockWallet)(nil).CreateNewWalletWithDefaultBalance), c, walletType)
 }
 
 // GetWalletById mocks base method.
@@ -66,32 +67,78 @@ func (mr *MockWalletMockRecorder) GetWalletById(c, walletId interface{}) *gomock
 }
 
 // GetWalletHistoryById mocks base method.
-func (m *MockWallet) GetWalletHistoryById(c context.Context, walletId string) ([]entity.Transaction, error) {
+func (m *MockWallet) GetWalletHistoryById(c context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWalletHistoryById", c, walletId)
+	ret := m.ctrl.Call(m, "GetWalletHistoryById", c, walletId, filter)
 	ret0, _ := ret[0].([]entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetWalletHistoryById indicates an expected call of GetWalletHistoryById.
-func (mr *MockWalletMockRecorder) GetWalletHistoryById(c, walletId interface{}) *gomock.Call {
+func (mr *MockWalletMockRecorder) GetWalletHistoryById(c, walletId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletHistoryById", reflect.TypeOf((*MockWallet)(nil).GetWalletHistoryById), c, walletId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletHistoryById", reflect.TypeOf((*MockWallet)(nil).GetWalletHistoryById), c, walletId, filter)
+}
+
+// LockWallet mocks base method.
+func (m *MockWallet) LockWallet(c context.Context, walletId string) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockWallet", c, walletId)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockWallet indicates an expected call of LockWallet.
+func (mr *MockWalletMockRecorder) LockWallet(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWallet", reflect.TypeOf((*MockWallet)(nil).LockWallet), c, walletId)
 }
 
 // SendFunds mocks base method.
-func (m *MockWallet) SendFunds(c context.Context, from, to string, amount float64) error {
+func (m *MockWallet) SendFunds(c context.Context, from string, request entity.TransactionRequest) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendFunds", c, from, to, amount)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SendFunds", c, from, request)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendFunds indicates an expected call of SendFunds.
-func (mr *MockWalletMockRecorder) SendFunds(c, from, to, amount interface{}) *gomock.Call {
+func (mr *MockWalletMockRecorder) SendFunds(c, from, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendFunds", reflect.TypeOf((*MockWallet)(nil).SendFunds), c, from, request)
+}
+
+// SetCreditLimit mocks base method.
+func (m *MockWallet) SetCreditLimit(c context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCreditLimit", c, walletId, creditLimit)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCreditLimit indicates an expected call of SetCreditLimit.
+func (mr *MockWalletMockRecorder) SetCreditLimit(c, walletId, creditLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCreditLimit", reflect.TypeOf((*MockWallet)(nil).SetCreditLimit), c, walletId, creditLimit)
+}
+
+// SetWalletStatus mocks base method.
+func (m *MockWallet) SetWalletStatus(c context.Context, walletId, status string) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWalletStatus", c, walletId, status)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWalletStatus indicates an expected call of SetWalletStatus.
+func (mr *MockWalletMockRecorder) SetWalletStatus(c, walletId, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendFunds", reflect.TypeOf((*MockWallet)(nil).SendFunds), c, from, to, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWalletStatus", reflect.TypeOf((*MockWallet)(nil).SetWalletStatus), c, walletId, status)
 }
 
 // MockWalletRepo is a mock of WalletRepo interface.
@@ -132,6 +179,51 @@ func (mr *MockWalletRepoMockRecorder) CreateNewWallet(с, wallet interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewWallet", reflect.TypeOf((*MockWalletRepo)(nil).CreateNewWallet), с, wallet)
 }
 
+// GetBalanceAt mocks base method.
+func (m *MockWalletRepo) GetBalanceAt(c context.Context, walletId string, at time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceAt", c, walletId, at)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceAt indicates an expected call of GetBalanceAt.
+func (mr *MockWalletRepoMockRecorder) GetBalanceAt(c, walletId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceAt", reflect.TypeOf((*MockWalletRepo)(nil).GetBalanceAt), c, walletId, at)
+}
+
+// GetPockets mocks base method.
+func (m *MockWalletRepo) GetPockets(c context.Context, walletId string) ([]entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPockets", c, walletId)
+	ret0, _ := ret[0].([]entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPockets indicates an expected call of GetPockets.
+func (mr *MockWalletRepoMockRecorder) GetPockets(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPockets", reflect.TypeOf((*MockWalletRepo)(nil).GetPockets), c, walletId)
+}
+
+// GetPromoGrants mocks base method.
+func (m *MockWalletRepo) GetPromoGrants(c context.Context, walletId string) ([]entity.PromoGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoGrants", c, walletId)
+	ret0, _ := ret[0].([]entity.PromoGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoGrants indicates an expected call of GetPromoGrants.
+func (mr *MockWalletRepoMockRecorder) GetPromoGrants(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoGrants", reflect.TypeOf((*MockWalletRepo)(nil).GetPromoGrants), c, walletId)
+}
+
 // GetWalletById mocks base method.
 func (m *MockWalletRepo) GetWalletById(c context.Context, walletId string) (*entity.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -148,18 +240,47 @@ func (mr *MockWalletRepoMockRecorder) GetWalletById(c, walletId interface{}) *go
 }
 
 // GetWalletHistoryById mocks base method.
-func (m *MockWalletRepo) GetWalletHistoryById(c context.Context, walletId string) ([]entity.Transaction, error) {
+func (m *MockWalletRepo) GetWalletHistoryById(c context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWalletHistoryById", c, walletId)
+	ret := m.ctrl.Call(m, "GetWalletHistoryById", c, walletId, filter)
 	ret0, _ := ret[0].([]entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetWalletHistoryById indicates an expected call of GetWalletHistoryById.
-func (mr *MockWalletRepoMockRecorder) GetWalletHistoryById(c, walletId interface{}) *gomock.Call {
+func (mr *MockWalletRepoMockRecorder) GetWalletHistoryById(c, walletId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletHistoryById", reflect.TypeOf((*MockWalletRepo)(nil).GetWalletHistoryById), c, walletId, filter)
+}
+
+// GetWalletVersion mocks base method.
+func (m *MockWalletRepo) GetWalletVersion(c context.Context, walletId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletVersion", c, walletId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletVersion indicates an expected call of GetWalletVersion.
+func (mr *MockWalletRepoMockRecorder) GetWalletVersion(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletVersion", reflect.TypeOf((*MockWalletRepo)(nil).GetWalletVersion), c, walletId)
+}
+
+// ReadSnapshot mocks base method.
+func (m *MockWalletRepo) ReadSnapshot(c context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSnapshot", c, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadSnapshot indicates an expected call of ReadSnapshot.
+func (mr *MockWalletRepoMockRecorder) ReadSnapshot(c, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletHistoryById", reflect.TypeOf((*MockWalletRepo)(nil).GetWalletHistoryById), c, walletId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSnapshot", reflect.TypeOf((*MockWalletRepo)(nil).ReadSnapshot), c, fn)
 }
 
 // SendFunds mocks base method.
@@ -175,3 +296,2258 @@ func (mr *MockWalletRepoMockRecorder) SendFunds(ctx, transaction interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendFunds", reflect.TypeOf((*MockWalletRepo)(nil).SendFunds), ctx, transaction)
 }
+
+// SetCreditLimit mocks base method.
+func (m *MockWalletRepo) SetCreditLimit(c context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCreditLimit", c, walletId, creditLimit)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCreditLimit indicates an expected call of SetCreditLimit.
+func (mr *MockWalletRepoMockRecorder) SetCreditLimit(c, walletId, creditLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCreditLimit", reflect.TypeOf((*MockWalletRepo)(nil).SetCreditLimit), c, walletId, creditLimit)
+}
+
+// SetWalletStatus mocks base method.
+func (m *MockWalletRepo) SetWalletStatus(c context.Context, walletId, status string) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWalletStatus", c, walletId, status)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWalletStatus indicates an expected call of SetWalletStatus.
+func (mr *MockWalletRepoMockRecorder) SetWalletStatus(c, walletId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWalletStatus", reflect.TypeOf((*MockWalletRepo)(nil).SetWalletStatus), c, walletId, status)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// RelayEvents mocks base method.
+func (m *MockOutbox) RelayEvents(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayEvents", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayEvents indicates an expected call of RelayEvents.
+func (mr *MockOutboxMockRecorder) RelayEvents(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayEvents", reflect.TypeOf((*MockOutbox)(nil).RelayEvents), c)
+}
+
+// MockOutboxRepo is a mock of OutboxRepo interface.
+type MockOutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepoMockRecorder
+}
+
+// MockOutboxRepoMockRecorder is the mock recorder for MockOutboxRepo.
+type MockOutboxRepoMockRecorder struct {
+	mock *MockOutboxRepo
+}
+
+// NewMockOutboxRepo creates a new mock instance.
+func NewMockOutboxRepo(ctrl *gomock.Controller) *MockOutboxRepo {
+	mock := &MockOutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepo) EXPECT() *MockOutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// AddEvents mocks base method.
+func (m *MockOutboxRepo) AddEvents(c context.Context, events ...entity.DomainEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{c}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddEvents", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvents indicates an expected call of AddEvents.
+func (mr *MockOutboxRepoMockRecorder) AddEvents(c interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{c}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockOutboxRepo)(nil).AddEvents), varargs...)
+}
+
+// Atomic mocks base method.
+func (m *MockOutboxRepo) Atomic(c context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Atomic", c, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Atomic indicates an expected call of Atomic.
+func (mr *MockOutboxRepoMockRecorder) Atomic(c, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atomic", reflect.TypeOf((*MockOutboxRepo)(nil).Atomic), c, fn)
+}
+
+// LockUnpublishedEvents mocks base method.
+func (m *MockOutboxRepo) LockUnpublishedEvents(c context.Context, limit int) ([]entity.DomainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUnpublishedEvents", c, limit)
+	ret0, _ := ret[0].([]entity.DomainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockUnpublishedEvents indicates an expected call of LockUnpublishedEvents.
+func (mr *MockOutboxRepoMockRecorder) LockUnpublishedEvents(c, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUnpublishedEvents", reflect.TypeOf((*MockOutboxRepo)(nil).LockUnpublishedEvents), c, limit)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxRepo) MarkPublished(c context.Context, ids []int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", c, ids, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxRepoMockRecorder) MarkPublished(c, ids, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxRepo)(nil).MarkPublished), c, ids, at)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(c context.Context, event entity.DomainEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", c, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(c, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), c, event)
+}
+
+// MockStatement is a mock of Statement interface.
+type MockStatement struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementMockRecorder
+}
+
+// MockStatementMockRecorder is the mock recorder for MockStatement.
+type MockStatementMockRecorder struct {
+	mock *MockStatement
+}
+
+// NewMockStatement creates a new mock instance.
+func NewMockStatement(ctrl *gomock.Controller) *MockStatement {
+	mock := &MockStatement{ctrl: ctrl}
+	mock.recorder = &MockStatementMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatement) EXPECT() *MockStatementMockRecorder {
+	return m.recorder
+}
+
+// GetStatement mocks base method.
+func (m *MockStatement) GetStatement(c context.Context, walletId string, from, to time.Time) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", c, walletId, from, to)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementMockRecorder) GetStatement(c, walletId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatement)(nil).GetStatement), c, walletId, from, to)
+}
+
+// MockPocket is a mock of Pocket interface.
+type MockPocket struct {
+	ctrl     *gomock.Controller
+	recorder *MockPocketMockRecorder
+}
+
+// MockPocketMockRecorder is the mock recorder for MockPocket.
+type MockPocketMockRecorder struct {
+	mock *MockPocket
+}
+
+// NewMockPocket creates a new mock instance.
+func NewMockPocket(ctrl *gomock.Controller) *MockPocket {
+	mock := &MockPocket{ctrl: ctrl}
+	mock.recorder = &MockPocketMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPocket) EXPECT() *MockPocketMockRecorder {
+	return m.recorder
+}
+
+// ClosePocket mocks base method.
+func (m *MockPocket) ClosePocket(c context.Context, walletId, pocketId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePocket", c, walletId, pocketId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePocket indicates an expected call of ClosePocket.
+func (mr *MockPocketMockRecorder) ClosePocket(c, walletId, pocketId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePocket", reflect.TypeOf((*MockPocket)(nil).ClosePocket), c, walletId, pocketId)
+}
+
+// CreatePocket mocks base method.
+func (m *MockPocket) CreatePocket(c context.Context, walletId, name string) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", c, walletId, name)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockPocketMockRecorder) CreatePocket(c, walletId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockPocket)(nil).CreatePocket), c, walletId, name)
+}
+
+// MovePocketFunds mocks base method.
+func (m *MockPocket) MovePocketFunds(c context.Context, walletId, pocketId string, request entity.PocketTransferRequest) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePocketFunds", c, walletId, pocketId, request)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePocketFunds indicates an expected call of MovePocketFunds.
+func (mr *MockPocketMockRecorder) MovePocketFunds(c, walletId, pocketId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePocketFunds", reflect.TypeOf((*MockPocket)(nil).MovePocketFunds), c, walletId, pocketId, request)
+}
+
+// MockPocketRepo is a mock of PocketRepo interface.
+type MockPocketRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPocketRepoMockRecorder
+}
+
+// MockPocketRepoMockRecorder is the mock recorder for MockPocketRepo.
+type MockPocketRepoMockRecorder struct {
+	mock *MockPocketRepo
+}
+
+// NewMockPocketRepo creates a new mock instance.
+func NewMockPocketRepo(ctrl *gomock.Controller) *MockPocketRepo {
+	mock := &MockPocketRepo{ctrl: ctrl}
+	mock.recorder = &MockPocketRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPocketRepo) EXPECT() *MockPocketRepoMockRecorder {
+	return m.recorder
+}
+
+// ClosePocket mocks base method.
+func (m *MockPocketRepo) ClosePocket(c context.Context, walletId, pocketId string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePocket", c, walletId, pocketId)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePocket indicates an expected call of ClosePocket.
+func (mr *MockPocketRepoMockRecorder) ClosePocket(c, walletId, pocketId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePocket", reflect.TypeOf((*MockPocketRepo)(nil).ClosePocket), c, walletId, pocketId)
+}
+
+// CreatePocket mocks base method.
+func (m *MockPocketRepo) CreatePocket(c context.Context, pocket *entity.Wallet) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", c, pocket)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockPocketRepoMockRecorder) CreatePocket(c, pocket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockPocketRepo)(nil).CreatePocket), c, pocket)
+}
+
+// MovePocketFunds mocks base method.
+func (m *MockPocketRepo) MovePocketFunds(c context.Context, walletId, pocketId string, transaction *entity.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePocketFunds", c, walletId, pocketId, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MovePocketFunds indicates an expected call of MovePocketFunds.
+func (mr *MockPocketRepoMockRecorder) MovePocketFunds(c, walletId, pocketId, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePocketFunds", reflect.TypeOf((*MockPocketRepo)(nil).MovePocketFunds), c, walletId, pocketId, transaction)
+}
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// CheckSubscriptionToken mocks base method.
+func (m *MockEvent) CheckSubscriptionToken(walletId, token string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSubscriptionToken", walletId, token)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckSubscriptionToken indicates an expected call of CheckSubscriptionToken.
+func (mr *MockEventMockRecorder) CheckSubscriptionToken(walletId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSubscriptionToken", reflect.TypeOf((*MockEvent)(nil).CheckSubscriptionToken), walletId, token)
+}
+
+// Subscribe mocks base method.
+func (m *MockEvent) Subscribe(c context.Context, walletId string, lastSequence int64) (<-chan entity.WalletEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", c, walletId, lastSequence)
+	ret0, _ := ret[0].(<-chan entity.WalletEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventMockRecorder) Subscribe(c, walletId, lastSequence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEvent)(nil).Subscribe), c, walletId, lastSequence)
+}
+
+// SubscriptionToken mocks base method.
+func (m *MockEvent) SubscriptionToken(walletId string) *entity.EventSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionToken", walletId)
+	ret0, _ := ret[0].(*entity.EventSubscription)
+	return ret0
+}
+
+// SubscriptionToken indicates an expected call of SubscriptionToken.
+func (mr *MockEventMockRecorder) SubscriptionToken(walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionToken", reflect.TypeOf((*MockEvent)(nil).SubscriptionToken), walletId)
+}
+
+// MockEventRepo is a mock of EventRepo interface.
+type MockEventRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepoMockRecorder
+}
+
+// MockEventRepoMockRecorder is the mock recorder for MockEventRepo.
+type MockEventRepoMockRecorder struct {
+	mock *MockEventRepo
+}
+
+// NewMockEventRepo creates a new mock instance.
+func NewMockEventRepo(ctrl *gomock.Controller) *MockEventRepo {
+	mock := &MockEventRepo{ctrl: ctrl}
+	mock.recorder = &MockEventRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepo) EXPECT() *MockEventRepoMockRecorder {
+	return m.recorder
+}
+
+// GetWalletEvents mocks base method.
+func (m *MockEventRepo) GetWalletEvents(c context.Context, walletId string, afterSequence int64) ([]entity.WalletEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletEvents", c, walletId, afterSequence)
+	ret0, _ := ret[0].([]entity.WalletEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletEvents indicates an expected call of GetWalletEvents.
+func (mr *MockEventRepoMockRecorder) GetWalletEvents(c, walletId, afterSequence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletEvents", reflect.TypeOf((*MockEventRepo)(nil).GetWalletEvents), c, walletId, afterSequence)
+}
+
+// ListenWalletEvents mocks base method.
+func (m *MockEventRepo) ListenWalletEvents(c context.Context, handler func(entity.WalletEvent), onError func(error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenWalletEvents", c, handler, onError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListenWalletEvents indicates an expected call of ListenWalletEvents.
+func (mr *MockEventRepoMockRecorder) ListenWalletEvents(c, handler, onError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenWalletEvents", reflect.TypeOf((*MockEventRepo)(nil).ListenWalletEvents), c, handler, onError)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhook) CreateWebhook(c context.Context, request entity.WebhookRequest) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", c, request)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookMockRecorder) CreateWebhook(c, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhook)(nil).CreateWebhook), c, request)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(c context.Context, webhookId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", c, webhookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(c, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), c, webhookId)
+}
+
+// DispatchDeliveries mocks base method.
+func (m *MockWebhook) DispatchDeliveries(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchDeliveries", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchDeliveries indicates an expected call of DispatchDeliveries.
+func (mr *MockWebhookMockRecorder) DispatchDeliveries(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchDeliveries", reflect.TypeOf((*MockWebhook)(nil).DispatchDeliveries), c)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(c context.Context, webhookId int64, status string) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", c, webhookId, status)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(c, webhookId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), c, webhookId, status)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(c context.Context, walletId string) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", c, walletId)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), c, walletId)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockWebhook) ReplayDelivery(c context.Context, webhookId, deliveryId int64) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", c, webhookId, deliveryId)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockWebhookMockRecorder) ReplayDelivery(c, webhookId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockWebhook)(nil).ReplayDelivery), c, webhookId, deliveryId)
+}
+
+// MockWebhookRepo is a mock of WebhookRepo interface.
+type MockWebhookRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepoMockRecorder
+}
+
+// MockWebhookRepoMockRecorder is the mock recorder for MockWebhookRepo.
+type MockWebhookRepoMockRecorder struct {
+	mock *MockWebhookRepo
+}
+
+// NewMockWebhookRepo creates a new mock instance.
+func NewMockWebhookRepo(ctrl *gomock.Controller) *MockWebhookRepo {
+	mock := &MockWebhookRepo{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepo) EXPECT() *MockWebhookRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhookRepo) ClaimDeliveries(c context.Context, now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", c, now, lease, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookRepoMockRecorder) ClaimDeliveries(c, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhookRepo)(nil).ClaimDeliveries), c, now, lease, limit)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookRepo) CreateWebhook(c context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", c, webhook)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookRepoMockRecorder) CreateWebhook(c, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookRepo)(nil).CreateWebhook), c, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepo) DeleteWebhook(c context.Context, webhookId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", c, webhookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepoMockRecorder) DeleteWebhook(c, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepo)(nil).DeleteWebhook), c, webhookId)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookRepo) GetDeliveries(c context.Context, webhookId int64, status string) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", c, webhookId, status)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookRepoMockRecorder) GetDeliveries(c, webhookId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookRepo)(nil).GetDeliveries), c, webhookId, status)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhookRepo) GetWebhooks(c context.Context, walletId string) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", c, walletId)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookRepoMockRecorder) GetWebhooks(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookRepo)(nil).GetWebhooks), c, walletId)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockWebhookRepo) ReplayDelivery(c context.Context, webhookId, deliveryId int64, now time.Time) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", c, webhookId, deliveryId, now)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockWebhookRepoMockRecorder) ReplayDelivery(c, webhookId, deliveryId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockWebhookRepo)(nil).ReplayDelivery), c, webhookId, deliveryId, now)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookRepo) UpdateDelivery(c context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", c, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookRepoMockRecorder) UpdateDelivery(c, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookRepo)(nil).UpdateDelivery), c, delivery)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(c context.Context, delivery entity.WebhookDelivery) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", c, delivery)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(c, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), c, delivery)
+}
+
+// MockBulk is a mock of Bulk interface.
+type MockBulk struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkMockRecorder
+}
+
+// MockBulkMockRecorder is the mock recorder for MockBulk.
+type MockBulkMockRecorder struct {
+	mock *MockBulk
+}
+
+// NewMockBulk creates a new mock instance.
+func NewMockBulk(ctrl *gomock.Controller) *MockBulk {
+	mock := &MockBulk{ctrl: ctrl}
+	mock.recorder = &MockBulkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulk) EXPECT() *MockBulkMockRecorder {
+	return m.recorder
+}
+
+// ImportPayments mocks base method.
+func (m *MockBulk) ImportPayments(c context.Context, payment *entity.BulkPayment) (*entity.BulkStatusReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPayments", c, payment)
+	ret0, _ := ret[0].(*entity.BulkStatusReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportPayments indicates an expected call of ImportPayments.
+func (mr *MockBulkMockRecorder) ImportPayments(c, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPayments", reflect.TypeOf((*MockBulk)(nil).ImportPayments), c, payment)
+}
+
+// MockPromo is a mock of Promo interface.
+type MockPromo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoMockRecorder
+}
+
+// MockPromoMockRecorder is the mock recorder for MockPromo.
+type MockPromoMockRecorder struct {
+	mock *MockPromo
+}
+
+// NewMockPromo creates a new mock instance.
+func NewMockPromo(ctrl *gomock.Controller) *MockPromo {
+	mock := &MockPromo{ctrl: ctrl}
+	mock.recorder = &MockPromoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromo) EXPECT() *MockPromoMockRecorder {
+	return m.recorder
+}
+
+// ExpirePromoGrants mocks base method.
+func (m *MockPromo) ExpirePromoGrants(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePromoGrants", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePromoGrants indicates an expected call of ExpirePromoGrants.
+func (mr *MockPromoMockRecorder) ExpirePromoGrants(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePromoGrants", reflect.TypeOf((*MockPromo)(nil).ExpirePromoGrants), c)
+}
+
+// GrantPromo mocks base method.
+func (m *MockPromo) GrantPromo(c context.Context, walletId string, amount float64) (*entity.PromoGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantPromo", c, walletId, amount)
+	ret0, _ := ret[0].(*entity.PromoGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantPromo indicates an expected call of GrantPromo.
+func (mr *MockPromoMockRecorder) GrantPromo(c, walletId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantPromo", reflect.TypeOf((*MockPromo)(nil).GrantPromo), c, walletId, amount)
+}
+
+// MockPromoRepo is a mock of PromoRepo interface.
+type MockPromoRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoRepoMockRecorder
+}
+
+// MockPromoRepoMockRecorder is the mock recorder for MockPromoRepo.
+type MockPromoRepoMockRecorder struct {
+	mock *MockPromoRepo
+}
+
+// NewMockPromoRepo creates a new mock instance.
+func NewMockPromoRepo(ctrl *gomock.Controller) *MockPromoRepo {
+	mock := &MockPromoRepo{ctrl: ctrl}
+	mock.recorder = &MockPromoRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoRepo) EXPECT() *MockPromoRepoMockRecorder {
+	return m.recorder
+}
+
+// ExpirePromoGrant mocks base method.
+func (m *MockPromoRepo) ExpirePromoGrant(c context.Context, candidate entity.PromoGrant, now time.Time) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePromoGrant", c, candidate, now)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePromoGrant indicates an expected call of ExpirePromoGrant.
+func (mr *MockPromoRepoMockRecorder) ExpirePromoGrant(c, candidate, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePromoGrant", reflect.TypeOf((*MockPromoRepo)(nil).ExpirePromoGrant), c, candidate, now)
+}
+
+// GetExpiredPromoGrants mocks base method.
+func (m *MockPromoRepo) GetExpiredPromoGrants(c context.Context, now time.Time) ([]entity.PromoGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredPromoGrants", c, now)
+	ret0, _ := ret[0].([]entity.PromoGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredPromoGrants indicates an expected call of GetExpiredPromoGrants.
+func (mr *MockPromoRepoMockRecorder) GetExpiredPromoGrants(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredPromoGrants", reflect.TypeOf((*MockPromoRepo)(nil).GetExpiredPromoGrants), c, now)
+}
+
+// GrantPromo mocks base method.
+func (m *MockPromoRepo) GrantPromo(c context.Context, grant *entity.PromoGrant) (*entity.PromoGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantPromo", c, grant)
+	ret0, _ := ret[0].(*entity.PromoGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantPromo indicates an expected call of GrantPromo.
+func (mr *MockPromoRepoMockRecorder) GrantPromo(c, grant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantPromo", reflect.TypeOf((*MockPromoRepo)(nil).GrantPromo), c, grant)
+}
+
+// MockVoucher is a mock of Voucher interface.
+type MockVoucher struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoucherMockRecorder
+}
+
+// MockVoucherMockRecorder is the mock recorder for MockVoucher.
+type MockVoucherMockRecorder struct {
+	mock *MockVoucher
+}
+
+// NewMockVoucher creates a new mock instance.
+func NewMockVoucher(ctrl *gomock.Controller) *MockVoucher {
+	mock := &MockVoucher{ctrl: ctrl}
+	mock.recorder = &MockVoucherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoucher) EXPECT() *MockVoucherMockRecorder {
+	return m.recorder
+}
+
+// IssueVouchers mocks base method.
+func (m *MockVoucher) IssueVouchers(c context.Context, request entity.VoucherBatchRequest) (*entity.VoucherBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueVouchers", c, request)
+	ret0, _ := ret[0].(*entity.VoucherBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueVouchers indicates an expected call of IssueVouchers.
+func (mr *MockVoucherMockRecorder) IssueVouchers(c, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueVouchers", reflect.TypeOf((*MockVoucher)(nil).IssueVouchers), c, request)
+}
+
+// RedeemVoucher mocks base method.
+func (m *MockVoucher) RedeemVoucher(c context.Context, walletId, code string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemVoucher", c, walletId, code)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemVoucher indicates an expected call of RedeemVoucher.
+func (mr *MockVoucherMockRecorder) RedeemVoucher(c, walletId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemVoucher", reflect.TypeOf((*MockVoucher)(nil).RedeemVoucher), c, walletId, code)
+}
+
+// RefundExpiredVouchers mocks base method.
+func (m *MockVoucher) RefundExpiredVouchers(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundExpiredVouchers", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundExpiredVouchers indicates an expected call of RefundExpiredVouchers.
+func (mr *MockVoucherMockRecorder) RefundExpiredVouchers(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundExpiredVouchers", reflect.TypeOf((*MockVoucher)(nil).RefundExpiredVouchers), c)
+}
+
+// MockVoucherRepo is a mock of VoucherRepo interface.
+type MockVoucherRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoucherRepoMockRecorder
+}
+
+// MockVoucherRepoMockRecorder is the mock recorder for MockVoucherRepo.
+type MockVoucherRepoMockRecorder struct {
+	mock *MockVoucherRepo
+}
+
+// NewMockVoucherRepo creates a new mock instance.
+func NewMockVoucherRepo(ctrl *gomock.Controller) *MockVoucherRepo {
+	mock := &MockVoucherRepo{ctrl: ctrl}
+	mock.recorder = &MockVoucherRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoucherRepo) EXPECT() *MockVoucherRepoMockRecorder {
+	return m.recorder
+}
+
+// Atomic mocks base method.
+func (m *MockVoucherRepo) Atomic(c context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Atomic", c, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Atomic indicates an expected call of Atomic.
+func (mr *MockVoucherRepoMockRecorder) Atomic(c, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atomic", reflect.TypeOf((*MockVoucherRepo)(nil).Atomic), c, fn)
+}
+
+// CountFailedRedemptions mocks base method.
+func (m *MockVoucherRepo) CountFailedRedemptions(c context.Context, walletId string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedRedemptions", c, walletId, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFailedRedemptions indicates an expected call of CountFailedRedemptions.
+func (mr *MockVoucherRepoMockRecorder) CountFailedRedemptions(c, walletId, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedRedemptions", reflect.TypeOf((*MockVoucherRepo)(nil).CountFailedRedemptions), c, walletId, since)
+}
+
+// CreateVouchers mocks base method.
+func (m *MockVoucherRepo) CreateVouchers(c context.Context, vouchers []entity.Voucher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVouchers", c, vouchers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVouchers indicates an expected call of CreateVouchers.
+func (mr *MockVoucherRepoMockRecorder) CreateVouchers(c, vouchers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVouchers", reflect.TypeOf((*MockVoucherRepo)(nil).CreateVouchers), c, vouchers)
+}
+
+// DeleteFailedRedemptions mocks base method.
+func (m *MockVoucherRepo) DeleteFailedRedemptions(c context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFailedRedemptions", c, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFailedRedemptions indicates an expected call of DeleteFailedRedemptions.
+func (mr *MockVoucherRepoMockRecorder) DeleteFailedRedemptions(c, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFailedRedemptions", reflect.TypeOf((*MockVoucherRepo)(nil).DeleteFailedRedemptions), c, before)
+}
+
+// LockRedemptions mocks base method.
+func (m *MockVoucherRepo) LockRedemptions(c context.Context, walletId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRedemptions", c, walletId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockRedemptions indicates an expected call of LockRedemptions.
+func (mr *MockVoucherRepoMockRecorder) LockRedemptions(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRedemptions", reflect.TypeOf((*MockVoucherRepo)(nil).LockRedemptions), c, walletId)
+}
+
+// RecordFailedRedemption mocks base method.
+func (m *MockVoucherRepo) RecordFailedRedemption(c context.Context, walletId string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedRedemption", c, walletId, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailedRedemption indicates an expected call of RecordFailedRedemption.
+func (mr *MockVoucherRepoMockRecorder) RecordFailedRedemption(c, walletId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedRedemption", reflect.TypeOf((*MockVoucherRepo)(nil).RecordFailedRedemption), c, walletId, at)
+}
+
+// RedeemVoucher mocks base method.
+func (m *MockVoucherRepo) RedeemVoucher(c context.Context, walletId, codeHash string, now time.Time) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemVoucher", c, walletId, codeHash, now)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemVoucher indicates an expected call of RedeemVoucher.
+func (mr *MockVoucherRepoMockRecorder) RedeemVoucher(c, walletId, codeHash, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemVoucher", reflect.TypeOf((*MockVoucherRepo)(nil).RedeemVoucher), c, walletId, codeHash, now)
+}
+
+// RefundExpiredVouchers mocks base method.
+func (m *MockVoucherRepo) RefundExpiredVouchers(c context.Context, now time.Time) ([]entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundExpiredVouchers", c, now)
+	ret0, _ := ret[0].([]entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundExpiredVouchers indicates an expected call of RefundExpiredVouchers.
+func (mr *MockVoucherRepoMockRecorder) RefundExpiredVouchers(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundExpiredVouchers", reflect.TypeOf((*MockVoucherRepo)(nil).RefundExpiredVouchers), c, now)
+}
+
+// MockPayment is a mock of Payment interface.
+type MockPayment struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentMockRecorder
+}
+
+// MockPaymentMockRecorder is the mock recorder for MockPayment.
+type MockPaymentMockRecorder struct {
+	mock *MockPayment
+}
+
+// NewMockPayment creates a new mock instance.
+func NewMockPayment(ctrl *gomock.Controller) *MockPayment {
+	mock := &MockPayment{ctrl: ctrl}
+	mock.recorder = &MockPaymentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayment) EXPECT() *MockPaymentMockRecorder {
+	return m.recorder
+}
+
+// Deposit mocks base method.
+func (m *MockPayment) Deposit(c context.Context, walletId string, amount float64) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", c, walletId, amount)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockPaymentMockRecorder) Deposit(c, walletId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockPayment)(nil).Deposit), c, walletId, amount)
+}
+
+// GetPaymentById mocks base method.
+func (m *MockPayment) GetPaymentById(c context.Context, paymentId string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentById", c, paymentId)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentById indicates an expected call of GetPaymentById.
+func (mr *MockPaymentMockRecorder) GetPaymentById(c, paymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentById", reflect.TypeOf((*MockPayment)(nil).GetPaymentById), c, paymentId)
+}
+
+// HandleNotification mocks base method.
+func (m *MockPayment) HandleNotification(c context.Context, payload []byte, signature string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleNotification", c, payload, signature)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleNotification indicates an expected call of HandleNotification.
+func (mr *MockPaymentMockRecorder) HandleNotification(c, payload, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNotification", reflect.TypeOf((*MockPayment)(nil).HandleNotification), c, payload, signature)
+}
+
+// Withdraw mocks base method.
+func (m *MockPayment) Withdraw(c context.Context, walletId string, amount float64) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", c, walletId, amount)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockPaymentMockRecorder) Withdraw(c, walletId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockPayment)(nil).Withdraw), c, walletId, amount)
+}
+
+// MockPaymentRepo is a mock of PaymentRepo interface.
+type MockPaymentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepoMockRecorder
+}
+
+// MockPaymentRepoMockRecorder is the mock recorder for MockPaymentRepo.
+type MockPaymentRepoMockRecorder struct {
+	mock *MockPaymentRepo
+}
+
+// NewMockPaymentRepo creates a new mock instance.
+func NewMockPaymentRepo(ctrl *gomock.Controller) *MockPaymentRepo {
+	mock := &MockPaymentRepo{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepo) EXPECT() *MockPaymentRepoMockRecorder {
+	return m.recorder
+}
+
+// CompletePayment mocks base method.
+func (m *MockPaymentRepo) CompletePayment(c context.Context, paymentId, status, reference string) (*entity.Payment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePayment", c, paymentId, status, reference)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompletePayment indicates an expected call of CompletePayment.
+func (mr *MockPaymentRepoMockRecorder) CompletePayment(c, paymentId, status, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePayment", reflect.TypeOf((*MockPaymentRepo)(nil).CompletePayment), c, paymentId, status, reference)
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentRepo) CreatePayment(c context.Context, payment *entity.Payment) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", c, payment)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentRepoMockRecorder) CreatePayment(c, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentRepo)(nil).CreatePayment), c, payment)
+}
+
+// GetPaymentById mocks base method.
+func (m *MockPaymentRepo) GetPaymentById(c context.Context, paymentId string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentById", c, paymentId)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentById indicates an expected call of GetPaymentById.
+func (mr *MockPaymentRepoMockRecorder) GetPaymentById(c, paymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentById", reflect.TypeOf((*MockPaymentRepo)(nil).GetPaymentById), c, paymentId)
+}
+
+// UpdateGatewayReference mocks base method.
+func (m *MockPaymentRepo) UpdateGatewayReference(c context.Context, paymentId, reference string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGatewayReference", c, paymentId, reference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGatewayReference indicates an expected call of UpdateGatewayReference.
+func (mr *MockPaymentRepoMockRecorder) UpdateGatewayReference(c, paymentId, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGatewayReference", reflect.TypeOf((*MockPaymentRepo)(nil).UpdateGatewayReference), c, paymentId, reference)
+}
+
+// MockPaymentGateway is a mock of PaymentGateway interface.
+type MockPaymentGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentGatewayMockRecorder
+}
+
+// MockPaymentGatewayMockRecorder is the mock recorder for MockPaymentGateway.
+type MockPaymentGatewayMockRecorder struct {
+	mock *MockPaymentGateway
+}
+
+// NewMockPaymentGateway creates a new mock instance.
+func NewMockPaymentGateway(ctrl *gomock.Controller) *MockPaymentGateway {
+	mock := &MockPaymentGateway{ctrl: ctrl}
+	mock.recorder = &MockPaymentGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentGateway) EXPECT() *MockPaymentGatewayMockRecorder {
+	return m.recorder
+}
+
+// Deposit mocks base method.
+func (m *MockPaymentGateway) Deposit(c context.Context, payment entity.Payment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", c, payment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockPaymentGatewayMockRecorder) Deposit(c, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockPaymentGateway)(nil).Deposit), c, payment)
+}
+
+// ParseNotification mocks base method.
+func (m *MockPaymentGateway) ParseNotification(payload []byte, signature string) (*entity.PaymentNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseNotification", payload, signature)
+	ret0, _ := ret[0].(*entity.PaymentNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseNotification indicates an expected call of ParseNotification.
+func (mr *MockPaymentGatewayMockRecorder) ParseNotification(payload, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseNotification", reflect.TypeOf((*MockPaymentGateway)(nil).ParseNotification), payload, signature)
+}
+
+// Withdraw mocks base method.
+func (m *MockPaymentGateway) Withdraw(c context.Context, payment entity.Payment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", c, payment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockPaymentGatewayMockRecorder) Withdraw(c, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockPaymentGateway)(nil).Withdraw), c, payment)
+}
+
+// MockInterest is a mock of Interest interface.
+type MockInterest struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterestMockRecorder
+}
+
+// MockInterestMockRecorder is the mock recorder for MockInterest.
+type MockInterestMockRecorder struct {
+	mock *MockInterest
+}
+
+// NewMockInterest creates a new mock instance.
+func NewMockInterest(ctrl *gomock.Controller) *MockInterest {
+	mock := &MockInterest{ctrl: ctrl}
+	mock.recorder = &MockInterestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInterest) EXPECT() *MockInterestMockRecorder {
+	return m.recorder
+}
+
+// AccrueInterest mocks base method.
+func (m *MockInterest) AccrueInterest(c context.Context, day time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueInterest", c, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueInterest indicates an expected call of AccrueInterest.
+func (mr *MockInterestMockRecorder) AccrueInterest(c, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueInterest", reflect.TypeOf((*MockInterest)(nil).AccrueInterest), c, day)
+}
+
+// GetInterestAccruals mocks base method.
+func (m *MockInterest) GetInterestAccruals(c context.Context, walletId string) ([]entity.InterestAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestAccruals", c, walletId)
+	ret0, _ := ret[0].([]entity.InterestAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestAccruals indicates an expected call of GetInterestAccruals.
+func (mr *MockInterestMockRecorder) GetInterestAccruals(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestAccruals", reflect.TypeOf((*MockInterest)(nil).GetInterestAccruals), c, walletId)
+}
+
+// RunAccrualJob mocks base method.
+func (m *MockInterest) RunAccrualJob(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunAccrualJob", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunAccrualJob indicates an expected call of RunAccrualJob.
+func (mr *MockInterestMockRecorder) RunAccrualJob(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunAccrualJob", reflect.TypeOf((*MockInterest)(nil).RunAccrualJob), c)
+}
+
+// MockInterestRepo is a mock of InterestRepo interface.
+type MockInterestRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterestRepoMockRecorder
+}
+
+// MockInterestRepoMockRecorder is the mock recorder for MockInterestRepo.
+type MockInterestRepoMockRecorder struct {
+	mock *MockInterestRepo
+}
+
+// NewMockInterestRepo creates a new mock instance.
+func NewMockInterestRepo(ctrl *gomock.Controller) *MockInterestRepo {
+	mock := &MockInterestRepo{ctrl: ctrl}
+	mock.recorder = &MockInterestRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInterestRepo) EXPECT() *MockInterestRepoMockRecorder {
+	return m.recorder
+}
+
+// AccrueInterest mocks base method.
+func (m *MockInterestRepo) AccrueInterest(c context.Context, day time.Time, overdraftRate float64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueInterest", c, day, overdraftRate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueInterest indicates an expected call of AccrueInterest.
+func (mr *MockInterestRepoMockRecorder) AccrueInterest(c, day, overdraftRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueInterest", reflect.TypeOf((*MockInterestRepo)(nil).AccrueInterest), c, day, overdraftRate)
+}
+
+// CapitalizeInterest mocks base method.
+func (m *MockInterestRepo) CapitalizeInterest(c context.Context, walletId string, month time.Time) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CapitalizeInterest", c, walletId, month)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CapitalizeInterest indicates an expected call of CapitalizeInterest.
+func (mr *MockInterestRepoMockRecorder) CapitalizeInterest(c, walletId, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CapitalizeInterest", reflect.TypeOf((*MockInterestRepo)(nil).CapitalizeInterest), c, walletId, month)
+}
+
+// ChargeInterestDebt mocks base method.
+func (m *MockInterestRepo) ChargeInterestDebt(c context.Context, walletId string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeInterestDebt", c, walletId)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeInterestDebt indicates an expected call of ChargeInterestDebt.
+func (mr *MockInterestRepoMockRecorder) ChargeInterestDebt(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeInterestDebt", reflect.TypeOf((*MockInterestRepo)(nil).ChargeInterestDebt), c, walletId)
+}
+
+// GetCapitalizationPeriods mocks base method.
+func (m *MockInterestRepo) GetCapitalizationPeriods(c context.Context, before time.Time) ([]entity.InterestPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapitalizationPeriods", c, before)
+	ret0, _ := ret[0].([]entity.InterestPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapitalizationPeriods indicates an expected call of GetCapitalizationPeriods.
+func (mr *MockInterestRepoMockRecorder) GetCapitalizationPeriods(c, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapitalizationPeriods", reflect.TypeOf((*MockInterestRepo)(nil).GetCapitalizationPeriods), c, before)
+}
+
+// GetInterestAccruals mocks base method.
+func (m *MockInterestRepo) GetInterestAccruals(c context.Context, walletId string) ([]entity.InterestAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestAccruals", c, walletId)
+	ret0, _ := ret[0].([]entity.InterestAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestAccruals indicates an expected call of GetInterestAccruals.
+func (mr *MockInterestRepoMockRecorder) GetInterestAccruals(c, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestAccruals", reflect.TypeOf((*MockInterestRepo)(nil).GetInterestAccruals), c, walletId)
+}
+
+// GetInterestDebtors mocks base method.
+func (m *MockInterestRepo) GetInterestDebtors(c context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestDebtors", c)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestDebtors indicates an expected call of GetInterestDebtors.
+func (mr *MockInterestRepoMockRecorder) GetInterestDebtors(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestDebtors", reflect.TypeOf((*MockInterestRepo)(nil).GetInterestDebtors), c)
+}
+
+// MockBalance is a mock of Balance interface.
+type MockBalance struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceMockRecorder
+}
+
+// MockBalanceMockRecorder is the mock recorder for MockBalance.
+type MockBalanceMockRecorder struct {
+	mock *MockBalance
+}
+
+// NewMockBalance creates a new mock instance.
+func NewMockBalance(ctrl *gomock.Controller) *MockBalance {
+	mock := &MockBalance{ctrl: ctrl}
+	mock.recorder = &MockBalanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalance) EXPECT() *MockBalanceMockRecorder {
+	return m.recorder
+}
+
+// GetBalanceAt mocks base method.
+func (m *MockBalance) GetBalanceAt(c context.Context, walletId string, at time.Time) (*entity.BalanceAt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceAt", c, walletId, at)
+	ret0, _ := ret[0].(*entity.BalanceAt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceAt indicates an expected call of GetBalanceAt.
+func (mr *MockBalanceMockRecorder) GetBalanceAt(c, walletId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceAt", reflect.TypeOf((*MockBalance)(nil).GetBalanceAt), c, walletId, at)
+}
+
+// RunSnapshotJob mocks base method.
+func (m *MockBalance) RunSnapshotJob(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSnapshotJob", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunSnapshotJob indicates an expected call of RunSnapshotJob.
+func (mr *MockBalanceMockRecorder) RunSnapshotJob(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSnapshotJob", reflect.TypeOf((*MockBalance)(nil).RunSnapshotJob), c)
+}
+
+// TakeSnapshots mocks base method.
+func (m *MockBalance) TakeSnapshots(c context.Context, day time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSnapshots", c, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeSnapshots indicates an expected call of TakeSnapshots.
+func (mr *MockBalanceMockRecorder) TakeSnapshots(c, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSnapshots", reflect.TypeOf((*MockBalance)(nil).TakeSnapshots), c, day)
+}
+
+// MockBalanceRepo is a mock of BalanceRepo interface.
+type MockBalanceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceRepoMockRecorder
+}
+
+// MockBalanceRepoMockRecorder is the mock recorder for MockBalanceRepo.
+type MockBalanceRepoMockRecorder struct {
+	mock *MockBalanceRepo
+}
+
+// NewMockBalanceRepo creates a new mock instance.
+func NewMockBalanceRepo(ctrl *gomock.Controller) *MockBalanceRepo {
+	mock := &MockBalanceRepo{ctrl: ctrl}
+	mock.recorder = &MockBalanceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceRepo) EXPECT() *MockBalanceRepoMockRecorder {
+	return m.recorder
+}
+
+// TakeSnapshots mocks base method.
+func (m *MockBalanceRepo) TakeSnapshots(c context.Context, day time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSnapshots", c, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeSnapshots indicates an expected call of TakeSnapshots.
+func (mr *MockBalanceRepoMockRecorder) TakeSnapshots(c, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSnapshots", reflect.TypeOf((*MockBalanceRepo)(nil).TakeSnapshots), c, day)
+}
+
+// MockReconciliation is a mock of Reconciliation interface.
+type MockReconciliation struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationMockRecorder
+}
+
+// MockReconciliationMockRecorder is the mock recorder for MockReconciliation.
+type MockReconciliationMockRecorder struct {
+	mock *MockReconciliation
+}
+
+// NewMockReconciliation creates a new mock instance.
+func NewMockReconciliation(ctrl *gomock.Controller) *MockReconciliation {
+	mock := &MockReconciliation{ctrl: ctrl}
+	mock.recorder = &MockReconciliationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliation) EXPECT() *MockReconciliationMockRecorder {
+	return m.recorder
+}
+
+// GetLastReconciliation mocks base method.
+func (m *MockReconciliation) GetLastReconciliation(c context.Context) (*entity.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastReconciliation", c)
+	ret0, _ := ret[0].(*entity.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastReconciliation indicates an expected call of GetLastReconciliation.
+func (mr *MockReconciliationMockRecorder) GetLastReconciliation(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastReconciliation", reflect.TypeOf((*MockReconciliation)(nil).GetLastReconciliation), c)
+}
+
+// Reconcile mocks base method.
+func (m *MockReconciliation) Reconcile(c context.Context) (*entity.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", c)
+	ret0, _ := ret[0].(*entity.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockReconciliationMockRecorder) Reconcile(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockReconciliation)(nil).Reconcile), c)
+}
+
+// MockReconciliationRepo is a mock of ReconciliationRepo interface.
+type MockReconciliationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationRepoMockRecorder
+}
+
+// MockReconciliationRepoMockRecorder is the mock recorder for MockReconciliationRepo.
+type MockReconciliationRepoMockRecorder struct {
+	mock *MockReconciliationRepo
+}
+
+// NewMockReconciliationRepo creates a new mock instance.
+func NewMockReconciliationRepo(ctrl *gomock.Controller) *MockReconciliationRepo {
+	mock := &MockReconciliationRepo{ctrl: ctrl}
+	mock.recorder = &MockReconciliationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationRepo) EXPECT() *MockReconciliationRepoMockRecorder {
+	return m.recorder
+}
+
+// GetLastReconciliation mocks base method.
+func (m *MockReconciliationRepo) GetLastReconciliation(c context.Context) (*entity.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastReconciliation", c)
+	ret0, _ := ret[0].(*entity.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastReconciliation indicates an expected call of GetLastReconciliation.
+func (mr *MockReconciliationRepoMockRecorder) GetLastReconciliation(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastReconciliation", reflect.TypeOf((*MockReconciliationRepo)(nil).GetLastReconciliation), c)
+}
+
+// GetTotals mocks base method.
+func (m *MockReconciliationRepo) GetTotals(c context.Context) (float64, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotals", c)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTotals indicates an expected call of GetTotals.
+func (mr *MockReconciliationRepoMockRecorder) GetTotals(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotals", reflect.TypeOf((*MockReconciliationRepo)(nil).GetTotals), c)
+}
+
+// GetWalletLedgers mocks base method.
+func (m *MockReconciliationRepo) GetWalletLedgers(c context.Context, afterId string, limit int) ([]entity.WalletLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletLedgers", c, afterId, limit)
+	ret0, _ := ret[0].([]entity.WalletLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletLedgers indicates an expected call of GetWalletLedgers.
+func (mr *MockReconciliationRepoMockRecorder) GetWalletLedgers(c, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletLedgers", reflect.TypeOf((*MockReconciliationRepo)(nil).GetWalletLedgers), c, afterId, limit)
+}
+
+// SaveReconciliation mocks base method.
+func (m *MockReconciliationRepo) SaveReconciliation(c context.Context, reconciliation *entity.Reconciliation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReconciliation", c, reconciliation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReconciliation indicates an expected call of SaveReconciliation.
+func (mr *MockReconciliationRepoMockRecorder) SaveReconciliation(c, reconciliation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReconciliation", reflect.TypeOf((*MockReconciliationRepo)(nil).SaveReconciliation), c, reconciliation)
+}
+
+// SetInitialBalances mocks base method.
+func (m *MockReconciliationRepo) SetInitialBalances(c context.Context, defaultBalance float64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInitialBalances", c, defaultBalance)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetInitialBalances indicates an expected call of SetInitialBalances.
+func (mr *MockReconciliationRepoMockRecorder) SetInitialBalances(c, defaultBalance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInitialBalances", reflect.TypeOf((*MockReconciliationRepo)(nil).SetInitialBalances), c, defaultBalance)
+}
+
+// MockChain is a mock of Chain interface.
+type MockChain struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainMockRecorder
+}
+
+// MockChainMockRecorder is the mock recorder for MockChain.
+type MockChainMockRecorder struct {
+	mock *MockChain
+}
+
+// NewMockChain creates a new mock instance.
+func NewMockChain(ctrl *gomock.Controller) *MockChain {
+	mock := &MockChain{ctrl: ctrl}
+	mock.recorder = &MockChainMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChain) EXPECT() *MockChainMockRecorder {
+	return m.recorder
+}
+
+// ChainTransactions mocks base method.
+func (m *MockChain) ChainTransactions(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainTransactions", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainTransactions indicates an expected call of ChainTransactions.
+func (mr *MockChainMockRecorder) ChainTransactions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainTransactions", reflect.TypeOf((*MockChain)(nil).ChainTransactions), c)
+}
+
+// GetHeads mocks base method.
+func (m *MockChain) GetHeads(c context.Context) ([]entity.ChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeads", c)
+	ret0, _ := ret[0].([]entity.ChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeads indicates an expected call of GetHeads.
+func (mr *MockChainMockRecorder) GetHeads(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeads", reflect.TypeOf((*MockChain)(nil).GetHeads), c)
+}
+
+// PublishHead mocks base method.
+func (m *MockChain) PublishHead(c context.Context) (*entity.ChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishHead", c)
+	ret0, _ := ret[0].(*entity.ChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishHead indicates an expected call of PublishHead.
+func (mr *MockChainMockRecorder) PublishHead(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishHead", reflect.TypeOf((*MockChain)(nil).PublishHead), c)
+}
+
+// VerifyChain mocks base method.
+func (m *MockChain) VerifyChain(c context.Context) (*entity.ChainVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyChain", c)
+	ret0, _ := ret[0].(*entity.ChainVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyChain indicates an expected call of VerifyChain.
+func (mr *MockChainMockRecorder) VerifyChain(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChain", reflect.TypeOf((*MockChain)(nil).VerifyChain), c)
+}
+
+// MockChainRepo is a mock of ChainRepo interface.
+type MockChainRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainRepoMockRecorder
+}
+
+// MockChainRepoMockRecorder is the mock recorder for MockChainRepo.
+type MockChainRepoMockRecorder struct {
+	mock *MockChainRepo
+}
+
+// NewMockChainRepo creates a new mock instance.
+func NewMockChainRepo(ctrl *gomock.Controller) *MockChainRepo {
+	mock := &MockChainRepo{ctrl: ctrl}
+	mock.recorder = &MockChainRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainRepo) EXPECT() *MockChainRepoMockRecorder {
+	return m.recorder
+}
+
+// ChainTransactions mocks base method.
+func (m *MockChainRepo) ChainTransactions(c context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainTransactions", c, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainTransactions indicates an expected call of ChainTransactions.
+func (mr *MockChainRepoMockRecorder) ChainTransactions(c, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainTransactions", reflect.TypeOf((*MockChainRepo)(nil).ChainTransactions), c, limit)
+}
+
+// CountUnchainedTransactions mocks base method.
+func (m *MockChainRepo) CountUnchainedTransactions(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnchainedTransactions", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnchainedTransactions indicates an expected call of CountUnchainedTransactions.
+func (mr *MockChainRepoMockRecorder) CountUnchainedTransactions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnchainedTransactions", reflect.TypeOf((*MockChainRepo)(nil).CountUnchainedTransactions), c)
+}
+
+// GetChain mocks base method.
+func (m *MockChainRepo) GetChain(c context.Context) (*entity.TransactionChain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChain", c)
+	ret0, _ := ret[0].(*entity.TransactionChain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChain indicates an expected call of GetChain.
+func (mr *MockChainRepoMockRecorder) GetChain(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChain", reflect.TypeOf((*MockChainRepo)(nil).GetChain), c)
+}
+
+// GetChainHeads mocks base method.
+func (m *MockChainRepo) GetChainHeads(c context.Context) ([]entity.ChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainHeads", c)
+	ret0, _ := ret[0].([]entity.ChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainHeads indicates an expected call of GetChainHeads.
+func (mr *MockChainRepoMockRecorder) GetChainHeads(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainHeads", reflect.TypeOf((*MockChainRepo)(nil).GetChainHeads), c)
+}
+
+// GetChainedTransactions mocks base method.
+func (m *MockChainRepo) GetChainedTransactions(c context.Context, afterPosition int64, limit int) ([]entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainedTransactions", c, afterPosition, limit)
+	ret0, _ := ret[0].([]entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainedTransactions indicates an expected call of GetChainedTransactions.
+func (mr *MockChainRepoMockRecorder) GetChainedTransactions(c, afterPosition, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainedTransactions", reflect.TypeOf((*MockChainRepo)(nil).GetChainedTransactions), c, afterPosition, limit)
+}
+
+// SaveChainHead mocks base method.
+func (m *MockChainRepo) SaveChainHead(c context.Context, head *entity.ChainHead) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChainHead", c, head)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChainHead indicates an expected call of SaveChainHead.
+func (mr *MockChainRepoMockRecorder) SaveChainHead(c, head interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChainHead", reflect.TypeOf((*MockChainRepo)(nil).SaveChainHead), c, head)
+}
+
+// MockReceipt is a mock of Receipt interface.
+type MockReceipt struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptMockRecorder
+}
+
+// MockReceiptMockRecorder is the mock recorder for MockReceipt.
+type MockReceiptMockRecorder struct {
+	mock *MockReceipt
+}
+
+// NewMockReceipt creates a new mock instance.
+func NewMockReceipt(ctrl *gomock.Controller) *MockReceipt {
+	mock := &MockReceipt{ctrl: ctrl}
+	mock.recorder = &MockReceiptMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceipt) EXPECT() *MockReceiptMockRecorder {
+	return m.recorder
+}
+
+// GetPublicKey mocks base method.
+func (m *MockReceipt) GetPublicKey() (*entity.ReceiptKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey")
+	ret0, _ := ret[0].(*entity.ReceiptKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockReceiptMockRecorder) GetPublicKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockReceipt)(nil).GetPublicKey))
+}
+
+// GetReceipt mocks base method.
+func (m *MockReceipt) GetReceipt(c context.Context, transactionId int64, walletId string) (*entity.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceipt", c, transactionId, walletId)
+	ret0, _ := ret[0].(*entity.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt.
+func (mr *MockReceiptMockRecorder) GetReceipt(c, transactionId, walletId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockReceipt)(nil).GetReceipt), c, transactionId, walletId)
+}
+
+// IssueReceipt mocks base method.
+func (m *MockReceipt) IssueReceipt(transaction *entity.Transaction) (*entity.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueReceipt", transaction)
+	ret0, _ := ret[0].(*entity.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueReceipt indicates an expected call of IssueReceipt.
+func (mr *MockReceiptMockRecorder) IssueReceipt(transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueReceipt", reflect.TypeOf((*MockReceipt)(nil).IssueReceipt), transaction)
+}
+
+// VerifyReceipt mocks base method.
+func (m *MockReceipt) VerifyReceipt(receipt entity.Receipt) (*entity.ReceiptVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyReceipt", receipt)
+	ret0, _ := ret[0].(*entity.ReceiptVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyReceipt indicates an expected call of VerifyReceipt.
+func (mr *MockReceiptMockRecorder) VerifyReceipt(receipt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyReceipt", reflect.TypeOf((*MockReceipt)(nil).VerifyReceipt), receipt)
+}
+
+// MockReceiptRepo is a mock of ReceiptRepo interface.
+type MockReceiptRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptRepoMockRecorder
+}
+
+// MockReceiptRepoMockRecorder is the mock recorder for MockReceiptRepo.
+type MockReceiptRepoMockRecorder struct {
+	mock *MockReceiptRepo
+}
+
+// NewMockReceiptRepo creates a new mock instance.
+func NewMockReceiptRepo(ctrl *gomock.Controller) *MockReceiptRepo {
+	mock := &MockReceiptRepo{ctrl: ctrl}
+	mock.recorder = &MockReceiptRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptRepo) EXPECT() *MockReceiptRepoMockRecorder {
+	return m.recorder
+}
+
+// GetTransactionById mocks base method.
+func (m *MockReceiptRepo) GetTransactionById(c context.Context, transactionId int64) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionById", c, transactionId)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionById indicates an expected call of GetTransactionById.
+func (mr *MockReceiptRepoMockRecorder) GetTransactionById(c, transactionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionById", reflect.TypeOf((*MockReceiptRepo)(nil).GetTransactionById), c, transactionId)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// GetEntries mocks base method.
+func (m *MockAudit) GetEntries(c context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", c, filter)
+	ret0, _ := ret[0].([]entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditMockRecorder) GetEntries(c, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAudit)(nil).GetEntries), c, filter)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockAudit) PurgeExpired(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockAuditMockRecorder) PurgeExpired(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockAudit)(nil).PurgeExpired), c)
+}
+
+// Record mocks base method.
+func (m *MockAudit) Record(c context.Context, entry entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", c, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditMockRecorder) Record(c, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAudit)(nil).Record), c, entry)
+}
+
+// MockAuditRepo is a mock of AuditRepo interface.
+type MockAuditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepoMockRecorder
+}
+
+// MockAuditRepoMockRecorder is the mock recorder for MockAuditRepo.
+type MockAuditRepoMockRecorder struct {
+	mock *MockAuditRepo
+}
+
+// NewMockAuditRepo creates a new mock instance.
+func NewMockAuditRepo(ctrl *gomock.Controller) *MockAuditRepo {
+	mock := &MockAuditRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepo) EXPECT() *MockAuditRepoMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEntry mocks base method.
+func (m *MockAuditRepo) AddAuditEntry(c context.Context, entry *entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuditEntry", c, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuditEntry indicates an expected call of AddAuditEntry.
+func (mr *MockAuditRepoMockRecorder) AddAuditEntry(c, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEntry", reflect.TypeOf((*MockAuditRepo)(nil).AddAuditEntry), c, entry)
+}
+
+// Atomic mocks base method.
+func (m *MockAuditRepo) Atomic(c context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Atomic", c, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Atomic indicates an expected call of Atomic.
+func (mr *MockAuditRepoMockRecorder) Atomic(c, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atomic", reflect.TypeOf((*MockAuditRepo)(nil).Atomic), c, fn)
+}
+
+// DeleteAuditEntries mocks base method.
+func (m *MockAuditRepo) DeleteAuditEntries(c context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditEntries", c, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAuditEntries indicates an expected call of DeleteAuditEntries.
+func (mr *MockAuditRepoMockRecorder) DeleteAuditEntries(c, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditEntries", reflect.TypeOf((*MockAuditRepo)(nil).DeleteAuditEntries), c, before)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockAuditRepo) GetAuditEntries(c context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", c, filter)
+	ret0, _ := ret[0].([]entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockAuditRepoMockRecorder) GetAuditEntries(c, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockAuditRepo)(nil).GetAuditEntries), c, filter)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const (
+	// Number of events published by one run of the relay
+	outboxRelayBatch = 100
+)
+
+// OutboxUseCase -.
+type OutboxUseCase struct {
+	repo      OutboxRepo
+	publisher EventPublisher
+}
+
+// NewOutbox -.
+func NewOutbox(r OutboxRepo, p EventPublisher) *OutboxUseCase {
+	return &OutboxUseCase{
+		repo:      r,
+		publisher: p,
+	}
+}
+
+// RelayEvents - publishing the stored events in order of their ids. The events are marked as published after
+// the publisher accepts them, so an event is published again if the app stops in between.
+// Returns the number of published events.
+func (o *OutboxUseCase) RelayEvents(ctx context.Context) (int, error) {
+	published := make([]int64, 0)
+	var publishErr error
+
+	err := o.repo.Atomic(ctx, func(ctx context.Context) error {
+		events, err := o.repo.LockUnpublishedEvents(ctx, outboxRelayBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			// Later events wait for the failed one to keep the order
+			if publishErr = o.publisher.Publish(ctx, event); publishErr != nil {
+				break
+			}
+			published = append(published, event.ID)
+		}
+
+		return o.repo.MarkPublished(ctx, published, time.Now())
+	})
+	if err != nil {
+		return 0, fmt.Errorf("OutboxUseCase - RelayEvents - o.repo.Atomic: %w", err)
+	}
+	if publishErr != nil {
+		return len(published), fmt.Errorf("OutboxUseCase - RelayEvents - o.publisher.Publish: %w", publishErr)
+	}
+
+	return len(published), nil
+}
+
+// newDomainEvent - creating an event of the aggregate with the encoded payload.
+func newDomainEvent(eventType string, aggregateId string, payload interface{}) (entity.DomainEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return entity.DomainEvent{}, err
+	}
+
+	return entity.DomainEvent{
+		Type:        eventType,
+		AggregateID: aggregateId,
+		OccurredAt:  time.Now(),
+		Payload:     data,
+	}, nil
+}
+
+// addEvent - storing the domain event in the outbox in the transaction of the state change
+func addEvent(ctx context.Context, outbox OutboxRepo, eventType string, aggregateId string, payload interface{}) error {
+	event, err := newDomainEvent(eventType, aggregateId, payload)
+	if err != nil {
+		return fmt.Errorf("addEvent - newDomainEvent: %w", err)
+	}
+
+	err = outbox.AddEvents(ctx, event)
+	if err != nil {
+		return fmt.Errorf("addEvent - outbox.AddEvents: %w", err)
+	}
+
+	return nil
+}
+
+// fundsMoved - payload of the event of a recorded movement of funds
+func fundsMoved(transaction *entity.Transaction) entity.FundsMoved {
+	return entity.FundsMoved{
+		From:              transaction.From,
+		To:                transaction.To,
+		Amount:            transaction.Amount,
+		Type:              transaction.Type,
+		Description:       transaction.Description,
+		ExternalReference: transaction.ExternalReference,
+		Time:              transaction.Time,
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	"github.com/egor-denisov/wallet-infotecs/internal/publisher"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+// atomic - running the transaction of the outbox mock in place.
+func atomic(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestRelayEvents(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	events := []entity.DomainEvent{
+		{ID: 1, Type: entity.DomainEventWalletCreated, AggregateID: "5b53700ed469fa6a09ea72bb78f36fd9"},
+		{ID: 2, Type: entity.DomainEventFundsTransferred, AggregateID: "5b53700ed469fa6a09ea72bb78f36fd9"},
+	}
+
+	repo := mock_usecase.NewMockOutboxRepo(c)
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	repo.EXPECT().LockUnpublishedEvents(gomock.Any(), outboxRelayBatch).Return(events, nil)
+	repo.EXPECT().MarkPublished(gomock.Any(), []int64{1, 2}, gomock.Any()).Return(nil)
+
+	p := publisher.NewMemory()
+	published, err := NewOutbox(repo, p).RelayEvents(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+	require.Equal(t, events, p.Events())
+}
+
+func TestRelayEventsPublisherFailed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	events := []entity.DomainEvent{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	repo := mock_usecase.NewMockOutboxRepo(c)
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	repo.EXPECT().LockUnpublishedEvents(gomock.Any(), outboxRelayBatch).Return(events, nil)
+	// Only the events before the failed one are marked, the rest are published by the next run
+	repo.EXPECT().MarkPublished(gomock.Any(), []int64{1}, gomock.Any()).Return(nil)
+
+	p := mock_usecase.NewMockEventPublisher(c)
+	p.EXPECT().Publish(gomock.Any(), events[0]).Return(nil)
+	p.EXPECT().Publish(gomock.Any(), events[1]).Return(errors.New("broker is unavailable"))
+
+	published, err := NewOutbox(repo, p).RelayEvents(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 1, published)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// PaymentUseCase -.
+type PaymentUseCase struct {
+	repo    PaymentRepo
+	outbox  OutboxRepo
+	gateway PaymentGateway
+}
+
+// NewPayment - completions of the payments are stored together with their domain events in the outbox.
+func NewPayment(r PaymentRepo, o OutboxRepo, g PaymentGateway) *PaymentUseCase {
+	return &PaymentUseCase{
+		repo:    r,
+		outbox:  o,
+		gateway: g,
+	}
+}
+
+// Deposit - starting a top-up of the wallet, the wallet is credited after the gateway confirms it
+func (p *PaymentUseCase) Deposit(ctx context.Context, walletId string, amount float64) (*entity.Payment, error) {
+	payment, err := p.submit(ctx, walletId, amount, entity.PaymentTypeDeposit, p.gateway.Deposit)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - Deposit - p.submit: %w", err)
+	}
+
+	return payment, nil
+}
+
+// Withdraw - starting a withdrawal from the wallet, funds are held until the gateway reports the result
+func (p *PaymentUseCase) Withdraw(ctx context.Context, walletId string, amount float64) (*entity.Payment, error) {
+	payment, err := p.submit(ctx, walletId, amount, entity.PaymentTypeWithdrawal, p.gateway.Withdraw)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - Withdraw - p.submit: %w", err)
+	}
+
+	return payment, nil
+}
+
+// HandleNotification - applying the result of a payment reported by the gateway
+func (p *PaymentUseCase) HandleNotification(ctx context.Context, payload []byte, signature string) (*entity.Payment, error) {
+	notification, err := p.gateway.ParseNotification(payload, signature)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - HandleNotification - p.gateway.ParseNotification: %w", err)
+	}
+	if notification.Status != entity.PaymentStatusSucceeded && notification.Status != entity.PaymentStatusFailed {
+		return nil, entity.ErrWrongPaymentStatus
+	}
+
+	payment, err := p.complete(ctx, notification.PaymentID, notification.Status, notification.GatewayReference)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - HandleNotification - p.complete: %w", err)
+	}
+
+	return payment, nil
+}
+
+// GetPaymentById - getting a payment by id
+func (p *PaymentUseCase) GetPaymentById(ctx context.Context, paymentId string) (*entity.Payment, error) {
+	payment, err := p.repo.GetPaymentById(ctx, paymentId)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - GetPaymentById - p.repo.GetPaymentById: %w", err)
+	}
+
+	return payment, nil
+}
+
+// submit - storing a pending payment and passing it to the gateway.
+// If the gateway rejects the payment, it is failed immediately.
+func (p *PaymentUseCase) submit(ctx context.Context, walletId string, amount float64, paymentType string,
+	send func(context.Context, entity.Payment) (string, error)) (*entity.Payment, error) {
+	if amount <= 0 {
+		return nil, entity.ErrWrongAmount
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - submit - randomHex: %w", err)
+	}
+
+	// The payment and the hold of a withdrawal are stored with their event
+	var payment *entity.Payment
+	err = p.outbox.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		payment, err = p.repo.CreatePayment(ctx, &entity.Payment{
+			ID:       id,
+			WalletID: walletId,
+			Type:     paymentType,
+			Amount:   amount,
+			Status:   entity.PaymentStatusPending,
+		})
+		if err != nil {
+			return fmt.Errorf("PaymentUseCase - submit - p.repo.CreatePayment: %w", err)
+		}
+
+		return addEvent(ctx, p.outbox, entity.DomainEventPaymentSubmitted, walletId, entity.PaymentSubmitted{
+			PaymentID: payment.ID,
+			WalletID:  payment.WalletID,
+			Type:      payment.Type,
+			Amount:    payment.Amount,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := send(ctx, *payment)
+	if err != nil {
+		if _, err := p.complete(ctx, payment.ID, entity.PaymentStatusFailed, ""); err != nil {
+			return nil, fmt.Errorf("PaymentUseCase - submit - p.complete: %w", err)
+		}
+		return nil, fmt.Errorf("PaymentUseCase - submit - send: %w", err)
+	}
+
+	err = p.repo.UpdateGatewayReference(ctx, payment.ID, reference)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentUseCase - submit - p.repo.UpdateGatewayReference: %w", err)
+	}
+	payment.GatewayReference = reference
+
+	return payment, nil
+}
+
+// complete - moving the payment to the final status, the PaymentCompleted event is added unless it already had it
+func (p *PaymentUseCase) complete(ctx context.Context, paymentId string, status string, reference string) (*entity.Payment, error) {
+	var payment *entity.Payment
+	err := p.outbox.Atomic(ctx, func(ctx context.Context) error {
+		var completed bool
+		var err error
+		payment, completed, err = p.repo.CompletePayment(ctx, paymentId, status, reference)
+		if err != nil {
+			return fmt.Errorf("PaymentUseCase - complete - p.repo.CompletePayment: %w", err)
+		}
+		if !completed {
+			return nil
+		}
+
+		return addEvent(ctx, p.outbox, entity.DomainEventPaymentCompleted, payment.WalletID, entity.PaymentCompleted{
+			PaymentID: payment.ID,
+			WalletID:  payment.WalletID,
+			Type:      payment.Type,
+			Status:    payment.Status,
+			Amount:    payment.Amount,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
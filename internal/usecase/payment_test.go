@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestHandleNotificationDomainEvent(t *testing.T) {
+	tests := []struct {
+		name           string
+		completed      bool
+		expectedEvents int
+	}{
+		{
+			name:           "Completed",
+			completed:      true,
+			expectedEvents: 1,
+		},
+		{
+			name:           "Repeated notification",
+			completed:      false,
+			expectedEvents: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			notification := &entity.PaymentNotification{PaymentID: "p", GatewayReference: "ref", Status: entity.PaymentStatusSucceeded}
+			gateway := mock_usecase.NewMockPaymentGateway(c)
+			gateway.EXPECT().ParseNotification(gomock.Any(), "sig").Return(notification, nil)
+
+			repo := mock_usecase.NewMockPaymentRepo(c)
+			repo.EXPECT().CompletePayment(gomock.Any(), "p", entity.PaymentStatusSucceeded, "ref").Return(&entity.Payment{
+				ID:       "p",
+				WalletID: "a",
+				Type:     entity.PaymentTypeDeposit,
+				Amount:   100,
+				Status:   entity.PaymentStatusSucceeded,
+			}, test.completed, nil)
+
+			// The completion is stored with its event, a repeated one adds nothing
+			var stored []entity.DomainEvent
+			outbox := mock_usecase.NewMockOutboxRepo(c)
+			outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+			outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Times(test.expectedEvents).DoAndReturn(
+				func(_ context.Context, events ...entity.DomainEvent) error {
+					stored = append(stored, events...)
+					return nil
+				})
+
+			_, err := NewPayment(repo, outbox, gateway).HandleNotification(context.Background(), []byte("{}"), "sig")
+			require.NoError(t, err)
+			require.Len(t, stored, test.expectedEvents)
+			if test.expectedEvents > 0 {
+				require.Equal(t, entity.DomainEventPaymentCompleted, stored[0].Type)
+				require.Equal(t, "a", stored[0].AggregateID)
+				require.JSONEq(t, `{"payment_id":"p","wallet_id":"a","type":"deposit","status":"succeeded","amount":100}`, string(stored[0].Payload))
+			}
+		})
+	}
+}
+
+func TestDepositRejectedByGateway(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	gatewayErr := errors.New("gateway is unavailable")
+	gateway := mock_usecase.NewMockPaymentGateway(c)
+	gateway.EXPECT().Deposit(gomock.Any(), gomock.Any()).Return("", gatewayErr)
+
+	repo := mock_usecase.NewMockPaymentRepo(c)
+	repo.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, payment *entity.Payment) (*entity.Payment, error) {
+			return payment, nil
+		})
+	// The rejected payment is failed at once
+	repo.EXPECT().CompletePayment(gomock.Any(), gomock.Any(), entity.PaymentStatusFailed, "").
+		Return(&entity.Payment{WalletID: "a", Status: entity.PaymentStatusFailed}, true, nil)
+
+	// The submitted payment and its failure are stored with their events
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(atomic)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	_, err := NewPayment(repo, outbox, gateway).Deposit(context.Background(), "a", 100)
+	require.ErrorIs(t, err, gatewayErr)
+	require.EqualError(t, err, "PaymentUseCase - Deposit - p.submit: PaymentUseCase - submit - send: gateway is unavailable")
+	require.Len(t, stored, 2)
+	require.Equal(t, entity.DomainEventPaymentSubmitted, stored[0].Type)
+	require.Equal(t, entity.DomainEventPaymentCompleted, stored[1].Type)
+}
+
+func TestWithdrawDomainEvent(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	gateway := mock_usecase.NewMockPaymentGateway(c)
+	gateway.EXPECT().Withdraw(gomock.Any(), gomock.Any()).Return("ref", nil)
+
+	repo := mock_usecase.NewMockPaymentRepo(c)
+	repo.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, payment *entity.Payment) (*entity.Payment, error) {
+			payment.ID = "p"
+			return payment, nil
+		})
+	repo.EXPECT().UpdateGatewayReference(gomock.Any(), "p", "ref").Return(nil)
+
+	// The hold of the withdrawal is stored with its event
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	payment, err := NewPayment(repo, outbox, gateway).Withdraw(context.Background(), "a", 40)
+	require.NoError(t, err)
+	require.Equal(t, "ref", payment.GatewayReference)
+	require.Len(t, stored, 1)
+	require.Equal(t, entity.DomainEventPaymentSubmitted, stored[0].Type)
+	require.Equal(t, "a", stored[0].AggregateID)
+	require.JSONEq(t, `{"payment_id":"p","wallet_id":"a","type":"withdrawal","amount":40}`, string(stored[0].Payload))
+}
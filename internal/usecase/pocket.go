@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// PocketUseCase -.
+type PocketUseCase struct {
+	repo   PocketRepo
+	outbox OutboxRepo
+}
+
+// NewPocket - movements of the pockets are stored together with their domain events in the outbox.
+func NewPocket(r PocketRepo, o OutboxRepo) *PocketUseCase {
+	return &PocketUseCase{
+		repo:   r,
+		outbox: o,
+	}
+}
+
+// CreatePocket - creating an empty pocket under a wallet
+func (p *PocketUseCase) CreatePocket(ctx context.Context, walletId string, name string) (*entity.Wallet, error) {
+	name = stripControlCharacters(name)
+	if name == "" || utf8.RuneCountInString(name) > entity.MaxPocketNameLength {
+		return nil, entity.ErrWrongPocketName
+	}
+
+	pocket := &entity.Wallet{
+		Type:     entity.WalletTypeStandard,
+		Status:   entity.WalletStatusActive,
+		ParentID: walletId,
+		Name:     name,
+	}
+
+	pocket, err := p.repo.CreatePocket(ctx, pocket)
+	if err != nil {
+		return nil, fmt.Errorf("PocketUseCase - CreatePocket - p.repo.CreatePocket: %w", err)
+	}
+
+	return pocket, nil
+}
+
+// MovePocketFunds - moving funds between a wallet and its pocket
+func (p *PocketUseCase) MovePocketFunds(ctx context.Context, walletId string, pocketId string, request entity.PocketTransferRequest) (*entity.Transaction, error) {
+	if request.Amount <= 0 {
+		return nil, entity.ErrWrongAmount
+	}
+
+	transaction := &entity.Transaction{
+		Amount: request.Amount,
+		Type:   entity.TransactionTypePocketTransfer,
+	}
+	switch request.Direction {
+	case entity.PocketDirectionIn:
+		transaction.From, transaction.To = walletId, pocketId
+	case entity.PocketDirectionOut:
+		transaction.From, transaction.To = pocketId, walletId
+	default:
+		return nil, entity.ErrWrongPocketDirection
+	}
+
+	err := p.outbox.Atomic(ctx, func(ctx context.Context) error {
+		err := p.repo.MovePocketFunds(ctx, walletId, pocketId, transaction)
+		if err != nil {
+			return fmt.Errorf("PocketUseCase - MovePocketFunds - p.repo.MovePocketFunds: %w", err)
+		}
+
+		return addEvent(ctx, p.outbox, entity.DomainEventPocketFundsMoved, walletId, fundsMoved(transaction))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// ClosePocket - closing a pocket, its funds are returned to the wallet
+func (p *PocketUseCase) ClosePocket(ctx context.Context, walletId string, pocketId string) error {
+	return p.outbox.Atomic(ctx, func(ctx context.Context) error {
+		transaction, err := p.repo.ClosePocket(ctx, walletId, pocketId)
+		if err != nil {
+			return fmt.Errorf("PocketUseCase - ClosePocket - p.repo.ClosePocket: %w", err)
+		}
+
+		closed := entity.PocketClosed{
+			WalletID: walletId,
+			PocketID: pocketId,
+		}
+		if transaction != nil {
+			closed.Returned = transaction.Amount
+		}
+		return addEvent(ctx, p.outbox, entity.DomainEventPocketClosed, walletId, closed)
+	})
+}
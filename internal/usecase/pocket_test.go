@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestPocketDomainEvents(t *testing.T) {
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+	pocketId := "eb376add88bf8e70f80787266a0801d5"
+
+	tests := []struct {
+		name            string
+		mockBehavior    func(r *mock_usecase.MockPocketRepo)
+		call            func(p *PocketUseCase) error
+		expectedType    string
+		expectedPayload string
+	}{
+		{
+			name: "PocketFundsMoved",
+			mockBehavior: func(r *mock_usecase.MockPocketRepo) {
+				r.EXPECT().MovePocketFunds(gomock.Any(), walletId, pocketId, gomock.Any()).Return(nil)
+			},
+			call: func(p *PocketUseCase) error {
+				_, err := p.MovePocketFunds(context.Background(), walletId, pocketId, entity.PocketTransferRequest{Amount: 30, Direction: entity.PocketDirectionIn})
+				return err
+			},
+			expectedType:    entity.DomainEventPocketFundsMoved,
+			expectedPayload: `{"from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30,"type":"pocket_transfer","time":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "PocketClosed",
+			mockBehavior: func(r *mock_usecase.MockPocketRepo) {
+				r.EXPECT().ClosePocket(gomock.Any(), walletId, pocketId).Return(&entity.Transaction{From: pocketId, To: walletId, Amount: 25}, nil)
+			},
+			call: func(p *PocketUseCase) error {
+				return p.ClosePocket(context.Background(), walletId, pocketId)
+			},
+			expectedType:    entity.DomainEventPocketClosed,
+			expectedPayload: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","pocket_id":"eb376add88bf8e70f80787266a0801d5","returned":25}`,
+		},
+		{
+			name: "Empty PocketClosed",
+			mockBehavior: func(r *mock_usecase.MockPocketRepo) {
+				r.EXPECT().ClosePocket(gomock.Any(), walletId, pocketId).Return(nil, nil)
+			},
+			call: func(p *PocketUseCase) error {
+				return p.ClosePocket(context.Background(), walletId, pocketId)
+			},
+			expectedType:    entity.DomainEventPocketClosed,
+			expectedPayload: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","pocket_id":"eb376add88bf8e70f80787266a0801d5","returned":0}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockPocketRepo(c)
+			test.mockBehavior(repo)
+
+			var stored []entity.DomainEvent
+			outbox := mock_usecase.NewMockOutboxRepo(c)
+			outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+			outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, events ...entity.DomainEvent) error {
+					stored = append(stored, events...)
+					return nil
+				})
+
+			require.NoError(t, test.call(NewPocket(repo, outbox)))
+			require.Len(t, stored, 1)
+			require.Equal(t, test.expectedType, stored[0].Type)
+			require.Equal(t, walletId, stored[0].AggregateID)
+			require.JSONEq(t, test.expectedPayload, string(stored[0].Payload))
+		})
+	}
+}
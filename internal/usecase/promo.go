@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// PromoUseCase -.
+type PromoUseCase struct {
+	repo   PromoRepo
+	outbox OutboxRepo
+	TTL    time.Duration
+}
+
+// NewPromo - grants and their expiry are stored together with their domain events in the outbox.
+func NewPromo(r PromoRepo, o OutboxRepo, ttl time.Duration) *PromoUseCase {
+	return &PromoUseCase{
+		repo:   r,
+		outbox: o,
+		TTL:    ttl,
+	}
+}
+
+// GrantPromo - granting promotional credit which expires after TTL
+func (p *PromoUseCase) GrantPromo(ctx context.Context, walletId string, amount float64) (*entity.PromoGrant, error) {
+	if amount <= 0 {
+		return nil, entity.ErrWrongAmount
+	}
+
+	now := time.Now()
+	grant := &entity.PromoGrant{
+		WalletID:  walletId,
+		Amount:    amount,
+		Remaining: amount,
+		GrantedAt: now,
+		ExpiresAt: now.Add(p.TTL),
+	}
+
+	err := p.outbox.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		grant, err = p.repo.GrantPromo(ctx, grant)
+		if err != nil {
+			return fmt.Errorf("PromoUseCase - GrantPromo - p.repo.GrantPromo: %w", err)
+		}
+
+		return addEvent(ctx, p.outbox, entity.DomainEventPromoGranted, grant.WalletID, entity.PromoGranted{
+			GrantID:   grant.ID,
+			WalletID:  grant.WalletID,
+			Amount:    grant.Amount,
+			ExpiresAt: grant.ExpiresAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// ExpirePromoGrants - sweeping unspent promotional credit after its expiry, each grant in its own transaction.
+// Grants of frozen and closed wallets are left till the wallet is active, a failed grant doesn't stop the others.
+func (p *PromoUseCase) ExpirePromoGrants(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	grants, err := p.repo.GetExpiredPromoGrants(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("PromoUseCase - ExpirePromoGrants - p.repo.GetExpiredPromoGrants: %w", err)
+	}
+
+	expired := 0
+	var errs []error
+	for _, grant := range grants {
+		err := p.outbox.Atomic(ctx, func(ctx context.Context) error {
+			transaction, err := p.repo.ExpirePromoGrant(ctx, grant, now)
+			// The grant could be spent or expired by another instance in the meantime
+			if err != nil || transaction == nil {
+				return err
+			}
+
+			expired++
+			return addEvent(ctx, p.outbox, entity.DomainEventPromoExpired, grant.WalletID, fundsMoved(transaction))
+		})
+		if err != nil && !isInactiveWallet(err) {
+			errs = append(errs, fmt.Errorf("PromoUseCase - ExpirePromoGrants - p.repo.ExpirePromoGrant: grant %d: %w", grant.ID, err))
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
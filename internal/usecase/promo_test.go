@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestGrantPromoDomainEvent(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	expiresAt, _ := time.Parse(time.RFC3339, "2024-03-05T17:25:35Z")
+	repo := mock_usecase.NewMockPromoRepo(c)
+	repo.EXPECT().GrantPromo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, grant *entity.PromoGrant) (*entity.PromoGrant, error) {
+			grant.ID = 7
+			grant.ExpiresAt = expiresAt
+			return grant, nil
+		})
+
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	_, err := NewPromo(repo, outbox, time.Hour).GrantPromo(context.Background(), "a", 50)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.Equal(t, entity.DomainEventPromoGranted, stored[0].Type)
+	require.Equal(t, "a", stored[0].AggregateID)
+	require.JSONEq(t, `{"grant_id":7,"wallet_id":"a","amount":50,"expires_at":"2024-03-05T17:25:35Z"}`, string(stored[0].Payload))
+}
+
+func TestExpirePromoGrants(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	first := entity.PromoGrant{ID: 1, WalletID: "a"}
+	second := entity.PromoGrant{ID: 2, WalletID: "b"}
+	repo := mock_usecase.NewMockPromoRepo(c)
+	repo.EXPECT().GetExpiredPromoGrants(gomock.Any(), gomock.Any()).Return([]entity.PromoGrant{first, second}, nil)
+	repo.EXPECT().ExpirePromoGrant(gomock.Any(), first, gomock.Any()).
+		Return(&entity.Transaction{From: "a", To: entity.SystemPromoWalletID, Amount: 20, Type: entity.TransactionTypePromoExpiry}, nil)
+	// The second grant is spent in the meantime
+	repo.EXPECT().ExpirePromoGrant(gomock.Any(), second, gomock.Any()).Return(nil, nil)
+
+	// Each grant is expired with its event in its own transaction
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(atomic)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	expired, err := NewPromo(repo, outbox, time.Hour).ExpirePromoGrants(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+	require.Len(t, stored, 1)
+	require.Equal(t, entity.DomainEventPromoExpired, stored[0].Type)
+	require.Equal(t, "a", stored[0].AggregateID)
+}
+
+func TestExpirePromoGrantsContinuesAfterFailure(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	frozen := entity.PromoGrant{ID: 1, WalletID: "a"}
+	failed := entity.PromoGrant{ID: 2, WalletID: "b"}
+	last := entity.PromoGrant{ID: 3, WalletID: "c"}
+	repo := mock_usecase.NewMockPromoRepo(c)
+	repo.EXPECT().GetExpiredPromoGrants(gomock.Any(), gomock.Any()).Return([]entity.PromoGrant{frozen, failed, last}, nil)
+	repo.EXPECT().ExpirePromoGrant(gomock.Any(), frozen, gomock.Any()).Return(nil, entity.ErrWalletFrozen)
+	repo.EXPECT().ExpirePromoGrant(gomock.Any(), failed, gomock.Any()).Return(nil, errors.New("connection reset"))
+	repo.EXPECT().ExpirePromoGrant(gomock.Any(), last, gomock.Any()).
+		Return(&entity.Transaction{From: "c", To: entity.SystemPromoWalletID, Amount: 20, Type: entity.TransactionTypePromoExpiry}, nil)
+
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(atomic)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(nil)
+
+	// The frozen wallet is skipped, the failure is reported after the rest of the grants are expired
+	expired, err := NewPromo(repo, outbox, time.Hour).ExpirePromoGrants(context.Background())
+	require.Equal(t, 1, expired)
+	require.EqualError(t, err, "PromoUseCase - ExpirePromoGrants - p.repo.ExpirePromoGrant: grant 2: connection reset")
+	require.NotErrorIs(t, err, entity.ErrWalletFrozen)
+}
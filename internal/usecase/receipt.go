@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// ReceiptUseCase -.
+type ReceiptUseCase struct {
+	repo       ReceiptRepo
+	SigningKey ed25519.PrivateKey
+}
+
+// NewReceipt -.
+func NewReceipt(r ReceiptRepo, signingKey ed25519.PrivateKey) *ReceiptUseCase {
+	return &ReceiptUseCase{
+		repo:       r,
+		SigningKey: signingKey,
+	}
+}
+
+// IssueReceipt - signing the receipt of the recorded transaction
+func (rp *ReceiptUseCase) IssueReceipt(transaction *entity.Transaction) (*entity.Receipt, error) {
+	if rp.SigningKey == nil {
+		return nil, fmt.Errorf("ReceiptUseCase - IssueReceipt: %w", entity.ErrReceiptSigningKeyMissing)
+	}
+	// Storages without transaction ids can't identify the transaction of the receipt
+	if transaction.ID == 0 {
+		return nil, fmt.Errorf("ReceiptUseCase - IssueReceipt: %w", entity.ErrReceiptUnavailable)
+	}
+
+	receipt := entity.NewReceipt(transaction)
+	receipt.PublicKey = rp.publicKey()
+	receipt.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(rp.SigningKey, receipt.Message()))
+
+	return receipt, nil
+}
+
+// GetReceipt - getting the receipt of the transaction for one of its parties, the transaction of other wallets
+// is not found, so the sequential transaction ids don't reveal the transfers of others.
+func (rp *ReceiptUseCase) GetReceipt(ctx context.Context, transactionId int64, walletId string) (*entity.Receipt, error) {
+	if rp.SigningKey == nil {
+		return nil, fmt.Errorf("ReceiptUseCase - GetReceipt: %w", entity.ErrReceiptSigningKeyMissing)
+	}
+
+	transaction, err := rp.repo.GetTransactionById(ctx, transactionId)
+	if err != nil {
+		return nil, fmt.Errorf("ReceiptUseCase - GetReceipt - rp.repo.GetTransactionById: %w", err)
+	}
+	if walletId == "" || (transaction.From != walletId && transaction.To != walletId) {
+		return nil, fmt.Errorf("ReceiptUseCase - GetReceipt: %w", entity.ErrTransactionNotFound)
+	}
+
+	return rp.IssueReceipt(transaction)
+}
+
+// VerifyReceipt - checking that the receipt is signed by the current key of the service
+func (rp *ReceiptUseCase) VerifyReceipt(receipt entity.Receipt) (*entity.ReceiptVerification, error) {
+	if rp.SigningKey == nil {
+		return nil, fmt.Errorf("ReceiptUseCase - VerifyReceipt: %w", entity.ErrReceiptSigningKeyMissing)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(receipt.Signature)
+	valid := err == nil &&
+		receipt.PublicKey == rp.publicKey() &&
+		ed25519.Verify(rp.SigningKey.Public().(ed25519.PublicKey), receipt.Message(), signature)
+
+	return &entity.ReceiptVerification{Valid: valid}, nil
+}
+
+// GetPublicKey - getting the key third parties verify the receipts with
+func (rp *ReceiptUseCase) GetPublicKey() (*entity.ReceiptKey, error) {
+	if rp.SigningKey == nil {
+		return nil, fmt.Errorf("ReceiptUseCase - GetPublicKey: %w", entity.ErrReceiptSigningKeyMissing)
+	}
+
+	return &entity.ReceiptKey{
+		Algorithm: entity.ReceiptAlgorithm,
+		PublicKey: rp.publicKey(),
+	}, nil
+}
+
+// publicKey - base64 encoded public key of the signing key.
+func (rp *ReceiptUseCase) publicKey() string {
+	return base64.StdEncoding.EncodeToString(rp.SigningKey.Public().(ed25519.PublicKey))
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func testTransaction() *entity.Transaction {
+	return &entity.Transaction{
+		ID:     1024,
+		Time:   time.Date(2024, 2, 4, 20, 25, 35, 448000000, time.FixedZone("MSK", 3*60*60)),
+		From:   "5b53700ed469fa6a09ea72bb78f36fd9",
+		To:     "eb376add88bf8e70f80787266a0801d5",
+		Amount: 30.1,
+		Type:   entity.TransactionTypeTransfer,
+	}
+}
+
+func TestIssueReceipt(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+	receipt, err := NewReceipt(nil, key).IssueReceipt(testTransaction())
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1024), receipt.TransactionID)
+	require.Equal(t, time.Date(2024, 2, 4, 17, 25, 35, 448000000, time.UTC), receipt.Time)
+	require.Equal(t, "receipt:1024:5b53700ed469fa6a09ea72bb78f36fd9:eb376add88bf8e70f80787266a0801d5:30.1:2024-02-04T17:25:35.448Z", string(receipt.Message()))
+
+	// The receipt is verified offline with the published key
+	signature, err := base64.StdEncoding.DecodeString(receipt.Signature)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), receipt.Message(), signature))
+}
+
+func TestIssueReceiptUnavailable(t *testing.T) {
+	_, err := NewReceipt(nil, nil).IssueReceipt(testTransaction())
+	require.ErrorIs(t, err, entity.ErrReceiptSigningKeyMissing)
+
+	transaction := testTransaction()
+	transaction.ID = 0
+	_, err = NewReceipt(nil, ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))).IssueReceipt(transaction)
+	require.ErrorIs(t, err, entity.ErrReceiptUnavailable)
+}
+
+func TestGetReceipt(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+	tests := []struct {
+		name          string
+		walletId      string
+		expectedError error
+	}{
+		{
+			name:     "Sender",
+			walletId: "5b53700ed469fa6a09ea72bb78f36fd9",
+		},
+		{
+			name:     "Receiver",
+			walletId: "eb376add88bf8e70f80787266a0801d5",
+		},
+		{
+			name:          "Other wallet",
+			walletId:      "0c4f8a7b2e6d4c1f9a3b5d7e8f0a1b2c",
+			expectedError: entity.ErrTransactionNotFound,
+		},
+		{
+			name:          "Without wallet",
+			expectedError: entity.ErrTransactionNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockReceiptRepo(c)
+			repo.EXPECT().GetTransactionById(context.Background(), int64(1024)).Return(testTransaction(), nil)
+
+			receipt, err := NewReceipt(repo, key).GetReceipt(context.Background(), 1024, test.walletId)
+			if test.expectedError != nil {
+				require.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(1024), receipt.TransactionID)
+		})
+	}
+}
+
+func TestVerifyReceipt(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	otherKey := ed25519.NewKeyFromSeed([]byte("another-receipt-signing-key-seed"))
+	receipts := NewReceipt(nil, key)
+
+	issued, err := receipts.IssueReceipt(testTransaction())
+	require.NoError(t, err)
+	forged, err := NewReceipt(nil, otherKey).IssueReceipt(testTransaction())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		receipt  func() entity.Receipt
+		expected bool
+	}{
+		{
+			name:     "Ok",
+			receipt:  func() entity.Receipt { return *issued },
+			expected: true,
+		},
+		{
+			name: "Changed amount",
+			receipt: func() entity.Receipt {
+				receipt := *issued
+				receipt.Amount = 301.0
+				return receipt
+			},
+		},
+		{
+			name: "Changed receiver",
+			receipt: func() entity.Receipt {
+				receipt := *issued
+				receipt.To = "0c4f8a7b2e6d4c1f9a3b5d7e8f0a1b2c"
+				return receipt
+			},
+		},
+		{
+			name:    "Signed by another key",
+			receipt: func() entity.Receipt { return *forged },
+		},
+		{
+			name: "Broken signature",
+			receipt: func() entity.Receipt {
+				receipt := *issued
+				receipt.Signature = "not base64"
+				return receipt
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verification, err := receipts.VerifyReceipt(test.receipt())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, verification.Valid)
+		})
+	}
+}
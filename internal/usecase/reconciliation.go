@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// ReconciliationUseCase -.
+type ReconciliationUseCase struct {
+	repo           ReconciliationRepo
+	BatchSize      int
+	DefaultBalance float64
+}
+
+// NewReconciliation -.
+func NewReconciliation(r ReconciliationRepo, batchSize int, defaultBalance float64) *ReconciliationUseCase {
+	return &ReconciliationUseCase{
+		repo:           r,
+		BatchSize:      batchSize,
+		DefaultBalance: defaultBalance,
+	}
+}
+
+// Reconcile - comparing the balance of every wallet with its initial balance and recorded movements, and the sum of
+// all balances with the sum of initial balances. The wallets are read in batches, so only a batch is held in memory.
+// Wallets created before the initial balances were stored are taken as created with the default balance.
+func (r *ReconciliationUseCase) Reconcile(ctx context.Context) (*entity.Reconciliation, error) {
+	_, err := r.repo.SetInitialBalances(ctx, r.DefaultBalance)
+	if err != nil {
+		return nil, fmt.Errorf("ReconciliationUseCase - Reconcile - r.repo.SetInitialBalances: %w", err)
+	}
+
+	reconciliation := &entity.Reconciliation{
+		StartedAt:  time.Now(),
+		Mismatches: make([]entity.BalanceMismatch, 0),
+	}
+
+	afterId := ""
+	for {
+		ledgers, err := r.repo.GetWalletLedgers(ctx, afterId, r.BatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("ReconciliationUseCase - Reconcile - r.repo.GetWalletLedgers: %w", err)
+		}
+
+		for _, ledger := range ledgers {
+			reconciliation.Wallets++
+			difference := ledger.Balance - ledger.Expected()
+			if math.Abs(difference) <= entity.ReconciliationTolerance {
+				continue
+			}
+
+			reconciliation.Mismatched++
+			if len(reconciliation.Mismatches) < entity.MaxReportedMismatches {
+				reconciliation.Mismatches = append(reconciliation.Mismatches, entity.BalanceMismatch{
+					WalletID:       ledger.WalletID,
+					Balance:        ledger.Balance,
+					InitialBalance: ledger.InitialBalance,
+					Incoming:       ledger.Incoming,
+					Outgoing:       ledger.Outgoing,
+					Expected:       ledger.Expected(),
+					Difference:     difference,
+				})
+			}
+		}
+
+		if len(ledgers) == 0 || len(ledgers) < r.BatchSize {
+			break
+		}
+		afterId = ledgers[len(ledgers)-1].WalletID
+	}
+
+	// Money is conserved if the transfers neither created nor destroyed it
+	total, initial, err := r.repo.GetTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ReconciliationUseCase - Reconcile - r.repo.GetTotals: %w", err)
+	}
+	reconciliation.TotalBalance = total
+	reconciliation.TotalInitialBalance = initial
+	reconciliation.Conserved = math.Abs(total-initial) <= entity.ReconciliationTolerance
+	reconciliation.FinishedAt = time.Now()
+
+	err = r.repo.SaveReconciliation(ctx, reconciliation)
+	if err != nil {
+		return nil, fmt.Errorf("ReconciliationUseCase - Reconcile - r.repo.SaveReconciliation: %w", err)
+	}
+
+	return reconciliation, nil
+}
+
+// GetLastReconciliation - getting the result of the latest reconciliation
+func (r *ReconciliationUseCase) GetLastReconciliation(ctx context.Context) (*entity.Reconciliation, error) {
+	reconciliation, err := r.repo.GetLastReconciliation(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ReconciliationUseCase - GetLastReconciliation - r.repo.GetLastReconciliation: %w", err)
+	}
+
+	return reconciliation, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestReconcile(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockReconciliationRepo(c)
+	// Wallets without an initial balance are taken as created with the default balance before they are read,
+	// the wallets are read in batches following the last wallet of the previous batch
+	gomock.InOrder(
+		repo.EXPECT().SetInitialBalances(context.Background(), 100.0).Return(1, nil),
+		repo.EXPECT().GetWalletLedgers(context.Background(), "", 2).Return([]entity.WalletLedger{
+			{WalletID: "a", Balance: 70.0, InitialBalance: 100.0, Outgoing: 30.0},
+			{WalletID: "b", Balance: 140.0, InitialBalance: 100.0, Incoming: 30.0},
+		}, nil),
+		repo.EXPECT().GetWalletLedgers(context.Background(), "b", 2).Return([]entity.WalletLedger{
+			{WalletID: "c", Balance: 100.0, InitialBalance: 100.0},
+		}, nil),
+	)
+	repo.EXPECT().GetTotals(context.Background()).Return(310.0, 300.0, nil)
+	repo.EXPECT().SaveReconciliation(context.Background(), gomock.Any()).Return(nil)
+
+	reconciliation, err := NewReconciliation(repo, 2, 100).Reconcile(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 3, reconciliation.Wallets)
+	require.Equal(t, 1, reconciliation.Mismatched)
+	require.Equal(t, []entity.BalanceMismatch{
+		{WalletID: "b", Balance: 140.0, InitialBalance: 100.0, Incoming: 30.0, Expected: 130.0, Difference: 10.0},
+	}, reconciliation.Mismatches)
+	require.Equal(t, 310.0, reconciliation.TotalBalance)
+	require.Equal(t, 300.0, reconciliation.TotalInitialBalance)
+	require.False(t, reconciliation.Conserved)
+	require.False(t, reconciliation.FinishedAt.Before(reconciliation.StartedAt))
+}
+
+func TestReconcileWithoutDrift(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockReconciliationRepo(c)
+	repo.EXPECT().SetInitialBalances(context.Background(), 0.0).Return(0, nil)
+	repo.EXPECT().GetWalletLedgers(context.Background(), "", 10).Return([]entity.WalletLedger{
+		// Float rounding errors are not a drift
+		{WalletID: "a", Balance: 0.3, InitialBalance: 0.1, Incoming: 0.2},
+		{WalletID: "system-promo", Balance: -0.2, Outgoing: 0.2},
+	}, nil)
+	repo.EXPECT().GetTotals(context.Background()).Return(0.1, 0.1, nil)
+	repo.EXPECT().SaveReconciliation(context.Background(), gomock.Any()).Return(nil)
+
+	reconciliation, err := NewReconciliation(repo, 10, 0).Reconcile(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 2, reconciliation.Wallets)
+	require.Equal(t, 0, reconciliation.Mismatched)
+	require.Empty(t, reconciliation.Mismatches)
+	require.True(t, reconciliation.Conserved)
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+// StatementUseCase -.
+type StatementUseCase struct {
+	repo     WalletRepo
+	Currency string
+}
+
+// NewStatement -.
+func NewStatement(r WalletRepo, currency string) *StatementUseCase {
+	return &StatementUseCase{
+		repo:     r,
+		Currency: currency,
+	}
+}
+
+// GetStatement - getting movements of a wallet for the period [from, to) with running balance
+func (s *StatementUseCase) GetStatement(ctx context.Context, walletId string, from time.Time, to time.Time) (*entity.Statement, error) {
+	if !to.After(from) {
+		return nil, entity.ErrWrongPeriod
+	}
+
+	// The opening balance and the movements are read from one state, so a concurrent transfer can't be missed
+	// or counted twice
+	var opening float64
+	var transactions []entity.Transaction
+	err := s.repo.ReadSnapshot(ctx, func(ctx context.Context) error {
+		var err error
+		opening, err = s.repo.GetBalanceAt(ctx, walletId, from)
+		if err != nil {
+			return fmt.Errorf("StatementUseCase - GetStatement - s.repo.GetBalanceAt: %w", err)
+		}
+
+		transactions, err = s.repo.GetWalletHistoryById(ctx, walletId, entity.HistoryFilter{From: from, To: to})
+		if err != nil {
+			return fmt.Errorf("StatementUseCase - GetStatement - s.repo.GetWalletHistoryById: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statement := newStatement(walletId, from, to, opening, transactions)
+	statement.Currency = s.Currency
+	statement.GeneratedAt = time.Now().UTC()
+
+	return statement, nil
+}
+
+// newStatement - building a statement from the opening balance and movements of the period
+func newStatement(walletId string, from time.Time, to time.Time, opening float64, transactions []entity.Transaction) *entity.Statement {
+	statement := &entity.Statement{
+		WalletID:       walletId,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		Lines:          make([]entity.StatementLine, 0, len(transactions)),
+	}
+
+	balance := opening
+	for _, transaction := range transactions {
+		line := entity.StatementLine{
+			Time:              transaction.Time,
+			Type:              transaction.Type,
+			Counterparty:      transaction.From,
+			Description:       transaction.Description,
+			ExternalReference: transaction.ExternalReference,
+			Amount:            transaction.Amount,
+		}
+		if transaction.From == walletId {
+			line.Counterparty = transaction.To
+			line.Amount = -transaction.Amount
+		}
+
+		balance += line.Amount
+		line.Balance = balance
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = balance
+
+	return statement
+}
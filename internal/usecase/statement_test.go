@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestGetStatement(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := mock_usecase.NewMockWalletRepo(c)
+	// Both reads are made in one snapshot
+	inSnapshot := false
+	repo.EXPECT().ReadSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		inSnapshot = true
+		defer func() { inSnapshot = false }()
+		return fn(ctx)
+	})
+	repo.EXPECT().GetBalanceAt(context.Background(), walletId, from).DoAndReturn(func(context.Context, string, time.Time) (float64, error) {
+		require.True(t, inSnapshot)
+		return 100.0, nil
+	})
+	repo.EXPECT().GetWalletHistoryById(context.Background(), walletId, entity.HistoryFilter{From: from, To: to}).DoAndReturn(func(context.Context, string, entity.HistoryFilter) ([]entity.Transaction, error) {
+		require.True(t, inSnapshot)
+		return []entity.Transaction{
+			{From: walletId, To: "eb376add88bf8e70f80787266a0801d5", Amount: 30.0, Type: entity.TransactionTypeTransfer},
+			{From: "system-promo", To: walletId, Amount: 5.5, Type: entity.TransactionTypePromoGrant},
+		}, nil
+	})
+
+	statement, err := NewStatement(repo, "XXX").GetStatement(context.Background(), walletId, from, to)
+	require.NoError(t, err)
+
+	require.Equal(t, "XXX", statement.Currency)
+	require.Equal(t, 100.0, statement.OpeningBalance)
+	require.Equal(t, 75.5, statement.ClosingBalance)
+	require.Len(t, statement.Lines, 2)
+	require.Equal(t, "eb376add88bf8e70f80787266a0801d5", statement.Lines[0].Counterparty)
+	require.Equal(t, -30.0, statement.Lines[0].Amount)
+	require.Equal(t, 70.0, statement.Lines[0].Balance)
+	require.Equal(t, "system-promo", statement.Lines[1].Counterparty)
+	require.Equal(t, 5.5, statement.Lines[1].Amount)
+	require.Equal(t, 75.5, statement.Lines[1].Balance)
+
+	_, err = NewStatement(repo, "XXX").GetStatement(context.Background(), walletId, to, from)
+	require.ErrorIs(t, err, entity.ErrWrongPeriod)
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const (
+	// Voucher codes use an alphabet without look-alike characters
+	voucherCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	voucherCodeLength   = 16
+	voucherCodeGroup    = 4
+)
+
+// VoucherUseCase -.
+type VoucherUseCase struct {
+	repo              VoucherRepo
+	outbox            OutboxRepo
+	MaxFailedAttempts int
+	AttemptsWindow    time.Duration
+}
+
+// NewVoucher - redemptions and refunds are stored together with their domain events in the outbox.
+func NewVoucher(r VoucherRepo, o OutboxRepo, maxFailedAttempts int, attemptsWindow time.Duration) *VoucherUseCase {
+	return &VoucherUseCase{
+		repo:              r,
+		outbox:            o,
+		MaxFailedAttempts: maxFailedAttempts,
+		AttemptsWindow:    attemptsWindow,
+	}
+}
+
+// IssueVouchers - generating a batch of random codes funded from the source wallet
+func (v *VoucherUseCase) IssueVouchers(ctx context.Context, request entity.VoucherBatchRequest) (*entity.VoucherBatch, error) {
+	if request.Amount <= 0 {
+		return nil, entity.ErrWrongAmount
+	}
+	if request.Count < 1 || request.Count > entity.MaxVouchersInBatch || request.MaxRedemptions < 1 || !request.ExpiresAt.After(time.Now()) {
+		return nil, entity.ErrWrongVoucherBatch
+	}
+
+	batchID, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("VoucherUseCase - IssueVouchers - randomHex: %w", err)
+	}
+
+	batch := &entity.VoucherBatch{
+		ID:             batchID,
+		SourceWalletID: request.SourceWalletID,
+		Amount:         request.Amount,
+		MaxRedemptions: request.MaxRedemptions,
+		ExpiresAt:      request.ExpiresAt,
+		Codes:          make([]string, 0, request.Count),
+	}
+	vouchers := make([]entity.Voucher, 0, request.Count)
+	for i := 0; i < request.Count; i++ {
+		code, err := generateVoucherCode()
+		if err != nil {
+			return nil, fmt.Errorf("VoucherUseCase - IssueVouchers - generateVoucherCode: %w", err)
+		}
+
+		batch.Codes = append(batch.Codes, code)
+		vouchers = append(vouchers, entity.Voucher{
+			BatchID:        batch.ID,
+			CodeHash:       hashVoucherCode(code),
+			SourceWalletID: batch.SourceWalletID,
+			Amount:         batch.Amount,
+			MaxRedemptions: batch.MaxRedemptions,
+			ExpiresAt:      batch.ExpiresAt,
+		})
+	}
+
+	err = v.repo.CreateVouchers(ctx, vouchers)
+	if err != nil {
+		return nil, fmt.Errorf("VoucherUseCase - IssueVouchers - v.repo.CreateVouchers: %w", err)
+	}
+
+	return batch, nil
+}
+
+// RedeemVoucher - crediting the wallet with the voucher amount, failed attempts are limited per wallet.
+// Attempts of the wallet are counted, made and recorded one at a time, so concurrent attempts can't exceed the limit.
+func (v *VoucherUseCase) RedeemVoucher(ctx context.Context, walletId string, code string) (*entity.Transaction, error) {
+	now := time.Now()
+
+	var transaction *entity.Transaction
+	var rejected error
+	err := v.repo.Atomic(ctx, func(ctx context.Context) error {
+		err := v.repo.LockRedemptions(ctx, walletId)
+		if err != nil {
+			return fmt.Errorf("VoucherUseCase - RedeemVoucher - v.repo.LockRedemptions: %w", err)
+		}
+
+		failed, err := v.repo.CountFailedRedemptions(ctx, walletId, now.Add(-v.AttemptsWindow))
+		if err != nil {
+			return fmt.Errorf("VoucherUseCase - RedeemVoucher - v.repo.CountFailedRedemptions: %w", err)
+		}
+		if failed >= v.MaxFailedAttempts {
+			return entity.ErrTooManyAttempts
+		}
+
+		transaction, err = v.repo.RedeemVoucher(ctx, walletId, hashVoucherCode(code), now)
+		// The failed attempt is committed, the rejection is returned after it
+		if errors.Is(err, entity.ErrVoucherNotFound) || errors.Is(err, entity.ErrVoucherExpired) || errors.Is(err, entity.ErrVoucherUsed) {
+			rejected = err
+			if err := v.repo.RecordFailedRedemption(ctx, walletId, now); err != nil {
+				return fmt.Errorf("VoucherUseCase - RedeemVoucher - v.repo.RecordFailedRedemption: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("VoucherUseCase - RedeemVoucher - v.repo.RedeemVoucher: %w", err)
+		}
+
+		return addEvent(ctx, v.outbox, entity.DomainEventVoucherRedeemed, walletId, fundsMoved(transaction))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejected != nil {
+		return nil, fmt.Errorf("VoucherUseCase - RedeemVoucher - v.repo.RedeemVoucher: %w", rejected)
+	}
+
+	return transaction, nil
+}
+
+// RefundExpiredVouchers - returning unused value of expired vouchers and forgetting old failed attempts
+func (v *VoucherUseCase) RefundExpiredVouchers(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	err := v.repo.DeleteFailedRedemptions(ctx, now.Add(-v.AttemptsWindow))
+	if err != nil {
+		return 0, fmt.Errorf("VoucherUseCase - RefundExpiredVouchers - v.repo.DeleteFailedRedemptions: %w", err)
+	}
+
+	var refunds []entity.Transaction
+	err = v.repo.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		refunds, err = v.repo.RefundExpiredVouchers(ctx, now)
+		if err != nil {
+			return fmt.Errorf("VoucherUseCase - RefundExpiredVouchers - v.repo.RefundExpiredVouchers: %w", err)
+		}
+
+		for i := range refunds {
+			err := addEvent(ctx, v.outbox, entity.DomainEventVoucherRefunded, refunds[i].To, fundsMoved(&refunds[i]))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(refunds), nil
+}
+
+// generateVoucherCode - generating a code like XXXX-XXXX-XXXX-XXXX with a cryptographically secure generator
+func generateVoucherCode() (string, error) {
+	var code strings.Builder
+	max := big.NewInt(int64(len(voucherCodeAlphabet)))
+
+	for i := 0; i < voucherCodeLength; i++ {
+		if i > 0 && i%voucherCodeGroup == 0 {
+			code.WriteByte('-')
+		}
+
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(voucherCodeAlphabet[n.Int64()])
+	}
+
+	return code.String(), nil
+}
+
+// hashVoucherCode - hashing the normalized code, so the case and separators entered by the user don't matter
+func hashVoucherCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// randomHex - generating a random identifier of n bytes
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
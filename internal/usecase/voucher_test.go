@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestRedeemVoucher(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockVoucherRepo(c)
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	// The attempts are counted under the lock of the wallet
+	gomock.InOrder(
+		repo.EXPECT().LockRedemptions(gomock.Any(), "a").Return(nil),
+		repo.EXPECT().CountFailedRedemptions(gomock.Any(), "a", gomock.Any()).Return(2, nil),
+		repo.EXPECT().RedeemVoucher(gomock.Any(), "a", hashVoucherCode("ABCD-EFGH-JKLM-NPQR"), gomock.Any()).
+			Return(&entity.Transaction{From: entity.SystemVoucherWalletID, To: "a", Amount: 50.0, Type: entity.TransactionTypeVoucherRedeem}, nil),
+	)
+	// The redemption is stored with its event
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	transaction, err := NewVoucher(repo, outbox, 3, time.Hour).RedeemVoucher(context.Background(), "a", "abcd efgh jklm npqr")
+	require.NoError(t, err)
+	require.Equal(t, 50.0, transaction.Amount)
+	require.Len(t, stored, 1)
+	require.Equal(t, entity.DomainEventVoucherRedeemed, stored[0].Type)
+	require.Equal(t, "a", stored[0].AggregateID)
+	require.JSONEq(t, `{"from":"system-vouchers","to":"a","amount":50,"type":"voucher_redeem","time":"0001-01-01T00:00:00Z"}`, string(stored[0].Payload))
+}
+
+func TestRedeemVoucherRejected(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockVoucherRepo(c)
+	// The failed attempt is recorded in the transaction, which is committed
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		require.NoError(t, fn(ctx))
+		return nil
+	})
+	gomock.InOrder(
+		repo.EXPECT().LockRedemptions(gomock.Any(), "a").Return(nil),
+		repo.EXPECT().CountFailedRedemptions(gomock.Any(), "a", gomock.Any()).Return(2, nil),
+		repo.EXPECT().RedeemVoucher(gomock.Any(), "a", gomock.Any(), gomock.Any()).Return(nil, entity.ErrVoucherNotFound),
+		repo.EXPECT().RecordFailedRedemption(gomock.Any(), "a", gomock.Any()).Return(nil),
+	)
+
+	_, err := NewVoucher(repo, mock_usecase.NewMockOutboxRepo(c), 3, time.Hour).RedeemVoucher(context.Background(), "a", "ABCD-EFGH-JKLM-NPQR")
+	require.ErrorIs(t, err, entity.ErrVoucherNotFound)
+}
+
+func TestRedeemVoucherTooManyAttempts(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockVoucherRepo(c)
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	repo.EXPECT().LockRedemptions(gomock.Any(), "a").Return(nil)
+	repo.EXPECT().CountFailedRedemptions(gomock.Any(), "a", gomock.Any()).Return(3, nil)
+
+	_, err := NewVoucher(repo, mock_usecase.NewMockOutboxRepo(c), 3, time.Hour).RedeemVoucher(context.Background(), "a", "ABCD-EFGH-JKLM-NPQR")
+	require.ErrorIs(t, err, entity.ErrTooManyAttempts)
+}
+
+func TestRefundExpiredVouchers(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockVoucherRepo(c)
+	repo.EXPECT().DeleteFailedRedemptions(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+	repo.EXPECT().RefundExpiredVouchers(gomock.Any(), gomock.Any()).Return([]entity.Transaction{
+		{From: entity.SystemVoucherWalletID, To: "a", Amount: 100.0, Type: entity.TransactionTypeVoucherRefund, ExternalReference: "batch-1"},
+		{From: entity.SystemVoucherWalletID, To: "b", Amount: 50.0, Type: entity.TransactionTypeVoucherRefund, ExternalReference: "batch-2"},
+	}, nil)
+
+	// Each refund is stored with the event of its source wallet
+	var stored []entity.DomainEvent
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, events ...entity.DomainEvent) error {
+			stored = append(stored, events...)
+			return nil
+		})
+
+	refunded, err := NewVoucher(repo, outbox, 3, time.Hour).RefundExpiredVouchers(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, refunded)
+	require.Len(t, stored, 2)
+	require.Equal(t, entity.DomainEventVoucherRefunded, stored[0].Type)
+	require.Equal(t, "a", stored[0].AggregateID)
+	require.Equal(t, "b", stored[1].AggregateID)
+}
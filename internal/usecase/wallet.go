@@ -3,69 +3,147 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/egor-denisov/wallet-infotecs/internal/entity"
 )
 
+// versionsKey - context key of the wallet versions expected by the client.
+type versionsKey struct{}
+
+// WithExpectedVersions - changes of the wallet made with the context are refused with entity.ErrVersionMismatch,
+// unless the wallet has one of the versions. Without versions every change is refused.
+func WithExpectedVersions(ctx context.Context, versions ...int64) context.Context {
+	if versions == nil {
+		versions = make([]int64, 0)
+	}
+	return context.WithValue(ctx, versionsKey{}, versions)
+}
+
 // WalletUseCase -.
 type WalletUseCase struct {
-	repo   WalletRepo
+	repo           WalletRepo
+	outbox         OutboxRepo
 	DefaultBalance float64
+	SavingsRate    float64
 }
 
-// New -.
-func New(r WalletRepo, b float64) *WalletUseCase {
+// New - state changes are stored together with their domain events in the outbox.
+func New(r WalletRepo, o OutboxRepo, b float64, savingsRate float64) *WalletUseCase {
 	return &WalletUseCase{
-		repo:   r,
+		repo:           r,
+		outbox:         o,
 		DefaultBalance: b,
+		SavingsRate:    savingsRate,
 	}
 }
 
-// CreateNewWallet - creating a new wallet
-func (w *WalletUseCase) CreateNewWalletWithDefaultBalance(ctx context.Context) (*entity.Wallet, error) {
+// CreateNewWallet - creating a new wallet of the type, savings wallets get the current savings rate
+func (w *WalletUseCase) CreateNewWalletWithDefaultBalance(ctx context.Context, walletType string) (*entity.Wallet, error) {
 	// Create a new instance of the wallet with default balance
 	defaultWallet := &entity.Wallet{
 		Balance: w.DefaultBalance,
+		Type:    entity.WalletTypeStandard,
+		Status:  entity.WalletStatusActive,
 	}
+	switch walletType {
+	case "", entity.WalletTypeStandard:
+	case entity.WalletTypeSavings:
+		defaultWallet.Type = entity.WalletTypeSavings
+		defaultWallet.AnnualRate = w.SavingsRate
+	default:
+		return nil, entity.ErrWrongWalletType
+	}
+
+	var wallet *entity.Wallet
+	err := w.outbox.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		wallet, err = w.repo.CreateNewWallet(ctx, defaultWallet)
+		if err != nil {
+			return fmt.Errorf("WalletUseCase - CreateNewWalletWithDefaultBalance - w.repo.CreateNewWallet: %w", err)
+		}
 
-	wallet, err := w.repo.CreateNewWallet(ctx, defaultWallet)
+		return addEvent(ctx, w.outbox, entity.DomainEventWalletCreated, wallet.ID, entity.WalletCreated{
+			WalletID:   wallet.ID,
+			Type:       wallet.Type,
+			Balance:    wallet.Balance,
+			AnnualRate: wallet.AnnualRate,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("WalletUseCase - CreateNewWalletWithDefaultBalance - w.repo.CreateNewWallet: %w", err)
+		return nil, err
 	}
 
 	return wallet, nil
 }
 
-// SendFunds - sending funds between wallets
-func (w *WalletUseCase) SendFunds(ctx context.Context, from string, to string, amount float64) error {
-	if amount <= 0 {
-		return entity.ErrWrongAmount
+// SendFunds - sending funds between wallets, the recorded transaction is returned
+func (w *WalletUseCase) SendFunds(ctx context.Context, from string, request entity.TransactionRequest) (*entity.Transaction, error) {
+	if request.Amount <= 0 {
+		return nil, entity.ErrWrongAmount
 	}
 
 	transaction := &entity.Transaction{
-		From: from,
-		To: to,
-		Amount: amount,
+		From:              from,
+		To:                request.To,
+		Amount:            request.Amount,
+		Description:       stripControlCharacters(request.Description),
+		ExternalReference: stripControlCharacters(request.ExternalReference),
+		Type:              entity.TransactionTypeTransfer,
+		UniqueReference:   request.UniqueReference,
+	}
+	// Transfers without a reference are never duplicates
+	if transaction.ExternalReference == "" {
+		transaction.UniqueReference = false
 	}
 	if transaction.From == transaction.To {
-		return entity.ErrSenderIsReceiver
+		return nil, entity.ErrSenderIsReceiver
+	}
+	if utf8.RuneCountInString(transaction.Description) > entity.MaxDescriptionLength {
+		return nil, entity.ErrDescriptionTooLong
 	}
+	if utf8.RuneCountInString(transaction.ExternalReference) > entity.MaxExternalReferenceLength {
+		return nil, entity.ErrExternalReferenceTooLong
+	}
+
+	err := w.outbox.Atomic(ctx, func(ctx context.Context) error {
+		if err := w.checkVersion(ctx, transaction.From); err != nil {
+			return fmt.Errorf("WalletUseCase - SendFunds - w.checkVersion: %w", err)
+		}
 
-	err := w.repo.SendFunds(ctx, transaction)
+		err := w.repo.SendFunds(ctx, transaction)
+		if err != nil {
+			return fmt.Errorf("WalletUseCase - SendFunds - w.repo.SendFunds: %w", err)
+		}
+
+		return addEvent(ctx, w.outbox, entity.DomainEventFundsTransferred, transaction.From, entity.FundsTransferred{
+			From:              transaction.From,
+			To:                transaction.To,
+			Amount:            transaction.Amount,
+			Description:       transaction.Description,
+			ExternalReference: transaction.ExternalReference,
+			Time:              transaction.Time,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("WalletUseCase - SendFunds - w.repo.SendFunds: %w", err)
+		return nil, err
 	}
 
-	return nil
+	return transaction, nil
 }
 
 // GetWalletHistoryById - getting a history of a wallet
-func (w *WalletUseCase) GetWalletHistoryById(ctx context.Context, walletId string) ([]entity.Transaction, error) {
-	transactions, err := w.repo.GetWalletHistoryById(ctx, walletId)
+func (w *WalletUseCase) GetWalletHistoryById(ctx context.Context, walletId string, filter entity.HistoryFilter) ([]entity.Transaction, error) {
+	filter.ExternalReference = stripControlCharacters(filter.ExternalReference)
+
+	transactions, err := w.repo.GetWalletHistoryById(ctx, walletId, filter)
 	if err != nil {
 		return nil, fmt.Errorf("WalletUseCase - GetWalletHistoryById - w.repo.GetWalletHistoryById: %w", err)
 	}
-	
+
 	return transactions, nil
 }
 
@@ -75,6 +153,163 @@ func (w *WalletUseCase) GetWalletById(ctx context.Context, walletId string) (*en
 	if err != nil {
 		return nil, fmt.Errorf("WalletUseCase - GetWalletById - w.repo.GetWalletById: %w", err)
 	}
-	
+
+	grants, err := w.repo.GetPromoGrants(ctx, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("WalletUseCase - GetWalletById - w.repo.GetPromoGrants: %w", err)
+	}
+	// Splitting the balance into buckets
+	promo := 0.0
+	wallet.Expirations = make([]entity.PromoExpiration, 0, len(grants))
+	for _, grant := range grants {
+		promo += grant.Remaining
+		wallet.Expirations = append(wallet.Expirations, entity.PromoExpiration{
+			Amount:    grant.Remaining,
+			ExpiresAt: grant.ExpiresAt,
+		})
+	}
+	wallet.Buckets = []entity.BalanceBucket{
+		{Name: entity.BucketMain, Amount: wallet.Balance - promo},
+		{Name: entity.BucketPromo, Amount: promo},
+	}
+	setAvailableCredit(wallet)
+
+	if wallet.ParentID == "" {
+		pockets, err := w.repo.GetPockets(ctx, walletId)
+		if err != nil {
+			return nil, fmt.Errorf("WalletUseCase - GetWalletById - w.repo.GetPockets: %w", err)
+		}
+		// Aggregating the balance of the wallet with its pockets
+		if len(pockets) > 0 {
+			total := wallet.Balance
+			for _, pocket := range pockets {
+				total += pocket.Balance
+			}
+			wallet.Pockets = pockets
+			wallet.TotalBalance = &total
+		}
+	}
+
+	return wallet, nil
+}
+
+// SetCreditLimit - changing the credit limit of a wallet
+func (w *WalletUseCase) SetCreditLimit(ctx context.Context, walletId string, creditLimit float64) (*entity.Wallet, error) {
+	if creditLimit < 0 {
+		return nil, entity.ErrWrongCreditLimit
+	}
+
+	var wallet *entity.Wallet
+	err := w.outbox.Atomic(ctx, func(ctx context.Context) error {
+		if err := w.checkVersion(ctx, walletId); err != nil {
+			return fmt.Errorf("WalletUseCase - SetCreditLimit - w.checkVersion: %w", err)
+		}
+
+		var err error
+		wallet, err = w.repo.SetCreditLimit(ctx, walletId, creditLimit)
+		if err != nil {
+			return fmt.Errorf("WalletUseCase - SetCreditLimit - w.repo.SetCreditLimit: %w", err)
+		}
+
+		return addEvent(ctx, w.outbox, entity.DomainEventCreditLimitChanged, walletId, entity.CreditLimitChanged{
+			WalletID:    walletId,
+			CreditLimit: creditLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	setAvailableCredit(wallet)
+
 	return wallet, nil
-}
\ No newline at end of file
+}
+
+// SetWalletStatus - freezing, unfreezing or closing a wallet together with its pockets
+func (w *WalletUseCase) SetWalletStatus(ctx context.Context, walletId string, status string) (*entity.Wallet, error) {
+	var eventType string
+	switch status {
+	case entity.WalletStatusActive:
+		eventType = entity.DomainEventWalletUnfrozen
+	case entity.WalletStatusFrozen:
+		eventType = entity.DomainEventWalletFrozen
+	case entity.WalletStatusClosed:
+		eventType = entity.DomainEventWalletClosed
+	default:
+		return nil, entity.ErrWrongWalletStatus
+	}
+
+	var wallet *entity.Wallet
+	err := w.outbox.Atomic(ctx, func(ctx context.Context) error {
+		if err := w.checkVersion(ctx, walletId); err != nil {
+			return fmt.Errorf("WalletUseCase - SetWalletStatus - w.checkVersion: %w", err)
+		}
+
+		var err error
+		wallet, err = w.repo.SetWalletStatus(ctx, walletId, status)
+		if err != nil {
+			return fmt.Errorf("WalletUseCase - SetWalletStatus - w.repo.SetWalletStatus: %w", err)
+		}
+
+		return addEvent(ctx, w.outbox, eventType, walletId, entity.WalletStatusChanged{
+			WalletID: walletId,
+			Status:   status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// LockWallet - getting the wallet locked by the repository till the end of the transaction of the context
+func (w *WalletUseCase) LockWallet(ctx context.Context, walletId string) (*entity.Wallet, error) {
+	if _, err := w.repo.GetWalletVersion(ctx, walletId); err != nil {
+		return nil, fmt.Errorf("WalletUseCase - LockWallet - w.repo.GetWalletVersion: %w", err)
+	}
+
+	return w.GetWalletById(ctx, walletId)
+}
+
+// checkVersion - refusing the change if the wallet doesn't have one of the versions expected by the client.
+// The wallet is locked by the repository, so it can't change between the check and the change.
+func (w *WalletUseCase) checkVersion(ctx context.Context, walletId string) error {
+	versions, ok := ctx.Value(versionsKey{}).([]int64)
+	if !ok {
+		return nil
+	}
+
+	version, err := w.repo.GetWalletVersion(ctx, walletId)
+	if err != nil {
+		return err
+	}
+	for _, expected := range versions {
+		if expected == version {
+			return nil
+		}
+	}
+
+	return entity.ErrVersionMismatch
+}
+
+// setAvailableCredit - calculating the unused part of the credit limit of a wallet with a credit line
+func setAvailableCredit(wallet *entity.Wallet) {
+	if wallet.CreditLimit <= 0 {
+		return
+	}
+
+	available := wallet.CreditLimit - math.Max(0, -wallet.Balance)
+	wallet.AvailableCredit = &available
+}
+
+// stripControlCharacters - removing control characters and surrounding spaces from user input
+func stripControlCharacters(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	return strings.TrimSpace(s)
+}
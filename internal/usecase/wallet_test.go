@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+)
+
+func TestWalletDomainEvents(t *testing.T) {
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+
+	tests := []struct {
+		name            string
+		mockBehavior    func(r *mock_usecase.MockWalletRepo)
+		call            func(w *WalletUseCase) error
+		expectedType    string
+		expectedPayload string
+	}{
+		{
+			name: "WalletCreated",
+			mockBehavior: func(r *mock_usecase.MockWalletRepo) {
+				r.EXPECT().CreateNewWallet(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, wallet *entity.Wallet) (*entity.Wallet, error) {
+						wallet.ID = walletId
+						return wallet, nil
+					})
+			},
+			call: func(w *WalletUseCase) error {
+				_, err := w.CreateNewWalletWithDefaultBalance(context.Background(), entity.WalletTypeSavings)
+				return err
+			},
+			expectedType:    entity.DomainEventWalletCreated,
+			expectedPayload: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","type":"savings","balance":100,"annual_rate":0.05}`,
+		},
+		{
+			name: "FundsTransferred",
+			mockBehavior: func(r *mock_usecase.MockWalletRepo) {
+				r.EXPECT().SendFunds(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			call: func(w *WalletUseCase) error {
+				_, err := w.SendFunds(context.Background(), walletId, entity.TransactionRequest{To: "eb376add88bf8e70f80787266a0801d5", Amount: 30})
+				return err
+			},
+			expectedType:    entity.DomainEventFundsTransferred,
+			expectedPayload: `{"from":"5b53700ed469fa6a09ea72bb78f36fd9","to":"eb376add88bf8e70f80787266a0801d5","amount":30,"time":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "WalletFrozen",
+			mockBehavior: func(r *mock_usecase.MockWalletRepo) {
+				r.EXPECT().SetWalletStatus(gomock.Any(), walletId, entity.WalletStatusFrozen).Return(&entity.Wallet{ID: walletId, Status: entity.WalletStatusFrozen}, nil)
+			},
+			call: func(w *WalletUseCase) error {
+				_, err := w.SetWalletStatus(context.Background(), walletId, entity.WalletStatusFrozen)
+				return err
+			},
+			expectedType:    entity.DomainEventWalletFrozen,
+			expectedPayload: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","status":"frozen"}`,
+		},
+		{
+			name: "CreditLimitChanged",
+			mockBehavior: func(r *mock_usecase.MockWalletRepo) {
+				r.EXPECT().SetCreditLimit(gomock.Any(), walletId, 500.0).Return(&entity.Wallet{ID: walletId, CreditLimit: 500}, nil)
+			},
+			call: func(w *WalletUseCase) error {
+				_, err := w.SetCreditLimit(context.Background(), walletId, 500)
+				return err
+			},
+			expectedType:    entity.DomainEventCreditLimitChanged,
+			expectedPayload: `{"wallet_id":"5b53700ed469fa6a09ea72bb78f36fd9","credit_limit":500}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockWalletRepo(c)
+			test.mockBehavior(repo)
+
+			var stored []entity.DomainEvent
+			outbox := mock_usecase.NewMockOutboxRepo(c)
+			outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+			outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, events ...entity.DomainEvent) error {
+					stored = append(stored, events...)
+					return nil
+				})
+
+			require.NoError(t, test.call(New(repo, outbox, 100, 0.05)))
+			require.Len(t, stored, 1)
+			require.Equal(t, test.expectedType, stored[0].Type)
+			require.Equal(t, walletId, stored[0].AggregateID)
+			require.JSONEq(t, test.expectedPayload, string(stored[0].Payload))
+		})
+	}
+}
+
+func TestWalletDomainEventsFailedChange(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_usecase.NewMockWalletRepo(c)
+	repo.EXPECT().SendFunds(gomock.Any(), gomock.Any()).Return(entity.ErrInsufficientFunds)
+
+	// No event is stored for a rejected transfer
+	outbox := mock_usecase.NewMockOutboxRepo(c)
+	outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+
+	w := New(repo, outbox, 100, 0.05)
+	_, err := w.SendFunds(context.Background(), "5b53700ed469fa6a09ea72bb78f36fd9", entity.TransactionRequest{To: "eb376add88bf8e70f80787266a0801d5", Amount: 30})
+	require.True(t, errors.Is(err, entity.ErrInsufficientFunds))
+}
+
+func TestWalletExpectedVersions(t *testing.T) {
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+
+	tests := []struct {
+		name          string
+		versions      []int64
+		expectedError error
+	}{
+		{
+			name:     "Matching version",
+			versions: []int64{3, 4},
+		},
+		{
+			name:          "Changed wallet",
+			versions:      []int64{3},
+			expectedError: entity.ErrVersionMismatch,
+		},
+		{
+			name:          "No versions",
+			versions:      nil,
+			expectedError: entity.ErrVersionMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockWalletRepo(c)
+			repo.EXPECT().GetWalletVersion(gomock.Any(), walletId).Return(int64(4), nil)
+			outbox := mock_usecase.NewMockOutboxRepo(c)
+			outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+			// The wallet is changed only if the version matches
+			if test.expectedError == nil {
+				repo.EXPECT().SetWalletStatus(gomock.Any(), walletId, entity.WalletStatusFrozen).Return(&entity.Wallet{ID: walletId, Version: 5}, nil)
+				outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			ctx := WithExpectedVersions(context.Background(), test.versions...)
+			_, err := New(repo, outbox, 100, 0.05).SetWalletStatus(ctx, walletId, entity.WalletStatusFrozen)
+			if test.expectedError != nil {
+				require.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLockWallet(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+	repo := mock_usecase.NewMockWalletRepo(c)
+	// The wallet is read only after it is locked
+	gomock.InOrder(
+		repo.EXPECT().GetWalletVersion(gomock.Any(), walletId).Return(int64(4), nil),
+		repo.EXPECT().GetWalletById(gomock.Any(), walletId).Return(&entity.Wallet{ID: walletId, Balance: 100, Version: 4}, nil),
+		repo.EXPECT().GetPromoGrants(gomock.Any(), walletId).Return(nil, nil),
+		repo.EXPECT().GetPockets(gomock.Any(), walletId).Return(nil, nil),
+	)
+
+	wallet, err := New(repo, mock_usecase.NewMockOutboxRepo(c), 100, 0.05).LockWallet(context.Background(), walletId)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), wallet.Version)
+
+	repo.EXPECT().GetWalletVersion(gomock.Any(), walletId).Return(int64(0), entity.ErrWalletNotFound)
+	_, err = New(repo, mock_usecase.NewMockOutboxRepo(c), 100, 0.05).LockWallet(context.Background(), walletId)
+	require.ErrorIs(t, err, entity.ErrWalletNotFound)
+}
+
+func TestStripControlCharacters(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain text", "Invoice payment", "Invoice payment"},
+		{"Line breaks and tabs", "Invoice\r\npayment\t", "Invoicepayment"},
+		{"Surrounding spaces", "  INV-1  ", "INV-1"},
+		{"Escape sequence", "\x1b[31mred\x1b[0m", "[31mred[0m"},
+		{"Unicode", "Оплата\u0000 счета\u009f", "Оплата счета"},
+		{"Only control characters", "\x00\x07\n", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, stripControlCharacters(test.input))
+		})
+	}
+}
+
+func TestSendFundsLimits(t *testing.T) {
+	walletId := "5b53700ed469fa6a09ea72bb78f36fd9"
+	receiverId := "eb376add88bf8e70f80787266a0801d5"
+
+	tests := []struct {
+		name                      string
+		request                   entity.TransactionRequest
+		expectedErr               error
+		expectedDescription       string
+		expectedExternalReference string
+	}{
+		{
+			name:                "Longest description in runes",
+			request:             entity.TransactionRequest{To: receiverId, Amount: 10, Description: strings.Repeat("ж", entity.MaxDescriptionLength)},
+			expectedDescription: strings.Repeat("ж", entity.MaxDescriptionLength),
+		},
+		{
+			name:        "Description is too long",
+			request:     entity.TransactionRequest{To: receiverId, Amount: 10, Description: strings.Repeat("ж", entity.MaxDescriptionLength+1)},
+			expectedErr: entity.ErrDescriptionTooLong,
+		},
+		{
+			name:                "Control characters don't count",
+			request:             entity.TransactionRequest{To: receiverId, Amount: 10, Description: " " + strings.Repeat("a\n", entity.MaxDescriptionLength) + "\t"},
+			expectedDescription: strings.Repeat("a", entity.MaxDescriptionLength),
+		},
+		{
+			name:                      "Longest external reference in runes",
+			request:                   entity.TransactionRequest{To: receiverId, Amount: 10, ExternalReference: strings.Repeat("№", entity.MaxExternalReferenceLength)},
+			expectedExternalReference: strings.Repeat("№", entity.MaxExternalReferenceLength),
+		},
+		{
+			name:        "External reference is too long",
+			request:     entity.TransactionRequest{To: receiverId, Amount: 10, ExternalReference: strings.Repeat("№", entity.MaxExternalReferenceLength+1)},
+			expectedErr: entity.ErrExternalReferenceTooLong,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			// Refused transfers don't reach the repository
+			repo := mock_usecase.NewMockWalletRepo(c)
+			outbox := mock_usecase.NewMockOutboxRepo(c)
+			if test.expectedErr == nil {
+				outbox.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(atomic)
+				outbox.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().SendFunds(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			transaction, err := New(repo, outbox, 100, 0.05).SendFunds(context.Background(), walletId, test.request)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedDescription, transaction.Description)
+			require.Equal(t, test.expectedExternalReference, transaction.ExternalReference)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const (
+	// Number of deliveries sent by one run of the dispatcher
+	webhookDispatchBatch = 20
+)
+
+// webhookEventTypes - events which can be delivered to the partner systems.
+var webhookEventTypes = []string{
+	entity.WalletEventIncomingTransfer,
+	entity.WalletEventOutgoingTransfer,
+	entity.WalletEventBalanceChanged,
+}
+
+// WebhookUseCase -.
+type WebhookUseCase struct {
+	repo        WebhookRepo
+	sender      WebhookSender
+	timeout     time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+// NewWebhook - failed attempts are retried after backoff doubled with each attempt up to maxBackoff.
+// After maxAttempts the delivery is failed and only sent again on replay.
+func NewWebhook(r WebhookRepo, s WebhookSender, timeout time.Duration, maxAttempts int, backoff time.Duration, maxBackoff time.Duration) *WebhookUseCase {
+	return &WebhookUseCase{
+		repo:        r,
+		sender:      s,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+// CreateWebhook - subscribing a partner system to the events of the wallet. The secret is generated if it isn't provided.
+func (w *WebhookUseCase) CreateWebhook(ctx context.Context, request entity.WebhookRequest) (*entity.Webhook, error) {
+	if err := validateWebhookURL(request.URL); err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := webhookTypes(request.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		secret, err = randomHex(32)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookUseCase - CreateWebhook - randomHex: %w", err)
+		}
+	}
+
+	webhook, err := w.repo.CreateWebhook(ctx, &entity.Webhook{
+		WalletID:   request.WalletID,
+		URL:        request.URL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebhookUseCase - CreateWebhook - w.repo.CreateWebhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// GetWebhooks - getting subscriptions of the wallet
+func (w *WebhookUseCase) GetWebhooks(ctx context.Context, walletId string) ([]entity.Webhook, error) {
+	webhooks, err := w.repo.GetWebhooks(ctx, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookUseCase - GetWebhooks - w.repo.GetWebhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook - deleting the subscription, its pending deliveries are not sent
+func (w *WebhookUseCase) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	if err := w.repo.DeleteWebhook(ctx, webhookId); err != nil {
+		return fmt.Errorf("WebhookUseCase - DeleteWebhook - w.repo.DeleteWebhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries - getting deliveries of the subscription, optionally only with the status
+func (w *WebhookUseCase) GetDeliveries(ctx context.Context, webhookId int64, status string) ([]entity.WebhookDelivery, error) {
+	switch status {
+	case "", entity.WebhookDeliveryPending, entity.WebhookDeliveryDelivered, entity.WebhookDeliveryFailed:
+	default:
+		return nil, entity.ErrWrongDeliveryStatus
+	}
+
+	deliveries, err := w.repo.GetDeliveries(ctx, webhookId, status)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookUseCase - GetDeliveries - w.repo.GetDeliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery - sending a failed delivery again with the next run of the dispatcher
+func (w *WebhookUseCase) ReplayDelivery(ctx context.Context, webhookId int64, deliveryId int64) (*entity.WebhookDelivery, error) {
+	delivery, err := w.repo.ReplayDelivery(ctx, webhookId, deliveryId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("WebhookUseCase - ReplayDelivery - w.repo.ReplayDelivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// DispatchDeliveries - sending the due deliveries and scheduling retries of the failed ones.
+// Returns the number of delivered events.
+func (w *WebhookUseCase) DispatchDeliveries(ctx context.Context) (int, error) {
+	// The claim outlives the attempts of the whole batch, so it isn't sent twice
+	lease := time.Duration(webhookDispatchBatch+1) * w.timeout
+
+	deliveries, err := w.repo.ClaimDeliveries(ctx, time.Now(), lease, webhookDispatchBatch)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookUseCase - DispatchDeliveries - w.repo.ClaimDeliveries: %w", err)
+	}
+
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		sendCtx, cancel := context.WithTimeout(ctx, w.timeout)
+		statusCode, err := w.sender.Send(sendCtx, *delivery)
+		cancel()
+		// The attempt is interrupted by shutdown, the delivery is sent again after the lease
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		now := time.Now()
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		delivery.LastError = ""
+		switch {
+		case err == nil:
+			delivery.Status = entity.WebhookDeliveryDelivered
+			delivery.DeliveredAt = &now
+			delivered++
+		case delivery.Attempts >= w.maxAttempts:
+			delivery.Status = entity.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			delivery.NextAttemptAt = now.Add(w.retryDelay(delivery.Attempts))
+			delivery.LastError = err.Error()
+		}
+
+		if err := w.repo.UpdateDelivery(ctx, delivery); err != nil {
+			return delivered, fmt.Errorf("WebhookUseCase - DispatchDeliveries - w.repo.UpdateDelivery: %w", err)
+		}
+	}
+
+	return delivered, nil
+}
+
+// retryDelay - exponential backoff after the failed attempt.
+func (w *WebhookUseCase) retryDelay(attempts int) time.Duration {
+	delay := w.backoff
+	for i := 1; i < attempts && delay < w.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.maxBackoff {
+		delay = w.maxBackoff
+	}
+
+	return delay
+}
+
+// validateWebhookURL - only absolute http and https URLs are accepted.
+func validateWebhookURL(rawURL string) error {
+	if len(rawURL) > entity.MaxWebhookURLLength {
+		return entity.ErrWrongWebhook
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return entity.ErrWrongWebhook
+	}
+
+	return nil
+}
+
+// webhookTypes - checking the requested event types, all types are delivered if none is requested.
+func webhookTypes(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return webhookEventTypes, nil
+	}
+
+	types := make([]string, 0, len(requested))
+	seen := make(map[string]bool, len(requested))
+	for _, eventType := range requested {
+		known := false
+		for _, t := range webhookEventTypes {
+			known = known || t == eventType
+		}
+		if !known {
+			return nil, entity.ErrWrongWebhook
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			types = append(types, eventType)
+		}
+	}
+
+	return types, nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+	mock_usecase "github.com/egor-denisov/wallet-infotecs/internal/usecase/mocks"
+	"github.com/egor-denisov/wallet-infotecs/internal/webhook"
+)
+
+func TestDispatchDeliveries(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	// The receiver accepts only the second delivery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(webhook.DeliveryHeader) != "2" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	hook := &entity.Webhook{ID: 1, URL: server.URL, Secret: "secret"}
+	repo := mock_usecase.NewMockWebhookRepo(c)
+	repo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), 21*time.Second, webhookDispatchBatch).Return([]entity.WebhookDelivery{
+		{ID: 1, WebhookID: 1, Payload: "{}", Status: entity.WebhookDeliveryPending, Attempts: 1, Webhook: hook},
+		{ID: 2, WebhookID: 1, Payload: "{}", Status: entity.WebhookDeliveryPending, Webhook: hook},
+		{ID: 3, WebhookID: 1, Payload: "{}", Status: entity.WebhookDeliveryPending, Attempts: 4, Webhook: hook},
+	}, nil)
+
+	updated := make(map[int64]entity.WebhookDelivery)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, delivery *entity.WebhookDelivery) error {
+			updated[delivery.ID] = *delivery
+			return nil
+		})
+
+	w := NewWebhook(repo, webhook.NewSender(), time.Second, 5, time.Minute, time.Hour)
+	start := time.Now()
+	delivered, err := w.DispatchDeliveries(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+
+	// The second attempt is retried after the doubled backoff
+	retried := updated[1]
+	require.Equal(t, entity.WebhookDeliveryPending, retried.Status)
+	require.Equal(t, 2, retried.Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, retried.LastStatusCode)
+	require.Equal(t, "unexpected status 503", retried.LastError)
+	require.WithinDuration(t, start.Add(2*time.Minute), retried.NextAttemptAt, 5*time.Second)
+
+	sent := updated[2]
+	require.Equal(t, entity.WebhookDeliveryDelivered, sent.Status)
+	require.Equal(t, 1, sent.Attempts)
+	require.Equal(t, http.StatusOK, sent.LastStatusCode)
+	require.NotNil(t, sent.DeliveredAt)
+
+	// The last attempt moves the delivery to the dead letters
+	dead := updated[3]
+	require.Equal(t, entity.WebhookDeliveryFailed, dead.Status)
+	require.Equal(t, 5, dead.Attempts)
+	require.Nil(t, dead.DeliveredAt)
+}
+
+func TestRetryDelay(t *testing.T) {
+	w := NewWebhook(nil, nil, time.Second, 10, 30*time.Second, 5*time.Minute)
+
+	for attempts, expected := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		4:  4 * time.Minute,
+		5:  5 * time.Minute,
+		60: 5 * time.Minute,
+	} {
+		require.Equal(t, expected, w.retryDelay(attempts), "attempts %d", attempts)
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	tests := []struct {
+		name               string
+		request            entity.WebhookRequest
+		expectedEventTypes []string
+		expectedError      error
+	}{
+		{
+			name:               "All events by default",
+			request:            entity.WebhookRequest{WalletID: "5b53700ed469fa6a09ea72bb78f36fd9", URL: "https://partner.example/hooks"},
+			expectedEventTypes: webhookEventTypes,
+		},
+		{
+			name: "Duplicated event types",
+			request: entity.WebhookRequest{
+				WalletID:   "5b53700ed469fa6a09ea72bb78f36fd9",
+				URL:        "http://partner.example/hooks",
+				EventTypes: []string{entity.WalletEventIncomingTransfer, entity.WalletEventIncomingTransfer},
+				Secret:     "secret",
+			},
+			expectedEventTypes: []string{entity.WalletEventIncomingTransfer},
+		},
+		{
+			name:          "Unknown event type",
+			request:       entity.WebhookRequest{WalletID: "5b53700ed469fa6a09ea72bb78f36fd9", URL: "https://partner.example/hooks", EventTypes: []string{"payment"}},
+			expectedError: entity.ErrWrongWebhook,
+		},
+		{
+			name:          "Relative URL",
+			request:       entity.WebhookRequest{WalletID: "5b53700ed469fa6a09ea72bb78f36fd9", URL: "/hooks"},
+			expectedError: entity.ErrWrongWebhook,
+		},
+		{
+			name:          "Not an HTTP URL",
+			request:       entity.WebhookRequest{WalletID: "5b53700ed469fa6a09ea72bb78f36fd9", URL: "ftp://partner.example/hooks"},
+			expectedError: entity.ErrWrongWebhook,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_usecase.NewMockWebhookRepo(c)
+			if test.expectedError == nil {
+				repo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, hook *entity.Webhook) (*entity.Webhook, error) {
+						return hook, nil
+					})
+			}
+
+			w := NewWebhook(repo, nil, time.Second, 5, time.Minute, time.Hour)
+			hook, err := w.CreateWebhook(context.Background(), test.request)
+			if test.expectedError != nil {
+				require.ErrorIs(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expectedEventTypes, hook.EventTypes)
+			if test.request.Secret != "" {
+				require.Equal(t, test.request.Secret, hook.Secret)
+			} else {
+				require.Len(t, hook.Secret, 64)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+const (
+	// SignatureHeader - header with HMAC-SHA256 signature of the timestamp and the body, see Sign.
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader - header with unix time of the attempt, receivers reject old timestamps to prevent replays.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// EventHeader - header with the type of the wallet event.
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader - header with the ID of the delivery, it is the same for all attempts.
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// Sender - delivering webhook payloads over HTTP.
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender - the duration of an attempt is limited by the context.
+func NewSender() *Sender {
+	return &Sender{
+		client: &http.Client{
+			// Redirects are reported as failures, the subscription URL has to be updated instead
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send - posting the signed payload to the subscription URL. Any status except 2xx is a failed attempt.
+func (s *Sender) Send(ctx context.Context, delivery entity.WebhookDelivery) (int, error) {
+	if delivery.Webhook == nil {
+		return 0, fmt.Errorf("Sender - Send - delivery %d has no webhook", delivery.ID)
+	}
+
+	payload := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("Sender - Send - http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(delivery.Webhook.Secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Sender - Send - s.client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	// Draining the body, so the connection is reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign - calculating hex encoded HMAC-SHA256 of "<timestamp>.<payload>" with the subscription secret.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
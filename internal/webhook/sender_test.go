@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/egor-denisov/wallet-infotecs/internal/entity"
+)
+
+func Test_Send(t *testing.T) {
+	tests := []struct {
+		name               string
+		status             int
+		expectedStatusCode int
+		expectedError      bool
+	}{
+		{
+			name:               "Delivered",
+			status:             http.StatusNoContent,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Receiver failed",
+			status:             http.StatusServiceUnavailable,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedError:      true,
+		},
+		{
+			name:               "Redirect is not followed",
+			status:             http.StatusFound,
+			expectedStatusCode: http.StatusFound,
+			expectedError:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var received *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				if test.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			delivery := entity.WebhookDelivery{
+				ID:        7,
+				EventType: entity.WalletEventIncomingTransfer,
+				Payload:   `{"id":42,"amount":30}`,
+				Webhook:   &entity.Webhook{URL: server.URL + "/hooks", Secret: "secret"},
+			}
+			statusCode, err := NewSender().Send(context.Background(), delivery)
+
+			require.Equal(t, test.expectedStatusCode, statusCode)
+			require.Equal(t, test.expectedError, err != nil)
+			require.Equal(t, "/hooks", received.URL.Path)
+			require.Equal(t, delivery.Payload, string(body))
+			require.Equal(t, "7", received.Header.Get(DeliveryHeader))
+			require.Equal(t, entity.WalletEventIncomingTransfer, received.Header.Get(EventHeader))
+
+			// The receiver checks the signature with the secret of the subscription
+			timestamp := received.Header.Get(TimestampHeader)
+			require.NotEmpty(t, timestamp)
+			require.Equal(t, Sign("secret", timestamp, body), received.Header.Get(SignatureHeader))
+			require.NotEqual(t, Sign("other", timestamp, body), received.Header.Get(SignatureHeader))
+		})
+	}
+}
+
+func Test_SendUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	delivery := entity.WebhookDelivery{
+		Payload: "{}",
+		Webhook: &entity.Webhook{URL: server.URL, Secret: "secret"},
+	}
+	statusCode, err := NewSender().Send(context.Background(), delivery)
+
+	require.Equal(t, 0, statusCode)
+	require.Error(t, err)
+}
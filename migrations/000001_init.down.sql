@@ -1,5 +1,5 @@
-DROP FUNCTION IF EXISTS make_uid();
+DROP TABLE IF EXISTS transactions;
 
 DROP TABLE IF EXISTS wallets;
 
-DROP TABLE IF EXISTS transactions;
\ No newline at end of file
+DROP FUNCTION IF EXISTS make_uid();
@@ -24,4 +24,10 @@ CREATE TABLE IF NOT EXISTS transactions
     from_wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id),
     to_wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id),
     amount FLOAT NOT NULL
-);
\ No newline at end of file
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS description VARCHAR(255);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS external_reference VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS transactions_external_reference_idx ON transactions (external_reference);
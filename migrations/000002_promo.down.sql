@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS promo_grants;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS type;
+
+DELETE FROM transactions WHERE from_wallet_id = 'system-promo' OR to_wallet_id = 'system-promo';
+
+DELETE FROM wallets WHERE id = 'system-promo';
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS wallets_balance_check;
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_balance_check CHECK (balance >= 0);
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS is_system;
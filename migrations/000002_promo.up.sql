@@ -0,0 +1,22 @@
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS is_system BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS wallets_balance_check;
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_balance_check CHECK (is_system OR balance >= 0);
+
+INSERT INTO wallets (id, balance, is_system) VALUES ('system-promo', 0, true) ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS type VARCHAR(32) NOT NULL DEFAULT 'transfer';
+
+CREATE TABLE IF NOT EXISTS promo_grants
+(
+    id BIGSERIAL PRIMARY KEY,
+    wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    amount FLOAT NOT NULL CHECK (amount > 0),
+    remaining FLOAT NOT NULL CHECK (remaining >= 0),
+    granted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expired_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS promo_grants_wallet_id_idx ON promo_grants (wallet_id) WHERE remaining > 0 AND expired_at IS NULL;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS voucher_attempts;
+
+DROP TABLE IF EXISTS voucher_redemptions;
+
+DROP TABLE IF EXISTS vouchers;
+
+DELETE FROM transactions WHERE from_wallet_id = 'system-vouchers' OR to_wallet_id = 'system-vouchers';
+
+DELETE FROM wallets WHERE id = 'system-vouchers';
@@ -0,0 +1,33 @@
+INSERT INTO wallets (id, balance, is_system) VALUES ('system-vouchers', 0, true) ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS vouchers
+(
+    id BIGSERIAL PRIMARY KEY,
+    batch_id VARCHAR(32) NOT NULL,
+    code_hash CHAR(64) NOT NULL UNIQUE,
+    source_wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    amount FLOAT NOT NULL CHECK (amount > 0),
+    max_redemptions INT NOT NULL CHECK (max_redemptions > 0),
+    redemptions INT NOT NULL DEFAULT 0 CHECK (redemptions <= max_redemptions),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    refunded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS vouchers_expires_at_idx ON vouchers (expires_at) WHERE refunded_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS voucher_redemptions
+(
+    voucher_id BIGINT NOT NULL REFERENCES vouchers(id),
+    wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    time TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (voucher_id, wallet_id)
+);
+
+CREATE TABLE IF NOT EXISTS voucher_attempts
+(
+    wallet_id TEXT NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS voucher_attempts_wallet_id_time_idx ON voucher_attempts (wallet_id, time);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS payments;
+
+DELETE FROM transactions WHERE from_wallet_id = 'system-gateway' OR to_wallet_id = 'system-gateway';
+
+DELETE FROM wallets WHERE id = 'system-gateway';
@@ -0,0 +1,15 @@
+INSERT INTO wallets (id, balance, is_system) VALUES ('system-gateway', 0, true) ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS payments
+(
+    id VARCHAR(32) PRIMARY KEY,
+    wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    type VARCHAR(16) NOT NULL,
+    amount FLOAT NOT NULL CHECK (amount > 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    gateway_reference VARCHAR(128),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS payments_wallet_id_idx ON payments (wallet_id);
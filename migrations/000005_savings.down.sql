@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS interest_accruals;
+
+DELETE FROM transactions WHERE from_wallet_id = 'system-interest' OR to_wallet_id = 'system-interest';
+
+DELETE FROM wallets WHERE id = 'system-interest';
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS annual_rate;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS type;
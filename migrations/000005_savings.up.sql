@@ -0,0 +1,18 @@
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS type VARCHAR(16) NOT NULL DEFAULT 'standard';
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS annual_rate FLOAT NOT NULL DEFAULT 0 CHECK (annual_rate >= 0);
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+INSERT INTO wallets (id, balance, is_system) VALUES ('system-interest', 0, true) ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS interest_accruals
+(
+    wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    day DATE NOT NULL,
+    balance FLOAT NOT NULL,
+    annual_rate FLOAT NOT NULL,
+    amount FLOAT NOT NULL,
+    capitalized_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (wallet_id, day)
+);
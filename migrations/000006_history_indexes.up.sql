@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS transactions_from_wallet_id_time_idx ON transactions (from_wallet_id, time);
+
+CREATE INDEX IF NOT EXISTS transactions_to_wallet_id_time_idx ON transactions (to_wallet_id, time);
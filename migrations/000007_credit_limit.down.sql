@@ -0,0 +1,5 @@
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS wallets_balance_check;
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_balance_check CHECK (is_system OR balance >= 0);
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS credit_limit;
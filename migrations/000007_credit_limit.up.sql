@@ -0,0 +1,5 @@
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS credit_limit FLOAT NOT NULL DEFAULT 0 CHECK (credit_limit >= 0);
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS wallets_balance_check;
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_balance_check CHECK (is_system OR balance >= -credit_limit);
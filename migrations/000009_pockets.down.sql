@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS wallets_parent_id_idx;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS name;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS parent_id;
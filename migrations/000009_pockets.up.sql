@@ -0,0 +1,5 @@
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS parent_id TEXT REFERENCES wallets(id);
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS name VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS wallets_parent_id_idx ON wallets (parent_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS wallet_events
+(
+    id BIGSERIAL PRIMARY KEY,
+    wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    type VARCHAR(32) NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    amount FLOAT NOT NULL,
+    balance FLOAT NOT NULL,
+    counterparty TEXT NOT NULL,
+    transaction_type VARCHAR(32) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS wallet_events_wallet_id_id_idx ON wallet_events (wallet_id, id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS domain_events
+(
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    payload JSONB NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS domain_events_unpublished_idx ON domain_events (id) WHERE published_at IS NULL;
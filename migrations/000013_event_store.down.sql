@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS wallet_history_projection;
+
+DROP TABLE IF EXISTS wallet_balance_projection;
+
+DROP TABLE IF EXISTS wallet_stream_snapshots;
+
+DROP TABLE IF EXISTS wallet_stream_events;
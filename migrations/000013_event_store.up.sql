@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS wallet_stream_events
+(
+    stream_id TEXT NOT NULL,
+    version BIGINT NOT NULL CHECK (version > 0),
+    position BIGSERIAL NOT NULL UNIQUE,
+    type VARCHAR(32) NOT NULL,
+    data JSONB NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (stream_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS wallet_stream_snapshots
+(
+    stream_id TEXT PRIMARY KEY,
+    version BIGINT NOT NULL,
+    state JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS wallet_balance_projection
+(
+    id TEXT PRIMARY KEY,
+    type VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    balance FLOAT NOT NULL,
+    credit_limit FLOAT NOT NULL,
+    annual_rate FLOAT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    version BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS wallet_history_projection
+(
+    position BIGINT PRIMARY KEY,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    from_wallet_id TEXT NOT NULL,
+    to_wallet_id TEXT NOT NULL,
+    amount FLOAT NOT NULL,
+    description VARCHAR(255),
+    external_reference VARCHAR(64),
+    type VARCHAR(32) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS wallet_history_projection_from_wallet_id_time_idx ON wallet_history_projection (from_wallet_id, time);
+
+CREATE INDEX IF NOT EXISTS wallet_history_projection_to_wallet_id_time_idx ON wallet_history_projection (to_wallet_id, time);
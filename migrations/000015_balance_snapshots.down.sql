@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS balance_snapshots;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS to_balance_after;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS from_balance_after;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS id;
@@ -0,0 +1,51 @@
+-- Transactions are numbered in the order they change the balances, existing ones in time order
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS id BIGINT;
+
+CREATE SEQUENCE IF NOT EXISTS transactions_id_seq OWNED BY transactions.id;
+
+UPDATE transactions AS t SET id = numbered.id
+FROM (SELECT ctid, row_number() OVER (ORDER BY time, ctid) AS id FROM transactions) AS numbered
+WHERE t.ctid = numbered.ctid;
+
+SELECT setval('transactions_id_seq', COALESCE(MAX(id), 0) + 1, false) FROM transactions;
+
+ALTER TABLE transactions ALTER COLUMN id SET DEFAULT nextval('transactions_id_seq');
+
+ALTER TABLE transactions ALTER COLUMN id SET NOT NULL;
+
+ALTER TABLE transactions ADD PRIMARY KEY (id);
+
+-- Balances of the wallets right after the transaction
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS from_balance_after FLOAT;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS to_balance_after FLOAT;
+
+-- The balance after an existing transaction is the current balance without the later movements of the wallet
+WITH legs AS (
+    SELECT id, from_wallet_id AS wallet_id, -amount AS amount, true AS outgoing FROM transactions
+    UNION ALL
+    SELECT id, to_wallet_id, amount, false FROM transactions
+), balances AS (
+    SELECT legs.id, legs.outgoing,
+        w.balance - COALESCE(SUM(legs.amount) OVER (
+            PARTITION BY legs.wallet_id ORDER BY legs.id DESC ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+        ), 0) AS balance
+    FROM legs
+    JOIN wallets AS w ON w.id = legs.wallet_id
+)
+UPDATE transactions AS t
+SET from_balance_after = b.from_balance, to_balance_after = b.to_balance
+FROM (
+    SELECT id, MAX(balance) FILTER (WHERE outgoing) AS from_balance, MAX(balance) FILTER (WHERE NOT outgoing) AS to_balance
+    FROM balances
+    GROUP BY id
+) AS b
+WHERE t.id = b.id;
+
+CREATE TABLE IF NOT EXISTS balance_snapshots
+(
+    wallet_id TEXT NOT NULL REFERENCES wallets(id),
+    day DATE NOT NULL,
+    balance FLOAT NOT NULL,
+    PRIMARY KEY (wallet_id, day)
+);
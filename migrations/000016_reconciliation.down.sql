@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reconciliations;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS initial_balance;
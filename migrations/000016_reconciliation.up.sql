@@ -0,0 +1,19 @@
+-- Balances the wallets are created with, the balance is reconciled with them and the recorded movements
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS initial_balance FLOAT;
+
+-- System wallets and pockets are created empty. The balance other existing wallets were created with is unknown and
+-- is set to the configured default balance before the reconciliation, so the drift they already have is reported
+UPDATE wallets SET initial_balance = 0 WHERE is_system OR parent_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS reconciliations
+(
+    id BIGSERIAL PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    wallets INT NOT NULL,
+    mismatched INT NOT NULL,
+    total_balance FLOAT NOT NULL,
+    total_initial_balance FLOAT NOT NULL,
+    conserved BOOLEAN NOT NULL,
+    mismatches JSONB NOT NULL
+);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS chain_heads;
+
+DROP TABLE IF EXISTS transaction_chains;
+
+DROP INDEX IF EXISTS transactions_unchained_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS hash;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS prev_hash;
@@ -0,0 +1,28 @@
+-- Every transaction is linked to the previous one by the hash of its content and the hash of the previous transaction
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS prev_hash TEXT;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS hash TEXT;
+
+-- Transactions recorded before the chain are chained by the chain job in the id order
+CREATE INDEX IF NOT EXISTS transactions_unchained_idx ON transactions (id) WHERE hash IS NULL;
+
+-- The last chained transaction, locking it orders the transactions in the chain
+CREATE TABLE IF NOT EXISTS transaction_chains
+(
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    transaction_id BIGINT NOT NULL DEFAULT 0,
+    hash TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO transaction_chains (id) VALUES (TRUE) ON CONFLICT DO NOTHING;
+
+-- Signed chain heads published for the auditors
+CREATE TABLE IF NOT EXISTS chain_heads
+(
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    hash TEXT NOT NULL,
+    signed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL
+);
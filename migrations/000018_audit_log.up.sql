@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS audit_entries
+(
+    id BIGSERIAL PRIMARY KEY,
+    time TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    actor VARCHAR(32) NOT NULL,
+    client_ip VARCHAR(64),
+    request_id VARCHAR(64),
+    operation TEXT NOT NULL,
+    wallet_id VARCHAR(36),
+    details JSONB,
+    before JSONB,
+    after JSONB,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS audit_entries_time_idx ON audit_entries (time);
+
+CREATE INDEX IF NOT EXISTS audit_entries_wallet_id_time_idx ON audit_entries (wallet_id, time);
+
+CREATE INDEX IF NOT EXISTS audit_entries_request_id_idx ON audit_entries (request_id);
+
+-- The log is append-only, entries are only deleted by the retention job
+CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log entries can not be changed';
+END;
+$$ LANGUAGE PLPGSQL;
+
+DROP TRIGGER IF EXISTS audit_entries_append_only ON audit_entries;
+
+CREATE TRIGGER audit_entries_append_only BEFORE UPDATE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
@@ -0,0 +1,9 @@
+ALTER TABLE transaction_chains DROP COLUMN IF EXISTS position;
+
+DROP INDEX IF EXISTS transactions_unchained_idx;
+
+CREATE INDEX IF NOT EXISTS transactions_unchained_idx ON transactions (id) WHERE hash IS NULL;
+
+DROP INDEX IF EXISTS transactions_chain_position_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS chain_position;
@@ -0,0 +1,15 @@
+-- Transactions are chained by the chain job after they are committed, so the chain order is stored separately
+-- from the id order. Transactions chained before were chained in the id order
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS chain_position BIGINT;
+
+UPDATE transactions SET chain_position = id WHERE hash IS NOT NULL AND chain_position IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS transactions_chain_position_idx ON transactions (chain_position);
+
+DROP INDEX IF EXISTS transactions_unchained_idx;
+
+CREATE INDEX IF NOT EXISTS transactions_unchained_idx ON transactions (id) WHERE chain_position IS NULL;
+
+ALTER TABLE transaction_chains ADD COLUMN IF NOT EXISTS position BIGINT NOT NULL DEFAULT 0;
+
+UPDATE transaction_chains SET position = transaction_id;
@@ -0,0 +1,5 @@
+ALTER TABLE wallets DROP COLUMN IF EXISTS event_sequence;
+
+DROP INDEX IF EXISTS wallet_events_wallet_id_sequence_idx;
+
+ALTER TABLE wallet_events DROP COLUMN IF EXISTS sequence;
@@ -0,0 +1,17 @@
+-- Event ids are taken on insert, not on commit, so a subscriber resumes by the sequence of the wallet events
+-- which is taken under the lock of the wallet row. Events stored before are numbered in the id order
+ALTER TABLE wallet_events ADD COLUMN IF NOT EXISTS sequence BIGINT;
+
+UPDATE wallet_events SET sequence = numbered.sequence
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY wallet_id ORDER BY id) AS sequence FROM wallet_events) AS numbered
+WHERE wallet_events.id = numbered.id;
+
+ALTER TABLE wallet_events ALTER COLUMN sequence SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS wallet_events_wallet_id_sequence_idx ON wallet_events (wallet_id, sequence);
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS event_sequence BIGINT NOT NULL DEFAULT 0;
+
+UPDATE wallets SET event_sequence = counted.sequence
+FROM (SELECT wallet_id, COUNT(*) AS sequence FROM wallet_events GROUP BY wallet_id) AS counted
+WHERE wallets.id = counted.wallet_id;
@@ -0,0 +1,2 @@
+-- Overdraft interest not charged because of the credit limit, it is charged when the credit allows
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS interest_debt FLOAT NOT NULL DEFAULT 0;
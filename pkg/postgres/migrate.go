@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// migrationLock - advisory lock held while migrating, so app instances started together don't race.
+const migrationLock = 7245100240
+
+var (
+	// migrationFile - <version>_<name>.up.sql or <version>_<name>.down.sql.
+	migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+	ErrMigrationChanged = errors.New("applied migration was changed")
+	ErrMigrationMissing = errors.New("applied migration is missing")
+)
+
+// Migration - numbered change of the schema with the sql to revert it.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// MigrationStatus - migration with the time it was applied, nil if it is pending.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// appliedMigration - row of the schema_migrations table.
+type appliedMigration struct {
+	tableName struct{} `pg:"schema_migrations"`
+
+	Version   int64 `pg:",pk"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// LoadMigrations - reading the migration files of the directory ordered by version.
+// Each migration must have an up file, the down file is optional.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		match := migrationFile.FindStringSubmatch(file.Name())
+		if file.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", file.Name(), err)
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		sql, err := fs.ReadFile(fsys, file.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.Up = string(sql)
+			sum := sha256.Sum256(sql)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// MigrateUp - applying the pending migrations in order of their versions, all of them or none.
+// Returns the applied migrations.
+func (p *Postgres) MigrateUp(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	applied := make([]Migration, 0)
+
+	err := p.migrate(ctx, migrations, func(tx *pg.Tx, done map[int64]appliedMigration) error {
+		for _, migration := range migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			if _, err := tx.Exec(migration.Up); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err := tx.Model(&appliedMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now(),
+			}).Insert()
+			if err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// MigrateDown - reverting the last n applied migrations in reverse order, all of them or none.
+// Returns the reverted migrations.
+func (p *Postgres) MigrateDown(ctx context.Context, migrations []Migration, n int) ([]Migration, error) {
+	reverted := make([]Migration, 0, n)
+
+	err := p.migrate(ctx, migrations, func(tx *pg.Tx, done map[int64]appliedMigration) error {
+		for i := len(migrations) - 1; i >= 0 && len(reverted) < n; i-- {
+			migration := migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			if _, err := tx.Exec(migration.Down); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err := tx.Model((*appliedMigration)(nil)).
+				Where("version = ?", migration.Version).
+				Delete()
+			if err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// MigrationStatus - getting the migrations with the time they were applied.
+func (p *Postgres) MigrationStatus(ctx context.Context, migrations []Migration) ([]MigrationStatus, error) {
+	statuses := make([]MigrationStatus, 0, len(migrations))
+
+	err := p.migrate(ctx, migrations, func(tx *pg.Tx, done map[int64]appliedMigration) error {
+		for _, migration := range migrations {
+			status := MigrationStatus{Migration: migration}
+			if applied, ok := done[migration.Version]; ok {
+				status.AppliedAt = &applied.AppliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// migrate - running fn under the migration lock with the applied migrations, which are checked against the files first.
+func (p *Postgres) migrate(ctx context.Context, migrations []Migration, fn func(tx *pg.Tx, done map[int64]appliedMigration) error) error {
+	return p.DB.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLock); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS schema_migrations
+			(
+				version BIGINT PRIMARY KEY,
+				name TEXT NOT NULL,
+				checksum CHAR(64) NOT NULL,
+				applied_at TIMESTAMP WITH TIME ZONE NOT NULL
+			)`)
+		if err != nil {
+			return err
+		}
+
+		applied := make([]appliedMigration, 0)
+		if err := tx.Model(&applied).Select(); err != nil {
+			return err
+		}
+		done, err := checkApplied(migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		return fn(tx, done)
+	})
+}
+
+// checkApplied - refusing to migrate if an applied migration was edited or removed.
+func checkApplied(migrations []Migration, applied []appliedMigration) (map[int64]appliedMigration, error) {
+	files := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+
+	done := make(map[int64]appliedMigration, len(applied))
+	for _, row := range applied {
+		migration, ok := files[row.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMigrationMissing, row.Version, row.Name)
+		}
+		if migration.Checksum != row.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMigrationChanged, row.Version, row.Name)
+		}
+		done[row.Version] = row
+	}
+
+	return done, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(fstest.MapFS{
+		"000002_accounts.up.sql": {Data: []byte("ALTER TABLE wallets ADD COLUMN account TEXT;")},
+		"000001_init.up.sql":     {Data: []byte("CREATE TABLE wallets (id TEXT);")},
+		"000001_init.down.sql":   {Data: []byte("DROP TABLE wallets;")},
+		"README.md":              {Data: []byte("not a migration")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	require.Equal(t, int64(1), migrations[0].Version)
+	require.Equal(t, "init", migrations[0].Name)
+	require.Equal(t, "DROP TABLE wallets;", migrations[0].Down)
+	require.Len(t, migrations[0].Checksum, 64)
+	require.Equal(t, int64(2), migrations[1].Version)
+	require.Empty(t, migrations[1].Down)
+
+	_, err = LoadMigrations(fstest.MapFS{
+		"000001_init.down.sql": {Data: []byte("DROP TABLE wallets;")},
+	})
+	require.Error(t, err)
+
+	// Migrations of the app can be applied and reverted
+	migrations, err = LoadMigrations(os.DirFS("../../migrations"))
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for _, migration := range migrations {
+		require.NotEmpty(t, migration.Down, migration.Name)
+	}
+}
+
+func Test_CheckApplied(t *testing.T) {
+	migrations, err := LoadMigrations(fstest.MapFS{
+		"000001_init.up.sql":     {Data: []byte("CREATE TABLE wallets (id TEXT);")},
+		"000002_accounts.up.sql": {Data: []byte("ALTER TABLE wallets ADD COLUMN account TEXT;")},
+	})
+	require.NoError(t, err)
+
+	done, err := checkApplied(migrations, []appliedMigration{
+		{Version: 1, Name: "init", Checksum: migrations[0].Checksum, AppliedAt: time.Now()},
+	})
+	require.NoError(t, err)
+	require.Contains(t, done, int64(1))
+	require.NotContains(t, done, int64(2))
+
+	// Applied migrations can't be edited or removed
+	_, err = checkApplied(migrations, []appliedMigration{
+		{Version: 1, Name: "init", Checksum: migrations[1].Checksum},
+	})
+	require.ErrorIs(t, err, ErrMigrationChanged)
+
+	_, err = checkApplied(migrations, []appliedMigration{
+		{Version: 3, Name: "removed", Checksum: migrations[0].Checksum},
+	})
+	require.ErrorIs(t, err, ErrMigrationMissing)
+}
@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"io/ioutil"
 
 	"github.com/go-pg/pg/v10"
 )
@@ -31,15 +30,3 @@ func New(url string) (*Postgres, error) {
 		DB: db,
 	}, nil
 }
-
-func (pg *Postgres) Migrate(filePath string) error {
-	c, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		return err
-	}
-	sql := string(c)
-	if _, err := pg.DB.Exec(sql); err != nil {
-		return err
-	}
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// txKey - context key of the transaction started by Atomic.
+type txKey struct{}
+
+// Atomic - running fn in one transaction. Repositories join it with RunInTransaction,
+// so a use case can combine several repository calls. Nested calls join the outer transaction.
+func (p *Postgres) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*pg.Tx); ok {
+		return fn(ctx)
+	}
+
+	return p.DB.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// RunInTransaction - running fn in the transaction of Atomic from the context or in a new one.
+func (p *Postgres) RunInTransaction(ctx context.Context, fn func(tx *pg.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*pg.Tx); ok {
+		return fn(tx)
+	}
+
+	return p.DB.RunInTransaction(ctx, fn)
+}
+
+// ReadSnapshot - running fn in one read only REPEATABLE READ transaction, so the repository reads made with its
+// context see the same state of the database. Nested calls join the outer transaction.
+func (p *Postgres) ReadSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*pg.Tx); ok {
+		return fn(ctx)
+	}
+
+	return p.DB.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY"); err != nil {
+			return err
+		}
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
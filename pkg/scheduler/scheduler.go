@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/egor-denisov/wallet-infotecs/pkg/logger"
+)
+
+// Job - periodic background task.
+type Job func(ctx context.Context) error
+
+// Scheduler - runs registered jobs at fixed intervals until stopped.
+type Scheduler struct {
+	l      logger.Interface
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New -.
+func New(l logger.Interface) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		l:      l,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Every - starting the job in the background, first run happens after one interval.
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(s.ctx); err != nil {
+					s.l.Error(fmt.Errorf("scheduler - %s: %w", name, err))
+				}
+			}
+		}
+	}()
+}
+
+// Stop - cancelling running jobs and waiting for them to finish.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}